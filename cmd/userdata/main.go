@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/Tap-Team/timerapi/internal/app"
+)
+
+func main() {
+	app.UserData(os.Args[1:])
+}
@@ -21,39 +21,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/notifications": {
+        "/calendar/feed": {
             "get": {
-                "description": "get user unread notifications, notifications include delete or expire timer",
+                "description": "get url of user calendar feed, feed contains all user timers and subscriptions, url is created on first request and should be kept in secret",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "calendar"
                 ],
-                "summary": "NotificationsByUser",
+                "summary": "Feed",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "user id",
                         "name": "vk_user_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/notification.NotificationDTO"
-                            }
+                            "$ref": "#/definitions/calendarmodel.Feed"
                         }
                     },
                     "400": {
@@ -62,12 +59,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -75,43 +66,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete all user notifications",
+            }
+        },
+        "/calendar/feed/reset": {
+            "post": {
+                "description": "create new url of user calendar feed, previous url stops working",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "calendar"
                 ],
-                "summary": "Delete",
+                "summary": "ResetFeed",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "user id",
                         "name": "vk_user_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                            "$ref": "#/definitions/calendarmodel.Feed"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
@@ -125,19 +115,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/create": {
+        "/calendar/import": {
             "post": {
-                "description": "create user timer",
+                "description": "create date timers from events of iCalendar file, file is sent as multipart form field \"file\" or as raw body\nevent start becomes timer end, recurring event becomes timer of next occurrence, past events are skipped, name and description are truncated\nreturns report of every event with status created, skipped or rejected, reason and notes about changes of event",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data",
+                    "text/calendar"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "calendar"
                 ],
-                "summary": "CreateTimer",
+                "summary": "Import",
                 "parameters": [
                     {
                         "type": "string",
@@ -153,27 +144,36 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "timer",
-                        "name": "timer",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/timermodel.CreateTimer"
-                        }
+                        "type": "string",
+                        "description": "IANA time zone of events without time zone, e.g. Europe/Berlin, utc is ignored if set",
+                        "name": "timeZone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "utc offset in hours of events without time zone, from -12 to 14, default 0",
+                        "name": "utc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "ics file",
+                        "name": "file",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/calendarmodel.ImportResult"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
@@ -187,42 +187,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/user": {
+        "/calendar/{token}": {
             "get": {
-                "description": "get all user timers with offset and limit, timers include created by user and user subscriptions",
+                "description": "live iCalendar feed of user timers, doesn't require vk launch params, token is secret part of feed url",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "timers"
+                    "calendar"
                 ],
-                "summary": "TimersByUser",
+                "summary": "FeedCalendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "vk_user_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
+                        "description": "feed token, .ics extension is allowed",
+                        "name": "token",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -230,23 +210,43 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/timermodel.Timer"
-                            }
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/colors": {
+            "get": {
+                "description": "palette of timer colors in palette order, color name is used as timer color\ntimer can also have custom hex color with optional gradient, clients which don't know custom colors show palette color",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "colors"
+                ],
+                "summary": "Colors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/colormodel.Color"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -257,16 +257,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/user-created": {
+        "/folders": {
             "get": {
-                "description": "get user created timers with offset and limit",
+                "description": "get user folders in manual order with count of timers in every folder",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "UserCreated",
+                "summary": "Folders",
                 "parameters": [
                     {
                         "type": "string",
@@ -280,20 +280,6 @@ const docTemplate = `{
                         "name": "vk_user_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -302,7 +288,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/timermodel.Timer"
+                                "$ref": "#/definitions/foldermodel.Folder"
                             }
                         }
                     },
@@ -312,12 +298,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -325,18 +305,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/timers/user-subscriptions": {
-            "get": {
-                "description": "get user subscriptions with offset and limit",
+            },
+            "post": {
+                "description": "create user folder, new folder is placed after other folders",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "UserSubscriptions",
+                "summary": "CreateFolder",
                 "parameters": [
                     {
                         "type": "string",
@@ -352,28 +333,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "folder",
+                        "name": "folder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.FolderSettings"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/timermodel.Timer"
-                            }
+                            "$ref": "#/definitions/foldermodel.Folder"
                         }
                     },
                     "400": {
@@ -382,12 +355,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -397,16 +364,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/{id}": {
-            "get": {
-                "description": "\"returns timer by param id\"",
-                "produces": [
+        "/folders/order": {
+            "put": {
+                "description": "save manual order of user folders, first id goes first, ids of not owned folders are skipped",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "TimerById",
+                "summary": "OrderFolders",
                 "parameters": [
                     {
                         "type": "string",
@@ -415,19 +382,25 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "timer id",
-                        "name": "id",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "folder ids",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Order"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/timermodel.Timer"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -435,12 +408,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -448,9 +415,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/folders/{id}": {
             "put": {
-                "description": "update user timer",
+                "description": "rename user folder",
                 "consumes": [
                     "application/json"
                 ],
@@ -458,9 +427,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "UpdateTimer",
+                "summary": "RenameFolder",
                 "parameters": [
                     {
                         "type": "string",
@@ -477,24 +446,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "timer id",
+                        "description": "folder id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "timer update settings",
-                        "name": "settings",
+                        "description": "folder",
+                        "name": "folder",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/timermodel.TimerSettings"
+                            "$ref": "#/definitions/foldermodel.FolderSettings"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Folder"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -502,6 +474,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -517,14 +495,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "delete user timer",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "delete user folder, timers of folder are not deleted and moved out of folders",
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "DeleteTimer",
+                "summary": "DeleteFolder",
                 "parameters": [
                     {
                         "type": "string",
@@ -541,7 +516,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "timer id",
+                        "description": "folder id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -557,6 +532,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -572,16 +553,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/{id}/reset": {
-            "patch": {
-                "description": "reset timer by timer id, only owner can reset timer, every subscriber (creator inclusive) will be send reset event, if timer is started, reset not pause, only update end time",
+        "/healthz": {
+            "get": {
+                "description": "liveness probe, process is alive if it responds, dependencies aren't checked",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "health"
                 ],
-                "summary": "ResetTimer",
+                "summary": "Healthz",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/healthmodel.Liveness"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics": {
+            "get": {
+                "description": "prometheus metrics in text exposition format",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "metrics"
+                ],
+                "summary": "Metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "get user unread notifications, notifications include delete or expire timer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "NotificationsByUser",
                 "parameters": [
                     {
                         "type": "integer",
@@ -595,20 +616,16 @@ const docTemplate = `{
                         "description": "you can add secret key to query for debug requests",
                         "name": "debug",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "timer id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/timermodel.Timer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notification.NotificationDTO"
+                            }
                         }
                     },
                     "400": {
@@ -630,18 +647,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/timers/{id}/start": {
-            "patch": {
-                "description": "start timer by timer id, only owner can start timer, every subscriber (creator inclusive) will be send start event",
+            },
+            "delete": {
+                "description": "delete all user notifications",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "notifications"
                 ],
-                "summary": "StartTimer",
+                "summary": "Delete",
                 "parameters": [
                     {
                         "type": "integer",
@@ -655,21 +670,11 @@ const docTemplate = `{
                         "description": "you can add secret key to query for debug requests",
                         "name": "debug",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "timer id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/timermodel.Timer"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -692,56 +697,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/{id}/stop": {
-            "patch": {
-                "description": "stop timer by timer id, only owner can stop timer, every subscriber (creator inclusive) will be send stop event",
-                "tags": [
-                    "timers"
+        "/readyz": {
+            "get": {
+                "description": "readiness probe, checks postgres pool, redis ping, ticker grpc connection and tick stream\nevery dependency is returned with status, latency of check in milliseconds, info and error\ntick stream is down if stream is closed, or if last receive is older than ticker max_tick_age when it is set",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "StopTimer",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "vk_user_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "pause time, 1690465114",
-                        "name": "pauseTime",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "timer id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "health"
                 ],
+                "summary": "Readyz",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                            "$ref": "#/definitions/healthmodel.Readiness"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                            "$ref": "#/definitions/healthmodel.Readiness"
+                        }
+                    }
+                }
+            }
+        },
+        "/sounds": {
+            "get": {
+                "description": "catalog of alarm sounds in catalog order, sound id is used as timer soundId",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sounds"
+                ],
+                "summary": "Sounds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/soundmodel.Sound"
+                            }
                         }
                     },
                     "500": {
@@ -753,43 +752,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/{id}/subscribe": {
-            "post": {
-                "description": "subscribe user on timer by id, user will see timer in subscriptions, get events and notificaitons",
+        "/tags": {
+            "get": {
+                "description": "get user tags ordered by name with count of tagged timers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "Subscribe",
+                "summary": "Tags",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "user id",
                         "name": "vk_user_id",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "timer id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/timermodel.Timer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/foldermodel.TagInfo"
+                            }
                         }
                     },
                     "400": {
@@ -798,12 +793,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echoconfig.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echoconfig.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -813,16 +802,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/timers/{id}/subscribers": {
-            "get": {
-                "description": "return array of id users which subscribe on timer",
-                "produces": [
+        "/tags/{tag}": {
+            "put": {
+                "description": "rename user tag on every timer, tag is merged with existing tag of same name",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "TimerSubscribers",
+                "summary": "RenameTag",
                 "parameters": [
                     {
                         "type": "string",
@@ -839,21 +828,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "timer id",
-                        "name": "id",
+                        "description": "tag, path escaped",
+                        "name": "tag",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "new name",
+                        "name": "rename",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.RenameTag"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -874,19 +866,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/timers/{id}/unsubscribe": {
+            },
             "delete": {
-                "description": "unsubscribe user on timer by id, user wont see timer in subscriptions, get events and notificaitons",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "remove user tag from every timer",
                 "tags": [
-                    "timers"
+                    "folders"
                 ],
-                "summary": "Unsubscribe",
+                "summary": "DeleteTag",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "user id",
@@ -896,16 +889,10 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "timer id",
-                        "name": "id",
+                        "description": "tag, path escaped",
+                        "name": "tag",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "you can add secret key to query for debug requests",
-                        "name": "debug",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -933,20 +920,2838 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws/timer": {
+        "/time": {
             "get": {
+                "description": "NTP-style clock sync, server is source of truth for timer times, all times are unix milliseconds\nwith clientReceiveTime as time when response is got\noffset = ((serverReceiveTime - clientSendTime) + (serverSendTime - clientReceiveTime)) / 2\ndelay = (clientReceiveTime - clientSendTime) - (serverSendTime - serverReceiveTime)\ndrift is change of offset between syncs, same exchange is available in websocket with event_clock_sync",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ws"
+                    "clock"
                 ],
-                "summary": "Websocket",
+                "summary": "Time",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user id",
-                        "name": "vk_user_id",
+                        "description": "client time of request, unix milliseconds",
+                        "name": "clientSendTime",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timerevent.ClockSyncEvent"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/batch/create": {
+            "post": {
+                "description": "create up to 100 user timers in one transaction\natomic batch creates all timers or nothing, otherwise every timer is created independently",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "BatchCreate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timers",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/batch/delete": {
+            "post": {
+                "description": "delete up to 100 user timers in one transaction\natomic batch deletes all timers or nothing, otherwise every timer is deleted independently",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "BatchDelete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timer ids",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchDelete"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/batch/subscribe": {
+            "post": {
+                "description": "subscribe up to 100 users to timer in one transaction, only timer creator can subscribe users\natomic batch subscribes all users or nobody, otherwise every user is subscribed independently",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "BatchSubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timer id and user ids",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchSubscribers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/batch/unsubscribe": {
+            "post": {
+                "description": "unsubscribe up to 100 users from timer in one transaction, only timer creator can unsubscribe users\natomic batch unsubscribes all users or nobody, otherwise every user is unsubscribed independently",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "BatchUnsubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timer id and user ids",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchSubscribers"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/create": {
+            "post": {
+                "description": "create user timer\ncolor is name from /colors palette, customColor is hex color with optional gradient, color is DEFAULT if only customColor is set\nsoundId is id from /sounds catalog, withMusic without soundId sets default sound, empty volume is max volume",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "CreateTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timer",
+                        "name": "timer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.CreateTimer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/order": {
+            "put": {
+                "description": "save manual order of user timers, usually timers of one folder, first id goes first\norder is private for user, ids of timers which user isn't subscribed on are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "OrderTimers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "timer ids",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Order"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/user": {
+            "get": {
+                "description": "get all user timers with offset and limit, timers include created by user and user subscriptions\npinned timers go first, then timers in manual order, then other timers by creation time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "TimersByUser",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers in user folder",
+                        "name": "folderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers with user tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timermodel.Timer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/user-created": {
+            "get": {
+                "description": "get user created timers with offset and limit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "UserCreated",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers in user folder",
+                        "name": "folderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers with user tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timermodel.Timer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/user-subscriptions": {
+            "get": {
+                "description": "get user subscriptions with offset and limit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "UserSubscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers in user folder",
+                        "name": "folderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only timers with user tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timermodel.Timer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}": {
+            "get": {
+                "description": "\"returns timer by param id\"\nwith vk_user_id relation and subscribedAt of user are returned, timer version is returned in ETag",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "TimerById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update user timer, new timer version is returned in ETag\ncustomColor isn't changed if it is absent, it is cleared by merge patch with null customColor\nsoundId and volume aren't changed if they are absent, withMusic false makes timer silent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "UpdateTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "timer update settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.TimerSettings"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, * matches any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete user timer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "DeleteTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "partial update of user timer with RFC 7386 merge patch, only fields of patch are validated and updated\nnull name, description or customColor clears it, null soundId makes timer silent, null of other fields is not allowed\nupdate event contains only changed fields, new timer version is returned in ETag",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "PatchTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "timer merge patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.TimerPatch"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, version isn't checked without header",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}.ics": {
+            "get": {
+                "description": "export timer as iCalendar file, date timer is event at end time, countdown timer is event of current run, paused countdown timer can't be exported",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "TimerCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/adjust": {
+            "patch": {
+                "description": "add or subtract time of running or paused countdown timer, end time and duration are shifted by delta\ntime left and duration after shift should be not less than min timer duration, every subscriber (creator inclusive) will be send adjust event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "AdjustTimer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "seconds to add, negative to subtract, e.g. 300 or -60, absolute value not greater than 10 years",
+                        "name": "delta",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, * matches any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/bans/{userId}": {
+            "delete": {
+                "description": "creator allows banned user to subscribe again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "Unban",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "banned user id",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/clone": {
+            "post": {
+                "description": "create own copy of timer with new id, copy has same name, duration, color, music and sound, countdown of copy can be reset or end with original timer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "CloneTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "copy options",
+                        "name": "clone",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.CloneTimer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/folder": {
+            "put": {
+                "description": "move timer into user folder, null folderId moves timer out of folders, only subscribers (creator inclusive) can move timer\nfolder is private for user, moved timer goes after manually ordered timers of folder",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "MoveTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "folder",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Move"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/pin": {
+            "put": {
+                "description": "pin timer, pinned timers go first in user timer lists, pin is private for user",
+                "tags": [
+                    "folders"
+                ],
+                "summary": "PinTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "unpin timer",
+                "tags": [
+                    "folders"
+                ],
+                "summary": "UnpinTimer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/reset": {
+            "patch": {
+                "description": "reset timer by timer id, only owner can reset timer, every subscriber (creator inclusive) will be send reset event, if timer is started, reset not pause, only update end time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "ResetTimer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, * matches any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/sound": {
+            "put": {
+                "description": "pick alarm sound of timer for user, it is played instead of timer sound and returned in subscriberSound of timer\nsound is private for user, only subscribers (creator inclusive) can pick sound, empty volume is max volume",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sounds"
+                ],
+                "summary": "SetSubscriberSound",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "sound",
+                        "name": "sound",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.SubscriberSound"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "return user to timer sound",
+                "tags": [
+                    "sounds"
+                ],
+                "summary": "ResetSubscriberSound",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/start": {
+            "patch": {
+                "description": "start timer by timer id, only owner can start timer, every subscriber (creator inclusive) will be send start event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "StartTimer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, * matches any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/stop": {
+            "patch": {
+                "description": "stop timer by timer id, only owner can stop timer, every subscriber (creator inclusive) will be send stop event\nserver time is used as pause time, new timer version is returned in ETag",
+                "tags": [
+                    "timers"
+                ],
+                "summary": "StopTimer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "deprecated, ignored, server time is used",
+                        "name": "pauseTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer version from ETag, * matches any version",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/timerhandler.VersionConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/subscribe": {
+            "post": {
+                "description": "subscribe user on timer by id, user will see timer in subscriptions, get events and notificaitons",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "Subscribe",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.Timer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/subscribers": {
+            "get": {
+                "description": "return array of id users which subscribe on timer",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "TimerSubscribers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/subscribers/limit": {
+            "get": {
+                "description": "max subscribers of timer and current subscribers count, 0 max subscribers is unlimited",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "SubscribersLimit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.SubscribersLimit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "set max subscribers of timer, 0 is unlimited, current subscribers over limit aren't removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "SetSubscribersLimit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "limit, count is ignored",
+                        "name": "limit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.SubscribersLimit"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/timermodel.SubscribersLimit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/subscribers/list": {
+            "get": {
+                "description": "page of timer subscribers with subscription time ordered by subscription time, creator isn't listed\nonly timer creator can get list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "TimerSubscribersPage",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/timermodel.Subscriber"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/subscribers/{userId}": {
+            "delete": {
+                "description": "creator removes subscriber from timer, removed user gets notification_removed notification\nwith ban=true user also can't subscribe again, user which isn't subscribed can be banned too",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "RemoveSubscriber",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "subscriber id",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "ban user",
+                        "name": "ban",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/tags": {
+            "put": {
+                "description": "replace user tags of timer, tags are private, subscriber can tag timer of other user\ntags are trimmed, duplicates are removed, empty list removes all tags of timer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "SetTimerTags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Tags"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/foldermodel.Tags"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timers/{id}/unsubscribe": {
+            "delete": {
+                "description": "unsubscribe user on timer by id, user wont see timer in subscriptions, get events and notificaitons",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "timers"
+                ],
+                "summary": "Unsubscribe",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "timer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-data": {
+            "get": {
+                "description": "json archive of all user data: created timers, subscriptions, unread notifications, webhooks and calendar feed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-data"
+                ],
+                "summary": "Export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/userdatamodel.Archive"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "erase all user data, created timers are deleted and subscribers get delete notification, user is unsubscribed from all timers\nrequest can be repeated, failed erasure is continued from unfinished step",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-data"
+                ],
+                "summary": "Erase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/userdatamodel.Erasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-data/erasure": {
+            "get": {
+                "description": "state of last user data erasure",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-data"
+                ],
+                "summary": "Erasure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/userdatamodel.Erasure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "get user webhooks, secrets are hidden",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Webhooks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhookmodel.Webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "register https endpoint on public address which receive timer events of user timers and subscriptions, secret is returned only in this response, every request is signed with X-Timer-Signature header, sha256=hex(hmac_sha256(secret, X-Timer-Timestamp + \".\" + body))",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "CreateWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook, events: expire, delete, update, stop, start, reset, adjust",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhookmodel.CreateWebhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhookmodel.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "delete user webhook with delivery log",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "DeleteWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "delivery log of webhook, every attempt is separate record, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhookmodel.Delivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/enable": {
+            "patch": {
+                "description": "enable webhook which was disabled after repeated failed deliveries",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "EnableWebhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "you can add secret key to query for debug requests",
+                        "name": "debug",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "webhook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echoconfig.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/timer": {
+            "get": {
+                "description": "on server shutdown connection is closed with code 1012 (service restart) and text \"reconnect\", client should reconnect",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ws"
+                ],
+                "summary": "Websocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "vk_user_id",
                         "in": "query",
                         "required": true
                     },
@@ -957,12 +3762,12 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "description": "event to add\\remove timers from event stream",
+                        "description": "event to add\\remove timers from event stream or event_clock_sync with clientSendTime",
                         "name": "event",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/timerevent.SubscribeEvent"
+                            "$ref": "#/definitions/timerevent.ClientEvent"
                         }
                     }
                 ],
@@ -996,23 +3801,245 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/timerevent.UpdateEvent"
                         }
+                    },
+                    "205": {
+                        "description": "update event of merge patch, only changed fields",
+                        "schema": {
+                            "$ref": "#/definitions/timerevent.PatchEvent"
+                        }
+                    },
+                    "206": {
+                        "description": "response to event_clock_sync",
+                        "schema": {
+                            "$ref": "#/definitions/timerevent.ClockSyncEvent"
+                        }
+                    },
+                    "207": {
+                        "description": "adjust event",
+                        "schema": {
+                            "$ref": "#/definitions/timerevent.AdjustEvent"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "calendarmodel.Feed": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "calendarmodel.ImportResult": {
+            "type": "object",
+            "properties": {
+                "endTime": {
+                    "description": "end time of created timer",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "changes made to fit event into timer, e.g. truncated name or next occurrence of recurring event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "description": "error code or reason of skip, empty for created timers",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/calendarmodel.ImportStatus"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "timerId": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "calendarmodel.ImportStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "skipped",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "Created",
+                "Skipped",
+                "Rejected"
+            ]
+        },
+        "colormodel.Color": {
+            "type": "object",
+            "properties": {
+                "gradient": {
+                    "type": "string"
+                },
+                "hex": {
+                    "type": "string"
+                },
+                "name": {
+                    "$ref": "#/definitions/timerfields.Color"
+                }
+            }
+        },
+        "echoconfig.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "foldermodel.Folder": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "manual order of folder",
+                    "type": "integer"
+                },
+                "timersCount": {
+                    "description": "count of not deleted timers in folder",
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "foldermodel.FolderSettings": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "foldermodel.Move": {
+            "type": "object",
+            "properties": {
+                "folderId": {
+                    "type": "string"
+                }
+            }
+        },
+        "foldermodel.Order": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "foldermodel.RenameTag": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "foldermodel.TagInfo": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "timersCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "foldermodel.Tags": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "echoconfig.ErrorResponse": {
+        },
+        "healthmodel.Dependency": {
             "type": "object",
             "properties": {
-                "code": {
+                "error": {
                     "type": "string"
                 },
-                "message": {
+                "info": {
+                    "description": "state of dependency, e.g. connection state or time of last receive",
                     "type": "string"
+                },
+                "latency": {
+                    "description": "duration of check in milliseconds",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/healthmodel.Status"
+                }
+            }
+        },
+        "healthmodel.Liveness": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/healthmodel.Status"
                 }
             }
         },
+        "healthmodel.Readiness": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/healthmodel.Dependency"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/healthmodel.Status"
+                }
+            }
+        },
+        "healthmodel.Status": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "UP",
+                "DOWN"
+            ]
+        },
         "notification.NotificationDTO": {
             "type": "object",
             "properties": {
@@ -1028,13 +4055,89 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "notification_expired",
-                "notification_delete"
+                "notification_delete",
+                "notification_removed"
             ],
             "x-enum-varnames": [
                 "Expired",
-                "Delete"
+                "Delete",
+                "Removed"
             ]
         },
+        "soundmodel.Sound": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "description": "duration in seconds",
+                    "type": "integer"
+                },
+                "id": {
+                    "$ref": "#/definitions/timerfields.SoundId"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "path of asset on static host of app",
+                    "type": "string"
+                }
+            }
+        },
+        "timerevent.AdjustEvent": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "integer"
+                },
+                "timerId": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/timerevent.EventType"
+                }
+            }
+        },
+        "timerevent.ClientEvent": {
+            "type": "object",
+            "properties": {
+                "clientSendTime": {
+                    "description": "client time of clock sync request, unix milliseconds",
+                    "type": "integer"
+                },
+                "timerIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/timerevent.EventType"
+                }
+            }
+        },
+        "timerevent.ClockSyncEvent": {
+            "type": "object",
+            "properties": {
+                "clientSendTime": {
+                    "type": "integer"
+                },
+                "serverReceiveTime": {
+                    "type": "integer"
+                },
+                "serverSendTime": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/timerevent.EventType"
+                }
+            }
+        },
         "timerevent.EventType": {
             "type": "string",
             "enum": [
@@ -1043,7 +4146,9 @@ const docTemplate = `{
                 "event_start",
                 "event_subscribe",
                 "event_unsubscribe",
-                "event_reset"
+                "event_reset",
+                "event_clock_sync",
+                "event_adjust"
             ],
             "x-enum-varnames": [
                 "Update",
@@ -1051,9 +4156,56 @@ const docTemplate = `{
                 "Start",
                 "Subscribe",
                 "Unsubscribe",
-                "Reset"
+                "Reset",
+                "ClockSync",
+                "Adjust"
             ]
         },
+        "timerevent.PatchEvent": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "$ref": "#/definitions/timerfields.Color"
+                },
+                "customColor": {
+                    "description": "custom color with empty hex clears custom color",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.CustomColor"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "soundId": {
+                    "description": "sound 0 makes timer silent, withMusic is set by sound",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.SoundId"
+                        }
+                    ]
+                },
+                "timerId": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/timerevent.EventType"
+                },
+                "volume": {
+                    "$ref": "#/definitions/timerfields.Volume"
+                },
+                "withMusic": {
+                    "type": "boolean"
+                }
+            }
+        },
         "timerevent.ResetEvent": {
             "type": "object",
             "properties": {
@@ -1099,81 +4251,264 @@ const docTemplate = `{
                 }
             }
         },
-        "timerevent.SubscribeEvent": {
+        "timerevent.UpdateEvent": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "description": "palette color, DEFAULT if empty and custom color is set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Color"
+                        }
+                    ]
+                },
+                "customColor": {
+                    "description": "custom color isn't changed if it is null, it is cleared by merge patch",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.CustomColor"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "soundId": {
+                    "$ref": "#/definitions/timerfields.SoundId"
+                },
+                "timerId": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/timerevent.EventType"
+                },
+                "volume": {
+                    "description": "volume isn't changed if it is empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Volume"
+                        }
+                    ]
+                },
+                "withMusic": {
+                    "description": "sound of timer with music isn't changed if soundId is empty, default sound is set if timer had no sound",
+                    "type": "boolean"
+                }
+            }
+        },
+        "timerfields.Color": {
+            "type": "string",
+            "enum": [
+                "DEFAULT",
+                "RED",
+                "GREEN",
+                "BLUE",
+                "PURPLE",
+                "YELLOW"
+            ],
+            "x-enum-varnames": [
+                "DEFAULT",
+                "RED",
+                "GREEN",
+                "BLUE",
+                "PURPLE",
+                "YELLOW"
+            ]
+        },
+        "timerfields.CustomColor": {
+            "type": "object",
+            "properties": {
+                "gradient": {
+                    "type": "string"
+                },
+                "hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "timerfields.Relation": {
+            "type": "string",
+            "enum": [
+                "owner",
+                "subscriber",
+                "none"
+            ],
+            "x-enum-varnames": [
+                "OWNER",
+                "SUBSCRIBER",
+                "NONE"
+            ]
+        },
+        "timerfields.SoundId": {
+            "type": "integer",
+            "enum": [
+                1
+            ],
+            "x-enum-varnames": [
+                "DefaultSound"
+            ]
+        },
+        "timerfields.TimeZone": {
+            "type": "string",
+            "enum": [
+                "UTC"
+            ],
+            "x-enum-varnames": [
+                "UTCZone"
+            ]
+        },
+        "timerfields.Type": {
+            "type": "string",
+            "enum": [
+                "COUNTDOWN",
+                "DATE"
+            ],
+            "x-enum-varnames": [
+                "COUNTDOWN",
+                "DATE"
+            ]
+        },
+        "timerfields.Volume": {
+            "type": "integer",
+            "enum": [
+                1,
+                100
+            ],
+            "x-enum-varnames": [
+                "MinVolume",
+                "MaxVolume"
+            ]
+        },
+        "timerhandler.VersionConflictResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "timer": {
+                    "$ref": "#/definitions/timermodel.Timer"
+                }
+            }
+        },
+        "timermodel.BatchCreate": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "timers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timermodel.CreateTimer"
+                    }
+                }
+            }
+        },
+        "timermodel.BatchDelete": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "timerIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "timermodel.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "error code of failed item, \"timer_batch_aborted\" for items rolled back by atomic batch",
+                    "type": "string"
+                },
+                "ok": {
+                    "type": "boolean"
+                },
+                "timerId": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "timermodel.BatchResult": {
             "type": "object",
             "properties": {
-                "timerIds": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/timermodel.BatchItemResult"
                     }
                 },
-                "type": {
-                    "$ref": "#/definitions/timerevent.EventType"
+                "succeeded": {
+                    "description": "count of committed items",
+                    "type": "integer"
                 }
             }
         },
-        "timerevent.UpdateEvent": {
+        "timermodel.BatchSubscribers": {
             "type": "object",
             "properties": {
-                "color": {
-                    "$ref": "#/definitions/timerfields.Color"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "endTime": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "atomic": {
+                    "type": "boolean"
                 },
                 "timerId": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/timerevent.EventType"
+                "userIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "timermodel.CloneTimer": {
+            "type": "object",
+            "properties": {
+                "keepSettings": {
+                    "description": "copy description of original timer, otherwise copy has empty description\nname, duration, color, music and sound are copied always",
+                    "type": "boolean"
                 },
-                "withMusic": {
+                "reset": {
+                    "description": "countdown of copy starts now with duration of original timer, otherwise copy ends with original timer\ndate timer always ends with original timer",
                     "type": "boolean"
                 }
             }
         },
-        "timerfields.Color": {
-            "type": "string",
-            "enum": [
-                "DEFAULT",
-                "RED",
-                "GREEN",
-                "BLUE",
-                "PURPLE",
-                "YELLOW"
-            ],
-            "x-enum-varnames": [
-                "DEFAULT",
-                "RED",
-                "GREEN",
-                "BLUE",
-                "PURPLE",
-                "YELLOW"
-            ]
-        },
-        "timerfields.Type": {
-            "type": "string",
-            "enum": [
-                "COUNTDOWN",
-                "DATE"
-            ],
-            "x-enum-varnames": [
-                "COUNTDOWN",
-                "DATE"
-            ]
-        },
         "timermodel.CreateTimer": {
             "type": "object",
             "properties": {
                 "color": {
-                    "$ref": "#/definitions/timerfields.Color"
+                    "description": "palette color, DEFAULT if empty and custom color is set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Color"
+                        }
+                    ]
+                },
+                "customColor": {
+                    "$ref": "#/definitions/timerfields.CustomColor"
                 },
                 "description": {
                     "type": "string"
@@ -1187,20 +4522,80 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "soundId": {
+                    "$ref": "#/definitions/timerfields.SoundId"
+                },
                 "startTime": {
                     "type": "integer"
                 },
+                "timeZone": {
+                    "description": "IANA time zone, if empty zone is taken from utc",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.TimeZone"
+                        }
+                    ]
+                },
                 "type": {
                     "$ref": "#/definitions/timerfields.Type"
                 },
                 "utc": {
                     "type": "integer"
                 },
+                "volume": {
+                    "description": "max volume if empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Volume"
+                        }
+                    ]
+                },
                 "withMusic": {
+                    "description": "default sound is set if only withMusic is set",
                     "type": "boolean"
                 }
             }
         },
+        "timermodel.Subscriber": {
+            "type": "object",
+            "properties": {
+                "subscribedAt": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "timermodel.SubscriberSound": {
+            "type": "object",
+            "properties": {
+                "soundId": {
+                    "$ref": "#/definitions/timerfields.SoundId"
+                },
+                "volume": {
+                    "description": "max volume if empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Volume"
+                        }
+                    ]
+                }
+            }
+        },
+        "timermodel.SubscribersLimit": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "current count of subscribers, ignored in requests",
+                    "type": "integer"
+                },
+                "maxSubscribers": {
+                    "description": "0 is unlimited",
+                    "type": "integer"
+                }
+            }
+        },
         "timermodel.Timer": {
             "type": "object",
             "properties": {
@@ -1210,6 +4605,14 @@ const docTemplate = `{
                 "creator": {
                     "type": "integer"
                 },
+                "customColor": {
+                    "description": "custom color shown instead of palette color by clients which know custom colors",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.CustomColor"
+                        }
+                    ]
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1219,35 +4622,107 @@ const docTemplate = `{
                 "endTime": {
                     "type": "integer"
                 },
+                "folderId": {
+                    "description": "folder, pin and tags of user who requested timer, private for this user",
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "isPaused": {
                     "type": "boolean"
                 },
+                "isPinned": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string"
                 },
+                "offset": {
+                    "description": "current offset of time zone in seconds, DST is included",
+                    "type": "integer"
+                },
                 "pauseTime": {
                     "type": "integer"
                 },
+                "relation": {
+                    "description": "relation of user who requested timer, empty if timer isn't requested by user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Relation"
+                        }
+                    ]
+                },
+                "soundId": {
+                    "description": "sound of alarm from sounds catalog, empty if timer is silent",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.SoundId"
+                        }
+                    ]
+                },
+                "subscribedAt": {
+                    "type": "integer"
+                },
+                "subscriberSound": {
+                    "description": "sound picked by user who requested timer, played instead of timer sound",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timermodel.SubscriberSound"
+                        }
+                    ]
+                },
+                "subscribersCount": {
+                    "description": "count of subscribers except creator",
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timeZone": {
+                    "description": "IANA time zone of timer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.TimeZone"
+                        }
+                    ]
+                },
                 "type": {
                     "$ref": "#/definitions/timerfields.Type"
                 },
                 "utc": {
                     "type": "integer"
                 },
+                "version": {
+                    "description": "incremented by every change of timer, returned as ETag",
+                    "type": "integer"
+                },
+                "volume": {
+                    "$ref": "#/definitions/timerfields.Volume"
+                },
                 "withMusic": {
+                    "description": "true if timer has sound, kept for clients which don't know sounds",
                     "type": "boolean"
                 }
             }
         },
-        "timermodel.TimerSettings": {
+        "timermodel.TimerPatch": {
             "type": "object",
             "properties": {
                 "color": {
                     "$ref": "#/definitions/timerfields.Color"
                 },
+                "customColor": {
+                    "description": "custom color with empty hex clears custom color",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.CustomColor"
+                        }
+                    ]
+                },
                 "description": {
                     "type": "string"
                 },
@@ -1257,8 +4732,225 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "soundId": {
+                    "description": "sound 0 makes timer silent, withMusic is set by sound",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.SoundId"
+                        }
+                    ]
+                },
+                "volume": {
+                    "$ref": "#/definitions/timerfields.Volume"
+                },
+                "withMusic": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "timermodel.TimerSettings": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "description": "palette color, DEFAULT if empty and custom color is set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Color"
+                        }
+                    ]
+                },
+                "customColor": {
+                    "description": "custom color isn't changed if it is null, it is cleared by merge patch",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.CustomColor"
+                        }
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "soundId": {
+                    "$ref": "#/definitions/timerfields.SoundId"
+                },
+                "volume": {
+                    "description": "volume isn't changed if it is empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/timerfields.Volume"
+                        }
+                    ]
+                },
                 "withMusic": {
+                    "description": "sound of timer with music isn't changed if soundId is empty, default sound is set if timer had no sound",
+                    "type": "boolean"
+                }
+            }
+        },
+        "userdatamodel.Archive": {
+            "type": "object",
+            "properties": {
+                "calendarFeed": {
+                    "description": "calendar feed, nil if user never requested feed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/calendarmodel.Feed"
+                        }
+                    ]
+                },
+                "exportedAt": {
+                    "type": "integer"
+                },
+                "notifications": {
+                    "description": "unread notifications",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification.NotificationDTO"
+                    }
+                },
+                "subscriptions": {
+                    "description": "timers of other users which user subscribed to",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timermodel.Timer"
+                    }
+                },
+                "timers": {
+                    "description": "timers created by user",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timermodel.Timer"
+                    }
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "description": "webhooks without secrets",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhookmodel.Webhook"
+                    }
+                }
+            }
+        },
+        "userdatamodel.Erasure": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean"
+                },
+                "completedAt": {
+                    "description": "zero while erasure isn't completed",
+                    "type": "string"
+                },
+                "requestedAt": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "webhookmodel.CreateWebhook": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhookmodel.EventType"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhookmodel.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/webhookmodel.EventType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isSuccess": {
+                    "type": "boolean"
+                },
+                "statusCode": {
+                    "type": "integer"
+                },
+                "webhookId": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhookmodel.EventType": {
+            "type": "string",
+            "enum": [
+                "expire",
+                "delete",
+                "update",
+                "stop",
+                "start",
+                "reset",
+                "adjust"
+            ],
+            "x-enum-varnames": [
+                "Expire",
+                "Delete",
+                "Update",
+                "Stop",
+                "Start",
+                "Reset",
+                "Adjust"
+            ]
+        },
+        "webhookmodel.Webhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhookmodel.EventType"
+                    }
+                },
+                "failureCount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isEnabled": {
                     "type": "boolean"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
                 }
             }
         }
@@ -1275,6 +4967,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/config"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/healthusecase"
+	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// readiness checks of every dependency
+func healthUseCase(
+	tickerConfig config.TickerConfig,
+	p *postgres.Postgres,
+	rc *redis.Client,
+	tickerConn *grpc.ClientConn,
+	ticker timerservice.StreamingClient,
+) *healthusecase.UseCase {
+	uc := healthusecase.New(time.Second * 2)
+	uc.Register("postgres", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		stat := p.Pool.Stat()
+		return fmt.Sprintf("connections %d/%d", stat.TotalConns(), stat.MaxConns()), p.Pool.Ping(ctx)
+	}))
+	uc.Register("redis", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		return "", rc.Ping(ctx).Err()
+	}))
+	uc.Register("ticker", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		state := tickerConn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return state.String(), nil
+		// idle connection is connected by first call
+		case connectivity.Idle:
+			tickerConn.Connect()
+			return state.String(), nil
+		default:
+			return state.String(), fmt.Errorf("ticker connection is %s", state)
+		}
+	}))
+	uc.Register("tick_stream", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		lastTick, err := ticker.LastTick()
+		if err != nil {
+			return "", err
+		}
+		age := time.Since(lastTick).Truncate(time.Second)
+		info := fmt.Sprintf("last receive %s ago", age)
+		maxAge := time.Duration(tickerConfig.MaxTickAge) * time.Second
+		if maxAge > 0 && age > maxAge {
+			return info, fmt.Errorf("no ticks for %s", age)
+		}
+		return info, nil
+	}))
+	return uc
+}
@@ -1,13 +1,15 @@
 package app
 
 import (
-	"log"
 	"net/http"
 	_ "net/http/pprof"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"golang.org/x/exp/slog"
 )
 
 func profilier(pprofUrl string) {
 	go func() {
-		log.Println("profilier err: ", http.ListenAndServe(pprofUrl, nil))
+		slog.Error("profilier stopped", logging.Err(http.ListenAndServe(pprofUrl, nil)))
 	}()
 }
@@ -2,39 +2,78 @@ package app
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"errors"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/SevereCloud/vksdk/v2/api"
 	"github.com/Tap-Team/timerapi/internal/config"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/calendarstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/colorstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/folderstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/notificationstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/soundstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/timerstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/userdatastorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/webhookstorage"
+	"github.com/Tap-Team/timerapi/internal/database/redis/idempotencystorage"
+	"github.com/Tap-Team/timerapi/internal/database/redis/ratelimitstorage"
+	"github.com/Tap-Team/timerapi/internal/database/redis/reminderstorage"
 	"github.com/Tap-Team/timerapi/internal/database/redis/subscriberstorage"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timereventstream"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timernotificationstream"
+	"github.com/Tap-Team/timerapi/internal/domain/datastream/webhookstream"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/calendarusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/countdowntimerusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/folderusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/notificationusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/soundusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/userdatausecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/webhookusecase"
 	"github.com/Tap-Team/timerapi/internal/echoconfig"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/metrics"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/swagger"
 	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/internal/utilityusecases/invokeusecase"
 	"github.com/Tap-Team/timerapi/pkg/vk"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
 	"github.com/Tap-Team/timerapi/internal/transport/bot"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/calendarhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/clockhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/colorhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/folderhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/healthhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/idempotency"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/metricshandler"
 	"github.com/Tap-Team/timerapi/internal/transport/rest/notificationhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/ratelimit"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/requestid"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/soundhandler"
 	"github.com/Tap-Team/timerapi/internal/transport/rest/timerhandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/userdatahandler"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/webhookhandler"
 	"github.com/Tap-Team/timerapi/internal/transport/ws/timersocket"
 	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/Tap-Team/timerapi/pkg/saga"
 	"github.com/Tap-Team/timerapi/proto/timerservicepb"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -43,27 +82,43 @@ func Run() {
 	os.Setenv("TZ", "UTC")
 
 	config := config.FromFile("config/config.yaml")
+	if err := logging.Setup(config.Log.Level); err != nil {
+		fatal("error while setup logging", err)
+	}
 	profilier(config.Profilier.Address())
 	ctx := context.Background()
 
 	e := echo.New()
-	g := middleWare(e, config)
 
-	p, err := postgres.New(config.Postgres.URL())
+	shutdownTracing, err := tracing.Setup(ctx, config.Tracing)
 	if err != nil {
-		log.Fatalf("error while connect to postgres, %s", err)
+		fatal("error while setup tracing", err)
+	}
+
+	p, err := postgres.New(config.Postgres.URL(), postgres.Tracer(tracing.PgxTracer()))
+	if err != nil {
+		fatal("error while connect to postgres", err)
 	}
 	opts, err := redis.ParseURL(config.Redis.URL())
 	if err != nil {
-		fmt.Println(config.Redis.URL())
-		log.Fatalf("error parse redis url, %s", err)
+		fatal("error parse redis url", err)
 	}
 	rc := redis.NewClient(opts)
+	if err := redisotel.InstrumentTracing(rc); err != nil {
+		fatal("error instrument redis tracing", err)
+	}
+	g := middleWare(e, config, ratelimitstorage.New(rc), idempotencystorage.New(rc))
 	timerStorage := timerstorage.New(p)
 	subscriberStorage := subscriberstorage.New(rc)
 	notificationStorage := notificationstorage.New(p)
+	webhookStorage := webhookstorage.New(p)
+	calendarStorage := calendarstorage.New(p)
+	userDataStorage := userdatastorage.New(p)
+	folderStorage := folderstorage.New(p)
+	colorStorage := colorstorage.New(p)
+	soundStorage := soundstorage.New(p)
 
-	timerService := tickerService(config.Ticker)
+	timerService, tickerConn := tickerService(config.Ticker)
 
 	notificationStream := timernotificationstream.New(
 		timerService,
@@ -71,25 +126,87 @@ func Run() {
 		subscriberStorage,
 		notificationStorage,
 	)
-	go notificationStream.Start(ctx)
+	notificationService := startService(ctx, func(ctx context.Context) {
+		if err := notificationStream.Start(ctx); err != nil {
+			slog.Error("notification stream stopped", logging.Err(err))
+		}
+	})
 
 	eventSender := timereventstream.New()
 
+	metrics.RegisterQueue("timer_events", eventSender.QueueDepth)
+	metrics.RegisterQueue("notifications", notificationStream.QueueDepth)
+	metrics.RegisterSubscriptions(eventSender.Subscriptions)
+	saga.OnRollback(metrics.SagaRollback)
+	saga.OnCompensationError(func(ctx context.Context, name string, step int, err error) {
+		logging.FromContext(ctx).Error("saga compensation failed", "saga", name, "step", step, logging.Err(err))
+	})
+
+	webhookDispatcher := webhookstream.New(
+		webhookStorage,
+		subscriberStorage,
+		webhookstream.NewHttpClient(),
+		webhookstream.DefaultConfig(),
+	)
+	webhookService := startService(ctx, webhookDispatcher.Start)
+	notificationStream.Listen(webhookDispatcher)
+	webhookEventSender := webhookDispatcher.EventSender(eventSender)
+
+	// timer colors are validated by palette from database, palette is loaded before serving requests
+	colorUseCase := colorusecase.New(
+		colorStorage,
+		time.Minute,
+	)
+	colorUseCase.Load(ctx)
+	colorService := startService(ctx, colorUseCase.Refresh)
+
 	timerUseCase := timerusecase.New(
 		timerStorage,
 		subscriberStorage,
 		timerService,
-		eventSender,
+		colorUseCase,
+		webhookEventSender,
 		notificationStream,
 	)
+	timerUseCase.SetQuota(timermodel.Quota{
+		MaxTimers:        config.Quota.MaxTimers,
+		MaxSubscriptions: config.Quota.MaxSubscriptions,
+		MaxSubscribers:   config.Quota.MaxSubscribers,
+	})
 	countdowntimerUseCase := countdowntimerusecase.New(
 		timerService,
 		timerStorage,
-		eventSender,
+		webhookEventSender,
 	)
 	notificationUseCase := notificationusecase.New(
 		notificationStorage,
 	)
+	webhookUseCase := webhookusecase.New(
+		webhookStorage,
+		net.DefaultResolver,
+	)
+	folderUseCase := folderusecase.New(
+		folderStorage,
+		timerStorage,
+	)
+	soundUseCase := soundusecase.New(
+		soundStorage,
+	)
+	calendarUseCase := calendarusecase.New(
+		calendarStorage,
+		timerStorage,
+		timerUseCase,
+		config.Calendar.FeedURL,
+	)
+	userDataUseCase := userdatausecase.New(
+		userDataStorage,
+		timerStorage,
+		subscriberStorage,
+		timerUseCase,
+		notificationUseCase,
+		webhookUseCase,
+		calendarUseCase,
+	)
 
 	err = invokeusecase.New(
 		timerService,
@@ -97,16 +214,27 @@ func Run() {
 		timerStorage,
 	).Invoke(ctx)
 	if err != nil {
-		log.Printf("\nfailed execute invoke use case, %s", err)
+		slog.Error("failed execute invoke use case", logging.Err(err))
 	}
 
-	timerhandler.Init(g, timerUseCase, countdowntimerUseCase)
+	timerhandler.Init(g, timerUseCase, countdowntimerUseCase, calendarUseCase)
 	notificationhandler.Init(g, notificationUseCase)
-	timersocket.Init(g, eventSender, notificationStream)
+	webhookhandler.Init(g, webhookUseCase)
+	folderhandler.Init(g, folderUseCase)
+	calendarhandler.Init(g, e.Group(""), calendarUseCase)
+	clockhandler.Init(e.Group(""))
+	colorhandler.Init(e.Group(""), colorUseCase)
+	soundhandler.Init(g, e.Group(""), soundUseCase)
+	healthhandler.Init(e.Group(""), healthUseCase(config.Ticker, p, rc, tickerConn, timerService))
+	metricshandler.Init(e.Group(""))
+	userdatahandler.Init(g, userDataUseCase)
+	socket := timersocket.Init(g, eventSender, notificationStream)
 
-	botmanager := bot.NewManager(api.NewVK(config.VK.BotToken))
-	go botmanager.RunMessageHandlers()
-	go botmanager.RunNotificationBot(ctx, notificationStream)
+	botmanager := bot.NewManager(api.NewVK(config.VK.BotToken), timerUseCase, countdowntimerUseCase, reminderstorage.New(rc))
+	messageService := startService(ctx, botmanager.RunMessageHandlers)
+	notificationBotService := startService(ctx, func(ctx context.Context) {
+		botmanager.RunNotificationBot(ctx, notificationStream)
+	})
 
 	addr := config.Server.Address()
 
@@ -117,44 +245,151 @@ func Run() {
 		Addr:    addr,
 		Handler: h2c.NewHandler(e, h2s),
 	}
-	log.Printf("ECHO APP STARTED ON %s", addr)
-	err = s.ListenAndServe()
-	log.Fatalf("api server failed start failed, %s", err)
+	sigCtx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	go func() {
+		slog.Info("echo app started", "addr", addr)
+		err := s.ListenAndServe()
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("api server failed start", err)
+		}
+	}()
+	<-sigCtx.Done()
+	stop()
+
+	slog.Info("shutdown started", "timeout", config.Server.GracefulTimeout())
+	shutdownCtx, cancel := context.WithTimeout(ctx, config.Server.GracefulTimeout())
+	defer cancel()
+	// stop accepting requests and wait for in-flight requests
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed shutdown api server", logging.Err(err))
+	}
+	// websockets are hijacked, so they are closed separately with reconnect hint
+	if err := socket.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed close websockets", logging.Err(err))
+	}
+	// stop long poll and reminders, command which is being handled is finished, snoozed reminders stay in redis
+	stopAndDrain(shutdownCtx, "bot message handlers", messageService, nil)
+	// cancel of stream context closes ticker stream, queued notifications are sent to listeners
+	stopAndDrain(shutdownCtx, "notification stream", notificationService, notificationStream)
+	stopAndDrain(shutdownCtx, "webhook dispatcher", webhookService, webhookDispatcher)
+	stopAndDrain(shutdownCtx, "notification bot", notificationBotService, botmanager)
+	stopAndDrain(shutdownCtx, "color palette refresh", colorService, nil)
+
+	if err := tickerConn.Close(); err != nil {
+		slog.Error("failed close ticker connection", logging.Err(err))
+	}
+	if err := rc.Close(); err != nil {
+		slog.Error("failed close redis client", logging.Err(err))
+	}
+	p.Pool.Close()
+	// flush spans of shutdown
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("failed shutdown tracing", logging.Err(err))
+	}
+	slog.Info("shutdown finished")
 }
 
-func middleWare(e *echo.Echo, config *config.Config) *echo.Group {
+func middleWare(e *echo.Echo, config *config.Config, limiter ratelimit.Limiter, idempotencyStorage idempotency.Storage) *echo.Group {
 	e.HTTPErrorHandler = echoconfig.ErrorHandler
 	swagger.New(e, config.Swagger)
 
+	// tracing is outermost, so span covers recover, metrics and logger
+	e.Use(otelecho.Middleware(tracing.ServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		switch c.Path() {
+		case "/healthz", "/readyz", "/metrics":
+			return true
+		}
+		// websocket span would last for connection lifetime
+		return c.IsWebSocket()
+	})))
+	// request id is set before logging, so every log of request has it
+	e.Use(requestid.Middleware())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(metricshandler.Middleware())
+	// headers which clients read on responses
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{"ETag", echo.HeaderRetryAfter, idempotency.HeaderIdempotentReplayed, requestid.Header},
+	}))
 	loggerMiddleWare(e)
 
-	return e.Group("", vk.VkKeyHandler(config.VK.Key, config.VK.DebugKey))
+	return e.Group(
+		"",
+		vk.VkKeyHandler(config.VK.Key, config.VK.DebugKey),
+		ratelimit.Middleware(limiter, ratelimit.Config{Rate: config.RateLimit.Rate, Burst: config.RateLimit.Burst}),
+		idempotency.Middleware(idempotencyStorage, idempotency.DefaultConfig()),
+	)
 }
 
 func loggerMiddleWare(e *echo.Echo) {
 	skippedUrls := []string{
 		"/timers/user-created",
 		"/timers/user-subscriptions",
+		// probes
+		"/healthz",
+		"/readyz",
+		"/metrics",
 	}
-	loggerConfig := middleware.DefaultLoggerConfig
-	loggerConfig.Skipper = func(c echo.Context) bool {
+	skipped := func(path string) bool {
 		for _, url := range skippedUrls {
-			if c.Request().URL.Path == url {
+			if path == url {
 				return true
 			}
 		}
 		return false
 	}
-	e.Use(middleware.LoggerWithConfig(loggerConfig))
+	// query isn't logged, it contains signed launch params
+	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		HandleError:  true,
+		LogMethod:    true,
+		LogURIPath:   true,
+		LogRoutePath: true,
+		LogStatus:    true,
+		LogLatency:   true,
+		LogRemoteIP:  true,
+		LogError:     true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			// errors of skipped urls are logged anyway
+			if v.Error == nil && skipped(v.URIPath) {
+				return nil
+			}
+			logger := logging.FromContext(c.Request().Context())
+			args := []any{
+				"method", v.Method,
+				"path", v.URIPath,
+				"route", v.RoutePath,
+				"status", v.Status,
+				"latency", v.Latency,
+				"remote_ip", v.RemoteIP,
+			}
+			if v.Error != nil {
+				logger.Error("request failed", append(args, logging.Err(v.Error))...)
+				return nil
+			}
+			logger.Info("request", args...)
+			return nil
+		},
+	}))
+}
+
+// log error and exit, used only on start
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Err(err))
+	os.Exit(1)
 }
 
-func tickerService(config config.TickerConfig) timerservice.TimerServiceClient {
+func tickerService(config config.TickerConfig) (timerservice.StreamingClient, *grpc.ClientConn) {
 	ctx := context.Background()
-	conn, err := grpc.DialContext(ctx, config.URL(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.DialContext(
+		ctx,
+		config.URL(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// trace context is propagated to ticker in metadata
+		grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), metrics.TickerUnaryInterceptor()),
+		grpc.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(), metrics.TickerStreamInterceptor()),
+	)
 	if err != nil {
-		log.Fatalf("dial context failed, %s", err)
+		fatal("dial context failed", err)
 	}
-	return timerservice.GrpcClient(timerservicepb.NewTimerServiceClient(conn))
+	return timerservice.GrpcClient(timerservicepb.NewTimerServiceClient(conn)), conn
 }
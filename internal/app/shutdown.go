@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"golang.org/x/exp/slog"
+)
+
+// background service which is stopped by cancel of its context
+type service struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// run f in new goroutine with child context of ctx
+func startService(ctx context.Context, f func(ctx context.Context)) *service {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &service{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		f(ctx)
+	}()
+	return s
+}
+
+// cancel context of service and wait until it returns
+func (s *service) stop(ctx context.Context) error {
+	s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainer waits for work which was started before stop
+type drainer interface {
+	Drain(ctx context.Context) error
+}
+
+// stop service and wait for its work, errors are logged because shutdown continues anyway
+func stopAndDrain(ctx context.Context, name string, s *service, d drainer) {
+	if err := s.stop(ctx); err != nil {
+		slog.Error("failed stop service", "service", name, logging.Err(err))
+		return
+	}
+	if d == nil {
+		return
+	}
+	if err := d.Drain(ctx); err != nil {
+		slog.Error("failed drain service", "service", name, logging.Err(err))
+	}
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/config"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/calendarstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/colorstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/notificationstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/timerstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/userdatastorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/webhookstorage"
+	"github.com/Tap-Team/timerapi/internal/database/redis/subscriberstorage"
+	"github.com/Tap-Team/timerapi/internal/domain/datastream/timereventstream"
+	"github.com/Tap-Team/timerapi/internal/domain/datastream/timernotificationstream"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/calendarusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/notificationusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/userdatausecase"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/webhookusecase"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/exp/slog"
+)
+
+const userDataUsage = `usage:
+	userdata export <vk_user_id>	print json archive of user data
+	userdata erase <vk_user_id>	erase user data
+	userdata resume			continue unfinished erasures`
+
+// notification stream isn't started in cli, so notifications are handled synchronously
+type syncNotificationSender struct {
+	ctx     context.Context
+	handler *timernotificationstream.StreamHandler
+}
+
+func (s syncNotificationSender) Send(n notification.Notification) {
+	s.handler.Handle(s.ctx, n)
+}
+
+// usage is printed to stderr, stdout is for json result
+func userDataUsageExit() {
+	slog.Error("wrong arguments", "usage", userDataUsage)
+	os.Exit(2)
+}
+
+// cli for user data requests, args are command and its arguments
+func UserData(args []string) {
+	os.Setenv("TZ", "UTC")
+	if len(args) == 0 {
+		userDataUsageExit()
+	}
+	config := config.FromFile("config/config.yaml")
+	ctx := context.Background()
+
+	p, err := postgres.New(config.Postgres.URL())
+	if err != nil {
+		fatal("connect to postgres", err)
+	}
+	opts, err := redis.ParseURL(config.Redis.URL())
+	if err != nil {
+		fatal("parse redis url", err)
+	}
+	rc := redis.NewClient(opts)
+	timerStorage := timerstorage.New(p)
+	subscriberStorage := subscriberstorage.New(rc)
+	notificationStorage := notificationstorage.New(p)
+
+	timerService, _ := tickerService(config.Ticker)
+
+	notificationStream := timernotificationstream.New(
+		timerService,
+		timerStorage,
+		subscriberStorage,
+		notificationStorage,
+	)
+	timerUseCase := timerusecase.New(
+		timerStorage,
+		subscriberStorage,
+		timerService,
+		// palette is loaded on demand, commands don't create timers
+		colorusecase.New(colorstorage.New(p), time.Minute),
+		timereventstream.New(),
+		syncNotificationSender{ctx: ctx, handler: notificationStream},
+	)
+	useCase := userdatausecase.New(
+		userdatastorage.New(p),
+		timerStorage,
+		subscriberStorage,
+		timerUseCase,
+		notificationusecase.New(notificationStorage),
+		webhookusecase.New(webhookstorage.New(p), net.DefaultResolver),
+		calendarusecase.New(calendarstorage.New(p), timerStorage, timerUseCase, config.Calendar.FeedURL),
+	)
+
+	var result any
+	switch args[0] {
+	case "export", "erase":
+		if len(args) != 2 {
+			userDataUsageExit()
+		}
+		userId, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			fatal("parse vk_user_id", err)
+		}
+		if args[0] == "export" {
+			result, err = useCase.Export(ctx, userId)
+		} else {
+			result, err = useCase.Erase(ctx, userId)
+		}
+		if err != nil {
+			slog.Error("user data command failed", "command", args[0], "user_id", userId, logging.Err(err))
+			os.Exit(1)
+		}
+	case "resume":
+		erasures, err := useCase.Resume(ctx)
+		if err != nil {
+			slog.Error("some erasures failed", logging.Err(err))
+		}
+		result = erasures
+	default:
+		userDataUsageExit()
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(result)
+	if err != nil {
+		fatal("encode result", err)
+	}
+}
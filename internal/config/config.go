@@ -2,10 +2,12 @@ package config
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"golang.org/x/exp/slog"
 	"gopkg.in/yaml.v3"
 )
 
@@ -51,12 +53,21 @@ func (u PostgresConfig) URL() string {
 type ServerConfig struct {
 	Host string `yaml:"host"`
 	Port int64  `yaml:"port"`
+	// time in seconds to finish requests and drain queues on shutdown, default 30
+	ShutdownTimeout int64 `yaml:"shutdown_timeout"`
 }
 
 func (c ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+func (c ServerConfig) GracefulTimeout() time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return time.Second * 30
+	}
+	return time.Second * time.Duration(c.ShutdownTimeout)
+}
+
 type VkConfig struct {
 	Key      string `yaml:"secret_key"`
 	DebugKey string `yaml:"debug_key"`
@@ -66,6 +77,9 @@ type VkConfig struct {
 type TickerConfig struct {
 	LocalURL  string `yaml:"local_url"`
 	DockerURL string `yaml:"docker_url"`
+	// max age of last tick stream receive in seconds for readiness, 0 disables check
+	// ticks are sent only when timers expire, so age should be set only for busy services
+	MaxTickAge int64 `yaml:"max_tick_age"`
 }
 
 func (u TickerConfig) URL() string {
@@ -98,6 +112,53 @@ func (c ProfilierConfig) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
 
+type CalendarConfig struct {
+	// public url of calendar feed route, feed token is appended to it
+	FeedURL string `yaml:"feed_url"`
+}
+
+// per user quotas, 0 is unlimited
+type QuotaConfig struct {
+	MaxTimers        int `yaml:"max_timers"`
+	MaxSubscriptions int `yaml:"max_subscriptions"`
+	MaxSubscribers   int `yaml:"max_subscribers"`
+}
+
+// token bucket of mutating requests per user, 0 rate disables limit
+type RateLimitConfig struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// exporter of traces is "otlp", "stdout" or "none", empty exporter disables tracing
+type TracingConfig struct {
+	Exporter string `yaml:"exporter"`
+	// otlp grpc collector address, host:port
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+	// part of traces which are sampled, from 0 to 1, 0 samples nothing, unset is DefaultSampleRatio
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// sample ratio of config without sample_ratio, all traces are sampled
+const DefaultSampleRatio = 1.0
+
+// sample ratio clamped to [0, 1]
+func (c TracingConfig) Ratio() float64 {
+	if c.SampleRatio < 0 {
+		return 0
+	}
+	if c.SampleRatio > 1 {
+		return 1
+	}
+	return c.SampleRatio
+}
+
+// level is "debug", "info", "warn" or "error", empty level is info
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
 type Config struct {
 	Redis     RedisConfig     `yaml:"redis"`
 	Postgres  PostgresConfig  `yaml:"postgres"`
@@ -106,6 +167,11 @@ type Config struct {
 	Ticker    TickerConfig    `yaml:"ticker"`
 	Swagger   SwaggerConfig   `yaml:"swagger"`
 	Profilier ProfilierConfig `yaml:"profilier"`
+	Calendar  CalendarConfig  `yaml:"calendar"`
+	Quota     QuotaConfig     `yaml:"quota"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Log       LogConfig       `yaml:"log"`
 }
 
 func New(
@@ -127,14 +193,19 @@ func New(
 }
 
 func FromFile(filePath string) *Config {
-	config := new(Config)
+	// defaults of fields which aren't set in file
+	config := &Config{
+		Tracing: TracingConfig{SampleRatio: DefaultSampleRatio},
+	}
 	b, err := os.ReadFile(filePath)
 	if err != nil {
-		log.Fatalf("error while read file, %s", err)
+		slog.Error("read config file", "path", filePath, logging.Err(err))
+		os.Exit(1)
 	}
 	err = yaml.Unmarshal(b, config)
 	if err != nil {
-		log.Fatalf("error while unmarshal file into config, %s", err)
+		slog.Error("unmarshal config file", "path", filePath, logging.Err(err))
+		os.Exit(1)
 	}
 	return config
 }
@@ -0,0 +1,100 @@
+package calendarstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/calendarfeedsql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const _PROVIDER = "internal/database/postgres/calendarstorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+func Error(err error, cause exception.Cause) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return exception.Wrap(calendarerror.ExceptionFeedNotFound(), cause)
+	default:
+		return exception.Wrap(err, cause)
+	}
+}
+
+var feedTokenQuery = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = $1`,
+	calendarfeedsql.Token,
+	calendarfeedsql.Table,
+	calendarfeedsql.UserId,
+)
+
+func (s *Storage) FeedToken(ctx context.Context, userId int64) (string, error) {
+	var token string
+	err := s.p.Pool.QueryRow(ctx, feedTokenQuery, userId).Scan(&token)
+	if err != nil {
+		return "", Error(err, exception.NewCause("select feed token", "FeedToken", _PROVIDER))
+	}
+	return token, nil
+}
+
+var setFeedTokenQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s) VALUES ($1,$2) ON CONFLICT (%s) DO UPDATE SET %s = excluded.%s, %s = now()`,
+	calendarfeedsql.Table,
+	calendarfeedsql.UserId,
+	calendarfeedsql.Token,
+	calendarfeedsql.UserId,
+	calendarfeedsql.Token,
+	calendarfeedsql.Token,
+	calendarfeedsql.CreatedAt,
+)
+
+// set user feed token, previous token stops working
+func (s *Storage) SetFeedToken(ctx context.Context, userId int64, token string) error {
+	_, err := s.p.Pool.Exec(ctx, setFeedTokenQuery, userId, token)
+	if err != nil {
+		return Error(err, exception.NewCause("upsert feed token", "SetFeedToken", _PROVIDER))
+	}
+	return nil
+}
+
+var feedUserQuery = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = $1`,
+	calendarfeedsql.UserId,
+	calendarfeedsql.Table,
+	calendarfeedsql.Token,
+)
+
+// owner of feed token
+func (s *Storage) FeedUser(ctx context.Context, token string) (int64, error) {
+	var userId int64
+	err := s.p.Pool.QueryRow(ctx, feedUserQuery, token).Scan(&userId)
+	if err != nil {
+		return 0, Error(err, exception.NewCause("select feed user", "FeedUser", _PROVIDER))
+	}
+	return userId, nil
+}
+
+var deleteFeedQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1`,
+	calendarfeedsql.Table,
+	calendarfeedsql.UserId,
+)
+
+// delete user feed, no error if user has no feed
+func (s *Storage) DeleteFeed(ctx context.Context, userId int64) error {
+	_, err := s.p.Pool.Exec(ctx, deleteFeedQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete feed", "DeleteFeed", _PROVIDER))
+	}
+	return nil
+}
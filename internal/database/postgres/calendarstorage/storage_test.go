@@ -0,0 +1,76 @@
+package calendarstorage_test
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/database/postgres/calendarstorage"
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/pkg/amidstr"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+var testCalendarStorage *calendarstorage.Storage
+
+func TestMain(m *testing.M) {
+	os.Setenv("TZ", "UTC")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, terminate, err := postgres.NewContainer(ctx, postgres.DEFAULT_MIGRATION_PATH)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer terminate(ctx)
+	testCalendarStorage = calendarstorage.New(p)
+	m.Run()
+}
+
+func TestFeedToken(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+
+	_, err := testCalendarStorage.FeedToken(ctx, userId)
+	require.ErrorIs(t, err, calendarerror.ExceptionFeedNotFound(), "token of user without feed found")
+
+	token := amidstr.MakeString(64)
+	err = testCalendarStorage.SetFeedToken(ctx, userId, token)
+	require.NoError(t, err, "create feed token failed")
+	stored, err := testCalendarStorage.FeedToken(ctx, userId)
+	require.NoError(t, err, "get feed token failed")
+	require.Equal(t, token, stored, "wrong feed token")
+	owner, err := testCalendarStorage.FeedUser(ctx, token)
+	require.NoError(t, err, "get feed user failed")
+	require.Equal(t, userId, owner, "wrong feed user")
+
+	// reset replaces token, previous token stops working
+	reset := amidstr.MakeString(64)
+	err = testCalendarStorage.SetFeedToken(ctx, userId, reset)
+	require.NoError(t, err, "reset feed token failed")
+	stored, err = testCalendarStorage.FeedToken(ctx, userId)
+	require.NoError(t, err, "get feed token failed")
+	require.Equal(t, reset, stored, "feed token not reset")
+	_, err = testCalendarStorage.FeedUser(ctx, token)
+	require.ErrorIs(t, err, calendarerror.ExceptionFeedNotFound(), "previous token still works")
+	owner, err = testCalendarStorage.FeedUser(ctx, reset)
+	require.NoError(t, err, "get feed user by reset token failed")
+	require.Equal(t, userId, owner, "wrong feed user of reset token")
+
+	// token is unique
+	err = testCalendarStorage.SetFeedToken(ctx, rand.Int63(), reset)
+	require.Error(t, err, "token of other user set")
+
+	err = testCalendarStorage.DeleteFeed(ctx, userId)
+	require.NoError(t, err, "delete feed failed")
+	_, err = testCalendarStorage.FeedToken(ctx, userId)
+	require.ErrorIs(t, err, calendarerror.ExceptionFeedNotFound(), "deleted feed found")
+	_, err = testCalendarStorage.FeedUser(ctx, reset)
+	require.ErrorIs(t, err, calendarerror.ExceptionFeedNotFound(), "token of deleted feed works")
+	// user without feed is not error
+	err = testCalendarStorage.DeleteFeed(ctx, userId)
+	require.NoError(t, err, "delete not existing feed failed")
+}
@@ -0,0 +1,53 @@
+package colorstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/model/colormodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/colorsql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+)
+
+const _PROVIDER = "internal/database/postgres/colorstorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+var colorsQuery = fmt.Sprintf(
+	`SELECT %s, %s, %s FROM %s ORDER BY %s, %s`,
+	colorsql.Color,
+	colorsql.Hex,
+	colorsql.Gradient,
+	colorsql.Table,
+	colorsql.Position,
+	colorsql.ID,
+)
+
+// palette colors in palette order
+func (s *Storage) Colors(ctx context.Context) ([]*colormodel.Color, error) {
+	rows, err := s.p.Pool.Query(ctx, colorsQuery)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("select colors", "Colors", _PROVIDER))
+	}
+	defer rows.Close()
+	colors := make([]*colormodel.Color, 0)
+	for rows.Next() {
+		color := new(colormodel.Color)
+		err = rows.Scan(&color.Name, &color.Hex, &color.Gradient)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("scan color", "Colors", _PROVIDER))
+		}
+		colors = append(colors, color)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("read colors", "Colors", _PROVIDER))
+	}
+	return colors, nil
+}
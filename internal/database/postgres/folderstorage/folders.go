@@ -0,0 +1,210 @@
+package folderstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/foldersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/placementsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// new folder is placed after other user folders
+var insertFolderQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s,%s) VALUES ($1,$2,$3,(SELECT coalesce(max(%s) + 1, 0) FROM %s WHERE %s = $2)) RETURNING %s,%s`,
+	foldersql.Table,
+	foldersql.ID,
+	foldersql.UserId,
+	foldersql.Name,
+	foldersql.Position,
+	foldersql.Position,
+	foldersql.Table,
+	foldersql.UserId,
+	foldersql.Position,
+	foldersql.CreatedAt,
+)
+
+// transaction level advisory lock of user folders, released on commit or rollback
+// concurrent inserts of one user wait for each other, so folders limit can't be exceeded by parallel requests
+var lockUserFoldersQuery = `SELECT pg_advisory_xact_lock(hashtextextended('folders_' || $1::text, 0))`
+
+var userFoldersCountQuery = fmt.Sprintf(
+	`SELECT count(*) FROM %s WHERE %s = $1`,
+	foldersql.Table,
+	foldersql.UserId,
+)
+
+// check user can create one more folder, 0 max folders means no limit
+func checkFoldersLimitTx(ctx context.Context, tx pgx.Tx, userId int64, maxFolders int) error {
+	if maxFolders == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, lockUserFoldersQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("lock user folders", "checkFoldersLimitTx", _PROVIDER))
+	}
+	var count int
+	err = tx.QueryRow(ctx, userFoldersCountQuery, userId).Scan(&count)
+	if err != nil {
+		return Error(err, exception.NewCause("select user folders count", "checkFoldersLimitTx", _PROVIDER))
+	}
+	if count >= maxFolders {
+		return exception.Wrap(foldererror.ExceptionTooManyFolders(), exception.NewCause("compare folders count with limit", "checkFoldersLimitTx", _PROVIDER))
+	}
+	return nil
+}
+
+// insert folder, position and creation time are set from database
+// count of user folders is checked in the same transaction, 0 max folders means no limit
+func (s *Storage) InsertFolder(ctx context.Context, folder *foldermodel.Folder, maxFolders int) error {
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "InsertFolder", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	err = checkFoldersLimitTx(ctx, tx, folder.UserId, maxFolders)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check folders limit", "InsertFolder", _PROVIDER))
+	}
+	err = tx.QueryRow(ctx, insertFolderQuery, folder.ID, folder.UserId, folder.Name).Scan(&folder.Position, &folder.CreatedAt)
+	if err != nil {
+		return Error(err, exception.NewCause("insert folder", "InsertFolder", _PROVIDER))
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "InsertFolder", _PROVIDER))
+	}
+	return nil
+}
+
+var selectFolderQuery = fmt.Sprintf(
+	`SELECT %s, (SELECT count(*) FROM %s INNER JOIN %s ON %s = %s AND NOT %s WHERE %s = %s), %s FROM %s`,
+	sqlutils.Full(
+		foldersql.ID,
+		foldersql.UserId,
+		foldersql.Name,
+		foldersql.Position,
+	),
+	// count of not deleted timers in folder
+	placementsql.Table,
+	timersql.Table,
+	sqlutils.Full(timersql.ID),
+	sqlutils.Full(placementsql.TimerId),
+	sqlutils.Full(timersql.IsDeleted),
+	sqlutils.Full(placementsql.FolderId),
+	sqlutils.Full(foldersql.ID),
+
+	sqlutils.Full(foldersql.CreatedAt),
+	foldersql.Table,
+)
+
+func scanFolder(row pgx.Row, folder *foldermodel.Folder) error {
+	return row.Scan(
+		&folder.ID,
+		&folder.UserId,
+		&folder.Name,
+		&folder.Position,
+		&folder.TimersCount,
+		&folder.CreatedAt,
+	)
+}
+
+var folderQuery = selectFolderQuery + fmt.Sprintf(` WHERE %s = $1`, sqlutils.Full(foldersql.ID))
+
+func (s *Storage) Folder(ctx context.Context, folderId uuid.UUID) (*foldermodel.Folder, error) {
+	folder := new(foldermodel.Folder)
+	err := scanFolder(s.p.Pool.QueryRow(ctx, folderQuery, folderId), folder)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan folder", "Folder", _PROVIDER))
+	}
+	return folder, nil
+}
+
+var userFoldersQuery = selectFolderQuery + fmt.Sprintf(
+	` WHERE %s = $1 ORDER BY %s`,
+	sqlutils.Full(foldersql.UserId),
+	sqlutils.Full(foldersql.Position, foldersql.CreatedAt, foldersql.ID),
+)
+
+// user folders in manual order
+func (s *Storage) UserFolders(ctx context.Context, userId int64) ([]*foldermodel.Folder, error) {
+	rows, err := s.p.Pool.Query(ctx, userFoldersQuery, userId)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("user folders query", "UserFolders", _PROVIDER))
+	}
+	folders, err := sqlutils.ScanList(rows, scanFolder)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan folder list", "UserFolders", _PROVIDER))
+	}
+	return folders, nil
+}
+
+var renameFolderQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = $2 WHERE %s = $1`,
+	foldersql.Table,
+	foldersql.Name,
+	foldersql.ID,
+)
+
+func (s *Storage) RenameFolder(ctx context.Context, folderId uuid.UUID, name foldermodel.Name) error {
+	cmd, err := s.p.Pool.Exec(ctx, renameFolderQuery, folderId, name)
+	if err != nil {
+		return Error(err, exception.NewCause("rename folder", "RenameFolder", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(foldererror.ExceptionFolderNotFound(), exception.NewCause("rename folder rows = 0", "RenameFolder", _PROVIDER))
+	}
+	return nil
+}
+
+var deleteFolderQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1`,
+	foldersql.Table,
+	foldersql.ID,
+)
+
+// delete folder, timers of folder are moved out of folders
+func (s *Storage) DeleteFolder(ctx context.Context, folderId uuid.UUID) error {
+	cmd, err := s.p.Pool.Exec(ctx, deleteFolderQuery, folderId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete folder", "DeleteFolder", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(foldererror.ExceptionFolderNotFound(), exception.NewCause("delete folder rows = 0", "DeleteFolder", _PROVIDER))
+	}
+	return nil
+}
+
+var folderPositionQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = $3 WHERE %s = $1 AND %s = $2`,
+	foldersql.Table,
+	foldersql.Position,
+	foldersql.ID,
+	foldersql.UserId,
+)
+
+// set position of user folders by order of ids, ids of other users folders are skipped
+func (s *Storage) OrderFolders(ctx context.Context, userId int64, folderIds []uuid.UUID) error {
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "OrderFolders", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	for position, folderId := range folderIds {
+		_, err = tx.Exec(ctx, folderPositionQuery, folderId, userId, position)
+		if err != nil {
+			return Error(err, exception.NewCause("update folder position", "OrderFolders", _PROVIDER))
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "OrderFolders", _PROVIDER))
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package folderstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func insertTimer(t *testing.T, ctx context.Context, creator int64) uuid.UUID {
+	timer := timermodel.NewCreateTimer(
+		uuid.New(),
+		0,
+		amidtime.Now(),
+		amidtime.DateTime(time.Now().Add(time.Hour)),
+		timerfields.DATE,
+		"timer",
+		"",
+		timerfields.BLUE,
+		false,
+	)
+	err := testTimerStorage.InsertDateTimer(ctx, creator, timer, 0)
+	require.NoError(t, err, "insert timer failed")
+	return timer.ID
+}
+
+func timerIds(timers []*timermodel.Timer) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(timers))
+	for _, timer := range timers {
+		ids = append(ids, timer.ID)
+	}
+	return ids
+}
+
+func TestFolderCrud(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+
+	first := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "first"}
+	second := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "second"}
+	require.NoError(t, testFolderStorage.InsertFolder(ctx, first, 0), "insert folder failed")
+	require.NoError(t, testFolderStorage.InsertFolder(ctx, second, 0), "insert folder failed")
+	require.Equal(t, first.Position+1, second.Position, "new folder not placed last")
+
+	err := testFolderStorage.RenameFolder(ctx, first.ID, "renamed")
+	require.NoError(t, err, "rename folder failed")
+	folder, err := testFolderStorage.Folder(ctx, first.ID)
+	require.NoError(t, err, "get folder failed")
+	require.Equal(t, foldermodel.Name("renamed"), folder.Name, "folder not renamed")
+
+	// reverse order of folders
+	err = testFolderStorage.OrderFolders(ctx, userId, []uuid.UUID{second.ID, first.ID})
+	require.NoError(t, err, "order folders failed")
+	folders, err := testFolderStorage.UserFolders(ctx, userId)
+	require.NoError(t, err, "get user folders failed")
+	require.Len(t, folders, 2, "wrong folders count")
+	require.Equal(t, second.ID, folders[0].ID, "folders order not saved")
+
+	// other user can't order folders
+	err = testFolderStorage.OrderFolders(ctx, rand.Int63(), []uuid.UUID{first.ID, second.ID})
+	require.NoError(t, err, "order folders of other user failed")
+	folders, err = testFolderStorage.UserFolders(ctx, userId)
+	require.NoError(t, err, "get user folders failed")
+	require.Equal(t, second.ID, folders[0].ID, "folders ordered by other user")
+
+	for _, folder := range folders {
+		require.NoError(t, testFolderStorage.DeleteFolder(ctx, folder.ID), "delete folder failed")
+	}
+	_, err = testFolderStorage.Folder(ctx, first.ID)
+	require.ErrorIs(t, err, foldererror.ExceptionFolderNotFound(), "deleted folder found")
+	err = testFolderStorage.DeleteFolder(ctx, first.ID)
+	require.ErrorIs(t, err, foldererror.ExceptionFolderNotFound(), "delete not exists folder wrong error")
+}
+
+func TestInsertFolderLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+	maxFolders := 3
+
+	// parallel inserts can't exceed limit
+	errs := make([]error, 10)
+	wg := new(sync.WaitGroup)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			folder := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "folder"}
+			errs[i] = testFolderStorage.InsertFolder(ctx, folder, maxFolders)
+		}(i)
+	}
+	wg.Wait()
+
+	inserted := 0
+	for _, err := range errs {
+		if err == nil {
+			inserted++
+			continue
+		}
+		require.ErrorIs(t, err, foldererror.ExceptionTooManyFolders(), "wrong error of folder over limit")
+	}
+	require.Equal(t, maxFolders, inserted, "wrong count of inserted folders")
+	folders, err := testFolderStorage.UserFolders(ctx, userId)
+	require.NoError(t, err, "get user folders failed")
+	require.Len(t, folders, maxFolders, "folders limit exceeded")
+
+	// limit is per user
+	err = testFolderStorage.InsertFolder(ctx, &foldermodel.Folder{ID: uuid.New(), UserId: rand.Int63(), Name: "folder"}, maxFolders)
+	require.NoError(t, err, "insert folder of other user failed")
+}
+
+func TestTimerPlacement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+	ids := []uuid.UUID{insertTimer(t, ctx, userId), insertTimer(t, ctx, userId), insertTimer(t, ctx, userId)}
+
+	folder := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "folder"}
+	require.NoError(t, testFolderStorage.InsertFolder(ctx, folder, 0), "insert folder failed")
+
+	// move two timers into folder and reorder them
+	for _, id := range ids[1:] {
+		err := testFolderStorage.MoveTimer(ctx, id, userId, &folder.ID)
+		require.NoError(t, err, "move timer failed")
+	}
+	err := testFolderStorage.OrderTimers(ctx, userId, []uuid.UUID{ids[2], ids[1]})
+	require.NoError(t, err, "order timers failed")
+	timers, err := testTimerStorage.FilteredTimers(ctx, userId, &timermodel.TimerFilter{List: timermodel.CreatedTimers, FolderId: &folder.ID}, 0, 10)
+	require.NoError(t, err, "get timers of folder failed")
+	require.Equal(t, []uuid.UUID{ids[2], ids[1]}, timerIds(timers), "wrong timers of folder")
+	require.Equal(t, folder.ID, *timers[0].FolderId, "wrong folder of timer")
+
+	folder, err = testFolderStorage.Folder(ctx, folder.ID)
+	require.NoError(t, err, "get folder failed")
+	require.Equal(t, 2, folder.TimersCount, "wrong timers count of folder")
+
+	// pinned timer goes first
+	err = testFolderStorage.PinTimer(ctx, ids[1], userId, true)
+	require.NoError(t, err, "pin timer failed")
+	timers, err = testTimerStorage.UserTimers(ctx, userId, 0, 10)
+	require.NoError(t, err, "get user timers failed")
+	require.Equal(t, ids[1], timers[0].ID, "pinned timer isn't first")
+	require.True(t, timers[0].IsPinned, "timer not pinned")
+
+	// placement is private for user
+	timer, err := testTimerStorage.UserTimer(ctx, ids[1], rand.Int63())
+	require.NoError(t, err, "get timer failed")
+	require.False(t, timer.IsPinned, "pin visible for other user")
+	require.Nil(t, timer.FolderId, "folder visible for other user")
+
+	// not subscriber can't place timer
+	err = testFolderStorage.MoveTimer(ctx, ids[0], rand.Int63(), nil)
+	require.ErrorIs(t, err, timererror.ExceptionTimerNotFound(), "not subscriber moved timer")
+
+	// deleted folder keeps timers
+	require.NoError(t, testFolderStorage.DeleteFolder(ctx, folder.ID), "delete folder failed")
+	timers, err = testTimerStorage.UserCreatedTimers(ctx, userId, 0, 10)
+	require.NoError(t, err, "get user timers failed")
+	require.Len(t, timers, len(ids), "timers deleted with folder")
+	for _, timer := range timers {
+		require.Nil(t, timer.FolderId, "timer still in deleted folder")
+	}
+}
+
+func TestTimerTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	creator, subscriber := rand.Int63(), rand.Int63()
+	timerId := insertTimer(t, ctx, creator)
+	require.NoError(t, testTimerStorage.Subscribe(ctx, timerId, subscriber), "subscribe failed")
+
+	// subscriber tags timer of creator
+	err := testFolderStorage.SetTimerTags(ctx, timerId, subscriber, []foldermodel.Tag{"work", "daily"})
+	require.NoError(t, err, "set timer tags failed")
+	timers, err := testTimerStorage.FilteredTimers(ctx, subscriber, &timermodel.TimerFilter{List: timermodel.Subscriptions, Tag: "work"}, 0, 10)
+	require.NoError(t, err, "get timers by tag failed")
+	require.Equal(t, []uuid.UUID{timerId}, timerIds(timers), "wrong timers of tag")
+	require.Equal(t, []string{"daily", "work"}, timers[0].Tags, "wrong tags of timer")
+
+	// tags are private
+	timers, err = testTimerStorage.FilteredTimers(ctx, creator, &timermodel.TimerFilter{Tag: "work"}, 0, 10)
+	require.NoError(t, err, "get timers by tag failed")
+	require.Empty(t, timers, "tag of subscriber visible for creator")
+
+	err = testFolderStorage.RenameTag(ctx, subscriber, "work", "daily")
+	require.NoError(t, err, "rename tag failed")
+	tags, err := testFolderStorage.UserTags(ctx, subscriber)
+	require.NoError(t, err, "get user tags failed")
+	require.Equal(t, []*foldermodel.TagInfo{{Name: "daily", TimersCount: 1}}, tags, "tags not merged")
+
+	err = testFolderStorage.DeleteTag(ctx, subscriber, "work")
+	require.ErrorIs(t, err, foldererror.ExceptionTagNotFound(), "delete not exists tag wrong error")
+
+	// tags are removed with subscription
+	require.NoError(t, testTimerStorage.Unsubscribe(ctx, timerId, subscriber), "unsubscribe failed")
+	tags, err = testFolderStorage.UserTags(ctx, subscriber)
+	require.NoError(t, err, "get user tags failed")
+	require.Empty(t, tags, "tags not removed after unsubscribe")
+}
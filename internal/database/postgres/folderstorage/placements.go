@@ -0,0 +1,85 @@
+package folderstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/placementsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+// moved timer loses manual position and goes after ordered timers of folder
+var moveTimerQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s) VALUES ($1,$2,$3) ON CONFLICT ON CONSTRAINT %s DO UPDATE SET %s = $3, %s = NULL`,
+	placementsql.Table,
+	placementsql.TimerId,
+	placementsql.UserId,
+	placementsql.FolderId,
+	placementsql.PrimaryKey,
+	placementsql.FolderId,
+	placementsql.Position,
+)
+
+// move timer into folder of user, nil folder moves timer out of folders
+func (s *Storage) MoveTimer(ctx context.Context, timerId uuid.UUID, userId int64, folderId *uuid.UUID) error {
+	_, err := s.p.Pool.Exec(ctx, moveTimerQuery, timerId, userId, folderId)
+	if err != nil {
+		return Error(err, exception.NewCause("upsert timer folder", "MoveTimer", _PROVIDER))
+	}
+	return nil
+}
+
+var pinTimerQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s) VALUES ($1,$2,$3) ON CONFLICT ON CONSTRAINT %s DO UPDATE SET %s = $3`,
+	placementsql.Table,
+	placementsql.TimerId,
+	placementsql.UserId,
+	placementsql.IsPinned,
+	placementsql.PrimaryKey,
+	placementsql.IsPinned,
+)
+
+func (s *Storage) PinTimer(ctx context.Context, timerId uuid.UUID, userId int64, isPinned bool) error {
+	_, err := s.p.Pool.Exec(ctx, pinTimerQuery, timerId, userId, isPinned)
+	if err != nil {
+		return Error(err, exception.NewCause("upsert timer pin", "PinTimer", _PROVIDER))
+	}
+	return nil
+}
+
+// position is set only if user is subscriber of timer
+var timerPositionQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s) SELECT $1,$2,$3 WHERE EXISTS (SELECT 1 FROM %s WHERE %s = $1 AND %s = $2)
+	ON CONFLICT ON CONSTRAINT %s DO UPDATE SET %s = $3`,
+	placementsql.Table,
+	placementsql.TimerId,
+	placementsql.UserId,
+	placementsql.Position,
+	subscribersql.Table,
+	subscribersql.TimerId,
+	subscribersql.UserId,
+	placementsql.PrimaryKey,
+	placementsql.Position,
+)
+
+// set manual position of timers by order of ids, timers which user isn't subscribed on are skipped
+func (s *Storage) OrderTimers(ctx context.Context, userId int64, timerIds []uuid.UUID) error {
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "OrderTimers", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	for position, timerId := range timerIds {
+		_, err = tx.Exec(ctx, timerPositionQuery, timerId, userId, position)
+		if err != nil {
+			return Error(err, exception.NewCause("upsert timer position", "OrderTimers", _PROVIDER))
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "OrderTimers", _PROVIDER))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package folderstorage
+
+import (
+	"errors"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/placementsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/tagsql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const _PROVIDER = "internal/database/postgres/folderstorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+func Error(err error, cause exception.Cause) error {
+	pgerr := new(pgconn.PgError)
+	if errors.As(err, &pgerr) {
+		switch pgerr.ConstraintName {
+		// placements and tags exist only for subscribers
+		case placementsql.FK_Subscribers:
+			return exception.Wrap(timererror.ExceptionTimerNotFound(), cause)
+		case tagsql.FK_Subscribers:
+			return exception.Wrap(timererror.ExceptionTimerNotFound(), cause)
+		case placementsql.FK_Folders:
+			return exception.Wrap(foldererror.ExceptionFolderNotFound(), cause)
+		}
+	}
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return exception.Wrap(foldererror.ExceptionFolderNotFound(), cause)
+	default:
+		return exception.Wrap(err, cause)
+	}
+}
@@ -0,0 +1,143 @@
+package folderstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/tagsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var deleteTimerTagsQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1 AND %s = $2`,
+	tagsql.Table,
+	tagsql.TimerId,
+	tagsql.UserId,
+)
+
+var insertTimerTagQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s) VALUES ($1,$2,$3)`,
+	tagsql.Table,
+	tagsql.TimerId,
+	tagsql.UserId,
+	tagsql.Tag,
+)
+
+// replace user tags of timer, tags should be unique
+func (s *Storage) SetTimerTags(ctx context.Context, timerId uuid.UUID, userId int64, tags []foldermodel.Tag) error {
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "SetTimerTags", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	_, err = tx.Exec(ctx, deleteTimerTagsQuery, timerId, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete timer tags", "SetTimerTags", _PROVIDER))
+	}
+	for _, tag := range tags {
+		_, err = tx.Exec(ctx, insertTimerTagQuery, timerId, userId, tag)
+		if err != nil {
+			return Error(err, exception.NewCause("insert timer tag", "SetTimerTags", _PROVIDER))
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "SetTimerTags", _PROVIDER))
+	}
+	return nil
+}
+
+var userTagsQuery = fmt.Sprintf(
+	`SELECT %s, count(*) FROM %s INNER JOIN %s ON %s = %s AND NOT %s WHERE %s = $1 GROUP BY %s ORDER BY %s`,
+	sqlutils.Full(tagsql.Tag),
+	tagsql.Table,
+	timersql.Table,
+	sqlutils.Full(timersql.ID),
+	sqlutils.Full(tagsql.TimerId),
+	sqlutils.Full(timersql.IsDeleted),
+	sqlutils.Full(tagsql.UserId),
+	sqlutils.Full(tagsql.Tag),
+	sqlutils.Full(tagsql.Tag),
+)
+
+func scanTag(row pgx.Row, tag *foldermodel.TagInfo) error {
+	return row.Scan(&tag.Name, &tag.TimersCount)
+}
+
+// user tags of not deleted timers ordered by name
+func (s *Storage) UserTags(ctx context.Context, userId int64) ([]*foldermodel.TagInfo, error) {
+	rows, err := s.p.Pool.Query(ctx, userTagsQuery, userId)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("user tags query", "UserTags", _PROVIDER))
+	}
+	tags, err := sqlutils.ScanList(rows, scanTag)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan tag list", "UserTags", _PROVIDER))
+	}
+	return tags, nil
+}
+
+// timers which already have new tag keep one tag
+var copyTagQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s) SELECT %s,%s,$3 FROM %s WHERE %s = $1 AND %s = $2 ON CONFLICT ON CONSTRAINT %s DO NOTHING`,
+	tagsql.Table,
+	tagsql.TimerId,
+	tagsql.UserId,
+	tagsql.Tag,
+	tagsql.TimerId,
+	tagsql.UserId,
+	tagsql.Table,
+	tagsql.UserId,
+	tagsql.Tag,
+	tagsql.PrimaryKey,
+)
+
+var deleteTagQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1 AND %s = $2`,
+	tagsql.Table,
+	tagsql.UserId,
+	tagsql.Tag,
+)
+
+// rename user tag on every timer, tag is merged if user already has tag with new name
+func (s *Storage) RenameTag(ctx context.Context, userId int64, tag foldermodel.Tag, name foldermodel.Tag) error {
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "RenameTag", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	_, err = tx.Exec(ctx, copyTagQuery, userId, tag, name)
+	if err != nil {
+		return Error(err, exception.NewCause("copy tag", "RenameTag", _PROVIDER))
+	}
+	cmd, err := tx.Exec(ctx, deleteTagQuery, userId, tag)
+	if err != nil {
+		return Error(err, exception.NewCause("delete old tag", "RenameTag", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(foldererror.ExceptionTagNotFound(), exception.NewCause("delete old tag rows = 0", "RenameTag", _PROVIDER))
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "RenameTag", _PROVIDER))
+	}
+	return nil
+}
+
+// remove user tag from every timer
+func (s *Storage) DeleteTag(ctx context.Context, userId int64, tag foldermodel.Tag) error {
+	cmd, err := s.p.Pool.Exec(ctx, deleteTagQuery, userId, tag)
+	if err != nil {
+		return Error(err, exception.NewCause("delete tag", "DeleteTag", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(foldererror.ExceptionTagNotFound(), exception.NewCause("delete tag rows = 0", "DeleteTag", _PROVIDER))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package notificationstorage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Tap-Team/timerapi/internal/model/notification"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/colorsql"
@@ -10,6 +11,7 @@ import (
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/notificationtypesql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/typesql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/sqlutils"
 	"github.com/google/uuid"
@@ -38,6 +40,8 @@ var insertNotificationQuery = fmt.Sprintf(`
 )
 
 func (s *Storage) InsertNotification(ctx context.Context, userId int64, notification notification.Notification) error {
+	ctx, span := tracing.Start(ctx, "notificationstorage.InsertNotification")
+	defer span.End()
 	_, err := s.p.Pool.Exec(ctx, insertNotificationQuery, userId, notification.TimerId(), notification.Type())
 	if err != nil {
 		return Error(err, exception.NewCause("insert notification", "InsertNotification", _PROVIDER))
@@ -72,6 +76,7 @@ func userNotificationTemplate(query string) string {
 			colorsql.Color,
 			timersql.WithMusic,
 			timersql.Duration,
+			timersql.TimeZone,
 		),
 
 		notificationsql.Table,
@@ -112,7 +117,7 @@ var userNotificationsQuery = userNotificationTemplate(
 )
 
 func scanNotification(row pgx.Row, notification *notification.NotificationDTO) error {
-	return row.Scan(
+	err := row.Scan(
 		&notification.Ntype,
 		&notification.NTimer.ID,
 		&notification.NTimer.UTC,
@@ -124,10 +129,18 @@ func scanNotification(row pgx.Row, notification *notification.NotificationDTO) e
 		&notification.NTimer.Color,
 		&notification.NTimer.WithMusic,
 		&notification.NTimer.Duration,
+		&notification.NTimer.TimeZone,
 	)
+	if err != nil {
+		return err
+	}
+	notification.NTimer.Offset = notification.NTimer.TimeZone.Offset(time.Now())
+	return nil
 }
 
 func (s *Storage) UserNotifications(ctx context.Context, userId int64) ([]*notification.NotificationDTO, error) {
+	ctx, span := tracing.Start(ctx, "notificationstorage.UserNotifications")
+	defer span.End()
 	rows, err := s.p.Pool.Query(ctx, userNotificationsQuery, userId)
 	if err != nil {
 		return nil, Error(err, exception.NewCause("user notification query", "UserNotifications", _PROVIDER))
@@ -148,6 +161,8 @@ var notificationQuery = userNotificationTemplate(
 )
 
 func (s *Storage) Notification(ctx context.Context, userId int64, timerId uuid.UUID) (*notification.NotificationDTO, error) {
+	ctx, span := tracing.Start(ctx, "notificationstorage.Notification")
+	defer span.End()
 	ntion := new(notification.NotificationDTO)
 	err := scanNotification(s.p.Pool.QueryRow(ctx, notificationQuery, userId, timerId), ntion)
 	if err != nil {
@@ -165,6 +180,8 @@ var deleteUserNotificationQuery = fmt.Sprintf(`
 )
 
 func (s *Storage) DeleteUserNotifications(ctx context.Context, userId int64) error {
+	ctx, span := tracing.Start(ctx, "notificationstorage.DeleteUserNotifications")
+	defer span.End()
 	_, err := s.p.Pool.Exec(ctx, deleteUserNotificationQuery, userId)
 	if err != nil {
 		return Error(err, exception.NewCause("delete query", "DeleteUserNotifications", _PROVIDER))
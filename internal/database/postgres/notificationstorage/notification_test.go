@@ -45,7 +45,7 @@ func TestNotificationCrud(t *testing.T) {
 	defer cancel()
 	userId := rand.Int63()
 	timer := randomTimer()
-	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	require.NoError(t, err, "insert date timer err")
 	err = testTimerStorage.DeleteTimer(ctx, timer.ID)
 	require.NoError(t, err, "delete date timer err")
@@ -0,0 +1,69 @@
+package soundstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSounds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sounds, err := testSoundStorage.Sounds(ctx)
+	require.NoError(t, err, "get sounds failed")
+	require.NotEmpty(t, sounds, "sounds catalog is empty")
+	require.Equal(t, timerfields.DefaultSound, sounds[0].ID, "default sound isn't first")
+}
+
+func TestSubscriberSound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	creator := rand.Int63()
+	timer := timermodel.NewCreateTimer(
+		uuid.New(),
+		0,
+		amidtime.Now(),
+		amidtime.DateTime(time.Now().Add(time.Hour)),
+		timerfields.DATE,
+		"timer",
+		"",
+		timerfields.BLUE,
+		true,
+	)
+	err := testTimerStorage.InsertDateTimer(ctx, creator, timer, 0)
+	require.NoError(t, err, "insert timer failed")
+
+	// timer with music only gets default sound
+	tm, err := testTimerStorage.UserTimer(ctx, timer.ID, creator)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, timerfields.DefaultSound, tm.SoundId, "default sound not set")
+	require.Equal(t, timerfields.MaxVolume, tm.Volume, "default volume not set")
+	require.Nil(t, tm.SubscriberSound, "subscriber sound is set")
+
+	sound := &timermodel.SubscriberSound{SoundId: 2, Volume: 40}
+	err = testSoundStorage.SetSubscriberSound(ctx, timer.ID, creator, sound)
+	require.NoError(t, err, "set subscriber sound failed")
+	tm, err = testTimerStorage.UserTimer(ctx, timer.ID, creator)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, sound, tm.SubscriberSound, "wrong subscriber sound")
+	require.Equal(t, timerfields.DefaultSound, tm.SoundId, "timer sound changed by subscriber")
+
+	err = testSoundStorage.SetSubscriberSound(ctx, timer.ID, creator, &timermodel.SubscriberSound{SoundId: 10000, Volume: 40})
+	require.ErrorIs(t, err, timererror.ExceptionSoundNotFound(), "wrong error of no exists sound")
+	err = testSoundStorage.SetSubscriberSound(ctx, timer.ID, rand.Int63(), sound)
+	require.ErrorIs(t, err, timererror.ExceptionUserNotSubscriber(), "wrong error of not subscriber")
+
+	err = testSoundStorage.SetSubscriberSound(ctx, timer.ID, creator, nil)
+	require.NoError(t, err, "reset subscriber sound failed")
+	tm, err = testTimerStorage.UserTimer(ctx, timer.ID, creator)
+	require.NoError(t, err, "get timer failed")
+	require.Nil(t, tm.SubscriberSound, "subscriber sound not reset")
+}
@@ -0,0 +1,93 @@
+package soundstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/soundmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/soundsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const _PROVIDER = "internal/database/postgres/soundstorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+func Error(err error, cause exception.Cause) error {
+	pgerr := new(pgconn.PgError)
+	if errors.As(err, &pgerr) && pgerr.ConstraintName == subscribersql.FK_Sounds {
+		return exception.Wrap(timererror.ExceptionSoundNotFound(), cause)
+	}
+	return exception.Wrap(err, cause)
+}
+
+var soundsQuery = fmt.Sprintf(
+	`SELECT %s, %s, %s, %s FROM %s ORDER BY %s, %s`,
+	soundsql.ID,
+	soundsql.Title,
+	soundsql.Duration,
+	soundsql.URL,
+	soundsql.Table,
+	soundsql.Position,
+	soundsql.ID,
+)
+
+// sounds in catalog order
+func (s *Storage) Sounds(ctx context.Context) ([]*soundmodel.Sound, error) {
+	rows, err := s.p.Pool.Query(ctx, soundsQuery)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("select sounds", "Sounds", _PROVIDER))
+	}
+	defer rows.Close()
+	sounds := make([]*soundmodel.Sound, 0)
+	for rows.Next() {
+		sound := new(soundmodel.Sound)
+		err = rows.Scan(&sound.ID, &sound.Title, &sound.Duration, &sound.URL)
+		if err != nil {
+			return nil, Error(err, exception.NewCause("scan sound", "Sounds", _PROVIDER))
+		}
+		sounds = append(sounds, sound)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, Error(err, exception.NewCause("read sounds", "Sounds", _PROVIDER))
+	}
+	return sounds, nil
+}
+
+var setSubscriberSoundQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = $3, %s = $4 WHERE %s = $1 AND %s = $2`,
+	subscribersql.Table,
+	subscribersql.SoundId,
+	subscribersql.Volume,
+	subscribersql.TimerId,
+	subscribersql.UserId,
+)
+
+// set sound of subscriber, nil sound returns subscriber to timer sound
+func (s *Storage) SetSubscriberSound(ctx context.Context, timerId uuid.UUID, userId int64, sound *timermodel.SubscriberSound) error {
+	var soundId, volume any
+	if sound != nil {
+		soundId, volume = sound.SoundId, sound.Volume
+	}
+	cmd, err := s.p.Pool.Exec(ctx, setSubscriberSoundQuery, timerId, userId, soundId, volume)
+	if err != nil {
+		return Error(err, exception.NewCause("update subscriber sound", "SetSubscriberSound", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(timererror.ExceptionUserNotSubscriber(), exception.NewCause("update subscriber sound", "SetSubscriberSound", _PROVIDER))
+	}
+	return nil
+}
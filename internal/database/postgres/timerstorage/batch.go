@@ -0,0 +1,145 @@
+package timerstorage
+
+import (
+	"context"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// run n items in one transaction, every item is executed in own savepoint
+// atomic batch is rolled back on first item error, other items get batch aborted exception
+// best effort batch rolls back only failed item savepoint and commits the rest
+// returns error of every item, nil item error means item was committed
+func (s *Storage) batchTx(ctx context.Context, n int, atomic bool, item func(ctx context.Context, tx pgx.Tx, i int) error) ([]error, error) {
+	errs := make([]error, n)
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("begin tx", "batchTx", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	failed := false
+	for i := 0; i < n; i++ {
+		if failed {
+			errs[i] = timererror.ExceptionBatchAborted()
+			continue
+		}
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, Error(err, exception.NewCause("begin savepoint", "batchTx", _PROVIDER))
+		}
+		err = item(ctx, sp, i)
+		if err != nil {
+			errs[i] = err
+			if rerr := sp.Rollback(ctx); rerr != nil {
+				return nil, Error(rerr, exception.NewCause("rollback savepoint", "batchTx", _PROVIDER))
+			}
+			failed = atomic
+			continue
+		}
+		err = sp.Commit(ctx)
+		if err != nil {
+			return nil, Error(err, exception.NewCause("release savepoint", "batchTx", _PROVIDER))
+		}
+	}
+	if failed {
+		// items executed before failed one are rolled back too
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = timererror.ExceptionBatchAborted()
+			}
+		}
+		return errs, nil
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("commit tx", "batchTx", _PROVIDER))
+	}
+	return errs, nil
+}
+
+// insert timers of any type in one transaction, creator is subscribed to every timer
+// timers quota is checked for every timer, so items over quota get quota error
+func (s *Storage) InsertTimers(ctx context.Context, creator int64, timers []*timermodel.CreateTimer, maxTimers int, atomic bool) ([]error, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.InsertTimers")
+	defer span.End()
+	return s.batchTx(ctx, len(timers), atomic, func(ctx context.Context, tx pgx.Tx, i int) error {
+		timer := timers[i]
+		err := insertTimerTx(ctx, tx, creator, timer, maxTimers)
+		if err != nil {
+			return err
+		}
+		if timer.Type != timerfields.COUNTDOWN {
+			return nil
+		}
+		_, err = tx.Exec(ctx, insertCountDownTimerQuery, timer.ID)
+		if err != nil {
+			return Error(err, exception.NewCause("insert countdown timer", "InsertTimers", _PROVIDER))
+		}
+		return nil
+	})
+}
+
+func (s *Storage) DeleteTimers(ctx context.Context, timerIds []uuid.UUID, atomic bool) ([]error, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.DeleteTimers")
+	defer span.End()
+	return s.batchTx(ctx, len(timerIds), atomic, func(ctx context.Context, tx pgx.Tx, i int) error {
+		cmd, err := tx.Exec(ctx, deleteTimerQuery, timerIds[i])
+		if err != nil {
+			return Error(err, exception.NewCause("delete timer query", "DeleteTimers", _PROVIDER))
+		}
+		if cmd.RowsAffected() == 0 {
+			return timererror.ExceptionTimerNotFound()
+		}
+		return nil
+	})
+}
+
+func (s *Storage) SubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.SubscribeUsers")
+	defer span.End()
+	return s.batchTx(ctx, len(userIds), atomic, func(ctx context.Context, tx pgx.Tx, i int) error {
+		_, err := tx.Exec(ctx, subscribeQuery, userIds[i], timerId)
+		if err != nil {
+			return Error(err, exception.NewCause("insert into subscribers table", "SubscribeUsers", _PROVIDER))
+		}
+		return nil
+	})
+}
+
+// subscribe users which are allowed to subscribe, every user is checked inside of batch transaction
+func (s *Storage) SubscribeAllowedUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, quota timermodel.Quota, atomic bool) ([]error, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.SubscribeAllowedUsers")
+	defer span.End()
+	return s.batchTx(ctx, len(userIds), atomic, func(ctx context.Context, tx pgx.Tx, i int) error {
+		err := checkSubscribeTx(ctx, tx, timerId, userIds[i], quota)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, subscribeQuery, userIds[i], timerId)
+		if err != nil {
+			return Error(err, exception.NewCause("insert into subscribers table", "SubscribeAllowedUsers", _PROVIDER))
+		}
+		return nil
+	})
+}
+
+func (s *Storage) UnsubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.UnsubscribeUsers")
+	defer span.End()
+	return s.batchTx(ctx, len(userIds), atomic, func(ctx context.Context, tx pgx.Tx, i int) error {
+		cmd, err := tx.Exec(ctx, unsubcribeQuery, userIds[i], timerId)
+		if err != nil {
+			return Error(err, exception.NewCause("delete from subscribers table", "UnsubscribeUsers", _PROVIDER))
+		}
+		if cmd.RowsAffected() == 0 {
+			return timererror.ExceptionTimerSubscribersNotFound()
+		}
+		return nil
+	})
+}
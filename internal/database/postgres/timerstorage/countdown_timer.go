@@ -3,6 +3,7 @@ package timerstorage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
@@ -11,6 +12,7 @@ import (
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/countdowntimersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/typesql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/sqlutils"
@@ -24,14 +26,17 @@ var insertCountDownTimerQuery = fmt.Sprintf(
 	countdowntimersql.TimerId,
 )
 
-func (s *Storage) InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+// insert countdown timer, 0 max timers means creator has no timers quota
+func (s *Storage) InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.InsertCountdownTimer")
+	defer span.End()
 	tx, err := s.p.Pool.Begin(ctx)
 	if err != nil {
 		return Error(err, exception.NewCause("begin tx", "InsertCountDownTimer", _PROVIDER))
 	}
 	defer tx.Rollback(ctx)
 	timer.Type = timerfields.COUNTDOWN
-	err = insertTimerTx(ctx, tx, creator, timer)
+	err = insertTimerTx(ctx, tx, creator, timer, maxTimers)
 	if err != nil {
 		return Error(err, exception.NewCause("insert timer into storage", "InsertCountDownTimer", _PROVIDER))
 	}
@@ -51,20 +56,71 @@ func (s *Storage) InsertCountdownTimer(ctx context.Context, creator int64, timer
 }
 
 var updateTimerPauseTimeQuery = fmt.Sprintf(
-	`UPDATE %s SET %s = $1, %s = $2 WHERE %s = $3`,
+	`
+	WITH timer AS (UPDATE %s SET %s = %s + 1 WHERE %s = $3 AND %s RETURNING %s)
+	UPDATE %s SET %s = $1, %s = $2 FROM timer WHERE %s = timer.%s
+	`,
+	// increment timer version if it is expected
+	timersql.Table,
+	timersql.Version,
+	timersql.Version,
+	timersql.ID,
+	versionCondition("$4"),
+	timersql.ID,
+
 	countdowntimersql.Table,
 	countdowntimersql.PauseTime,
 	countdowntimersql.IsPaused,
-	countdowntimersql.TimerId,
+	sqlutils.Full(countdowntimersql.TimerId),
+	timersql.ID,
 )
 
-func (s *Storage) UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool) error {
-	cmd, err := s.p.Pool.Exec(ctx, updateTimerPauseTimeQuery, pauseTime, isPaused, timerId)
+func (s *Storage) UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.UpdatePauseTime")
+	defer span.End()
+	cmd, err := s.p.Pool.Exec(ctx, updateTimerPauseTimeQuery, pauseTime, isPaused, timerId, version)
 	if err != nil {
 		return Error(err, exception.NewCause("update timer pause time failed", "UpdatePauseTime", _PROVIDER))
 	}
 	if cmd.RowsAffected() == 0 {
-		return Error(timererror.ExceptionCountDownTimerNotFound(), exception.NewCause("update timer rows = 0", "UpdatePauseTime", _PROVIDER))
+		err = s.versionError(ctx, timerId, version, timererror.ExceptionCountDownTimerNotFound())
+		return Error(err, exception.NewCause("update timer rows = 0", "UpdatePauseTime", _PROVIDER))
+	}
+	return nil
+}
+
+var updateTimeAndPauseQuery = fmt.Sprintf(
+	`
+	WITH timer AS (UPDATE %s SET %s = $1, %s = %s + 1 WHERE %s = $4 AND %s RETURNING %s)
+	UPDATE %s SET %s = $2, %s = $3 FROM timer WHERE %s = timer.%s
+	`,
+	// update end time and increment timer version if it is expected
+	timersql.Table,
+	timersql.EndTime,
+	timersql.Version,
+	timersql.Version,
+	timersql.ID,
+	versionCondition("$5"),
+	timersql.ID,
+
+	countdowntimersql.Table,
+	countdowntimersql.PauseTime,
+	countdowntimersql.IsPaused,
+	sqlutils.Full(countdowntimersql.TimerId),
+	timersql.ID,
+)
+
+// update end time and pause of countdown timer by one statement, so version is incremented once
+func (s *Storage) UpdateTimeAndPause(ctx context.Context, timerId uuid.UUID, endTime, pauseTime amidtime.DateTime, isPaused bool, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.UpdateTimeAndPause")
+	defer span.End()
+	cmd, err := s.p.Pool.Exec(ctx, updateTimeAndPauseQuery, endTime, pauseTime, isPaused, timerId, version)
+	if err != nil {
+		return Error(err, exception.NewCause("update timer end time and pause time", "UpdateTimeAndPause", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		err = s.versionError(ctx, timerId, version, timererror.ExceptionCountDownTimerNotFound())
+		return Error(err, exception.NewCause("update timer rows = 0", "UpdateTimeAndPause", _PROVIDER))
 	}
 	return nil
 }
@@ -95,6 +151,8 @@ func scanTimerPause(row pgx.Row, tp *timermodel.TimerPause) error {
 }
 
 func (s *Storage) TimerPause(ctx context.Context, timerId uuid.UUID) (*timermodel.TimerPause, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.TimerPause")
+	defer span.End()
 	tp := &timermodel.TimerPause{ID: timerId}
 	row := s.p.Pool.QueryRow(ctx, timerPauseQuery, timerId)
 	err := scanTimerPause(row, tp)
@@ -106,7 +164,7 @@ func (s *Storage) TimerPause(ctx context.Context, timerId uuid.UUID) (*timermode
 
 var countdownTimerQuery = fmt.Sprintf(
 	`SELECT 
-		%s 
+		%s, coalesce(%s, 0)
 	FROM %s 
 	INNER JOIN %s ON %s = %s AND NOT %s
 	INNER JOIN %s ON %s = %s
@@ -131,7 +189,13 @@ var countdownTimerQuery = fmt.Sprintf(
 		timersql.Duration,
 		countdowntimersql.PauseTime,
 		countdowntimersql.IsPaused,
+		timersql.Version,
+		timersql.TimeZone,
+		timersql.CustomColor,
+		timersql.CustomGradient,
+		timersql.Volume,
 	),
+	sqlutils.Full(timersql.SoundId),
 
 	// from timers
 	timersql.Table,
@@ -164,7 +228,8 @@ var countdownTimerQuery = fmt.Sprintf(
 )
 
 func scanCountdownTimer(row pgx.Row, timer *timermodel.CountdownTimer) error {
-	return row.Scan(
+	var customColor, customGradient timerfields.HexColor
+	err := row.Scan(
 		&timer.ID,
 		&timer.UTC,
 		&timer.Creator,
@@ -177,10 +242,26 @@ func scanCountdownTimer(row pgx.Row, timer *timermodel.CountdownTimer) error {
 		&timer.Duration,
 		&timer.PauseTime,
 		&timer.IsPaused,
+		&timer.Version,
+		&timer.TimeZone,
+		&customColor,
+		&customGradient,
+		&timer.Volume,
+		&timer.SoundId,
 	)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	timer.Offset = timer.TimeZone.Offset(now)
+	timer.UTC = timer.TimeZone.UTC(now, timer.UTC)
+	timer.CustomColor = timerfields.NewCustomColor(customColor, customGradient)
+	return nil
 }
 
 func (s *Storage) CountdownTimer(ctx context.Context, timerId uuid.UUID) (*timermodel.CountdownTimer, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.CountdownTimer")
+	defer span.End()
 	row := s.p.Pool.QueryRow(ctx, countdownTimerQuery, timerId)
 	timer := new(timermodel.CountdownTimer)
 	err := scanCountdownTimer(row, timer)
@@ -20,7 +20,7 @@ func TestCountDownTimerCrud(t *testing.T) {
 	timer := randomTimer(func(t *timermodel.Timer) { t.Type = timerfields.COUNTDOWN })
 	defer testTimerStorage.DeleteTimer(ctx, timer.ID)
 	t1 := *timer
-	err := testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer())
+	err := testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
@@ -64,7 +64,7 @@ func TestUpdateCountDownTimer(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	timer := randomTimer(func(t *timermodel.Timer) { t.Type = timerfields.COUNTDOWN })
-	err := testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer())
+	err := testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
@@ -72,7 +72,7 @@ func TestUpdateCountDownTimer(t *testing.T) {
 	// pause timer data for update
 	pauseTime := amidtime.DateTime(time.Now().Add(time.Second * time.Duration(rand.Uint32())))
 	isPaused := rand.Int()%2 == 0
-	err = testTimerStorage.UpdatePauseTime(ctx, timer.ID, pauseTime, isPaused)
+	err = testTimerStorage.UpdatePauseTime(ctx, timer.ID, pauseTime, isPaused, 0)
 	require.NoError(t, err, "update pause time test failed")
 	// get countdown timer to compare
 	ctTimer, err := testTimerStorage.Timer(ctx, timer.ID)
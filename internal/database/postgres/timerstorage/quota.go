@@ -0,0 +1,77 @@
+package timerstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/jackc/pgx/v5"
+)
+
+// transaction level advisory lock of user quota, released on commit or rollback
+// concurrent transactions of one user wait for each other, so quota can't be exceeded by parallel requests
+var lockUserQuotaQuery = `SELECT pg_advisory_xact_lock(hashtextextended($1::text || '_' || $2::text, 0))`
+
+const (
+	timersQuotaLock        = "timers"
+	subscriptionsQuotaLock = "subscriptions"
+)
+
+func lockUserQuotaTx(ctx context.Context, tx pgx.Tx, lock string, userId int64) error {
+	_, err := tx.Exec(ctx, lockUserQuotaQuery, lock, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("lock user quota", "lockUserQuotaTx", _PROVIDER))
+	}
+	return nil
+}
+
+// count of active timers created by user
+var userCreatedCountQuery = fmt.Sprintf(`
+	SELECT count(*) FROM %s WHERE %s = $1 AND NOT %s
+`,
+	timersql.Table,
+	timersql.Creator,
+	timersql.IsDeleted,
+)
+
+// check creator can create one more timer, 0 max timers means no quota
+func checkTimersQuotaTx(ctx context.Context, tx pgx.Tx, creator int64, maxTimers int) error {
+	if maxTimers == 0 {
+		return nil
+	}
+	err := lockUserQuotaTx(ctx, tx, timersQuotaLock, creator)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("lock timers quota", "checkTimersQuotaTx", _PROVIDER))
+	}
+	var count int
+	err = tx.QueryRow(ctx, userCreatedCountQuery, creator).Scan(&count)
+	if err != nil {
+		return Error(err, exception.NewCause("select user created count", "checkTimersQuotaTx", _PROVIDER))
+	}
+	if count >= maxTimers {
+		return exception.Wrap(timererror.ExceptionTimersQuota(), exception.NewCause("compare created count with quota", "checkTimersQuotaTx", _PROVIDER))
+	}
+	return nil
+}
+
+// count of user subscriptions on active timers, own timers aren't counted
+var userSubscriptionsCountQuery = fmt.Sprintf(`
+	SELECT count(*)
+	FROM %s
+	INNER JOIN %s ON %s = %s AND NOT %s AND %s != $1
+	WHERE %s = $1
+`,
+	subscribersql.Table,
+
+	timersql.Table,
+	sqlutils.Full(timersql.ID),
+	sqlutils.Full(subscribersql.TimerId),
+	sqlutils.Full(timersql.IsDeleted),
+	sqlutils.Full(timersql.Creator),
+
+	sqlutils.Full(subscribersql.UserId),
+)
@@ -0,0 +1,64 @@
+package timerstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertTimersQuota(t *testing.T) {
+	ctx := context.Background()
+	creator := rand.Int63()
+	maxTimers := 2
+
+	timers := randomTimerList(3, func(t *timermodel.Timer) { t.Creator = creator })
+	createTimers := make([]*timermodel.CreateTimer, 0, len(timers))
+	for _, timer := range timers {
+		createTimers = append(createTimers, timer.CreateTimer())
+	}
+	errs, err := testTimerStorage.InsertTimers(ctx, creator, createTimers, maxTimers, false)
+	require.NoError(t, err, "insert timers failed")
+	require.NoError(t, errs[0], "timer under quota not inserted")
+	require.NoError(t, errs[1], "timer under quota not inserted")
+	require.ErrorIs(t, errs[2], timererror.ExceptionTimersQuota(), "timer over quota inserted")
+
+	err = testTimerStorage.InsertDateTimer(ctx, creator, randomTimer().CreateTimer(), maxTimers)
+	require.ErrorIs(t, err, timererror.ExceptionTimersQuota(), "timer over quota inserted")
+}
+
+func TestSubscribeAllowedConcurrentQuota(t *testing.T) {
+	ctx := context.Background()
+	userId := rand.Int63()
+	quota := timermodel.Quota{MaxSubscriptions: 2}
+
+	timers := randomTimerList(10)
+	for _, timer := range timers {
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+		require.NoError(t, err, "insert timer failed")
+	}
+	errs := make([]error, len(timers))
+	wg := new(sync.WaitGroup)
+	for i, timer := range timers {
+		wg.Add(1)
+		go func(i int, timer *timermodel.Timer) {
+			defer wg.Done()
+			errs[i] = testTimerStorage.SubscribeAllowed(ctx, timer.ID, userId, quota)
+		}(i, timer)
+	}
+	wg.Wait()
+
+	subscribed := 0
+	for _, err := range errs {
+		if err == nil {
+			subscribed++
+			continue
+		}
+		require.ErrorIs(t, err, timererror.ExceptionSubscriptionsQuota(), "wrong subscribe error")
+	}
+	require.Equal(t, quota.MaxSubscriptions, subscribed, "subscriptions quota exceeded by concurrent subscribes")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/countdowntimersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timerbansql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/postgres"
@@ -31,8 +32,14 @@ func Error(err error, cause exception.Cause) error {
 			return exception.Wrap(timererror.ExceptionTimerNotFound(), cause)
 		case subscribersql.FK_Timers:
 			return exception.Wrap(timererror.ExceptionTimerNotFound(), cause)
+		case timerbansql.FK_Timers:
+			return exception.Wrap(timererror.ExceptionTimerNotFound(), cause)
 		case subscribersql.PrimaryKey:
 			return exception.Wrap(timererror.ExceptionUserAlreadySubscriber(), cause)
+		case timersql.FK_Sounds:
+			return exception.Wrap(timererror.ExceptionSoundNotFound(), cause)
+		case subscribersql.FK_Sounds:
+			return exception.Wrap(timererror.ExceptionSoundNotFound(), cause)
 		case timersql.PrimaryKey:
 			return exception.Wrap(timererror.ExceptionTimerExists(), cause)
 		}
@@ -11,6 +11,7 @@ import (
 )
 
 var (
+	testPostgres     *postgres.Postgres
 	testTimerStorage *timerstorage.Storage
 )
 
@@ -18,11 +19,12 @@ func TestMain(m *testing.M) {
 	os.Setenv("TZ", "UTC")
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	postgres, terminate, err := postgres.NewContainer(ctx, postgres.DEFAULT_MIGRATION_PATH)
+	p, terminate, err := postgres.NewContainer(ctx, postgres.DEFAULT_MIGRATION_PATH)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer terminate(ctx)
-	testTimerStorage = timerstorage.New(postgres)
+	testPostgres = p
+	testTimerStorage = timerstorage.New(p)
 	m.Run()
 }
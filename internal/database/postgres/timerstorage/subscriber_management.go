@@ -0,0 +1,270 @@
+package timerstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timerbansql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var timerSubscribersPageQuery = fmt.Sprintf(`
+	SELECT %s
+	FROM %s
+	INNER JOIN %s ON %s = %s AND %s != %s
+	WHERE %s = $1
+	ORDER BY %s
+	LIMIT $2
+	OFFSET $3
+`,
+	sqlutils.Full(subscribersql.UserId, subscribersql.CreatedAt),
+	subscribersql.Table,
+
+	// creator isn't listed in subscribers
+	timersql.Table,
+	sqlutils.Full(timersql.ID),
+	sqlutils.Full(subscribersql.TimerId),
+	sqlutils.Full(subscribersql.UserId),
+	sqlutils.Full(timersql.Creator),
+
+	sqlutils.Full(subscribersql.TimerId),
+
+	sqlutils.Full(subscribersql.CreatedAt, subscribersql.UserId),
+)
+
+func scanSubscriber(row pgx.Row, subscriber *timermodel.Subscriber) error {
+	return row.Scan(&subscriber.UserId, &subscriber.SubscribedAt)
+}
+
+// timer subscribers except creator ordered by subscription time
+func (s *Storage) TimerSubscribersPage(ctx context.Context, timerId uuid.UUID, offset, limit int) ([]*timermodel.Subscriber, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.TimerSubscribersPage")
+	defer span.End()
+	rows, err := s.p.Pool.Query(ctx, timerSubscribersPageQuery, timerId, limit, offset)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("timer subscribers page query", "TimerSubscribersPage", _PROVIDER))
+	}
+	subscribers, err := sqlutils.ScanList(rows, scanSubscriber)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan rows into subscriber list", "TimerSubscribersPage", _PROVIDER))
+	}
+	return subscribers, nil
+}
+
+var subscribersLimitQuery = fmt.Sprintf(`
+	SELECT %s, %s FROM %s WHERE %s = $1 AND NOT %s
+`,
+	timersql.MaxSubscribers,
+	timersql.SubscribersCount,
+	timersql.Table,
+	timersql.ID,
+	timersql.IsDeleted,
+)
+
+func (s *Storage) SubscribersLimit(ctx context.Context, timerId uuid.UUID) (*timermodel.SubscribersLimit, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.SubscribersLimit")
+	defer span.End()
+	limit := new(timermodel.SubscribersLimit)
+	err := s.p.Pool.QueryRow(ctx, subscribersLimitQuery, timerId).Scan(&limit.MaxSubscribers, &limit.Count)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("select subscribers limit", "SubscribersLimit", _PROVIDER))
+	}
+	return limit, nil
+}
+
+var setMaxSubscribersQuery = fmt.Sprintf(`
+	UPDATE %s SET %s = $2, %s = %s + 1 WHERE %s = $1 AND NOT %s
+`,
+	timersql.Table,
+	timersql.MaxSubscribers,
+	timersql.Version,
+	timersql.Version,
+	timersql.ID,
+	timersql.IsDeleted,
+)
+
+func (s *Storage) SetMaxSubscribers(ctx context.Context, timerId uuid.UUID, maxSubscribers int) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.SetMaxSubscribers")
+	defer span.End()
+	cmd, err := s.p.Pool.Exec(ctx, setMaxSubscribersQuery, timerId, maxSubscribers)
+	if err != nil {
+		return Error(err, exception.NewCause("update max subscribers", "SetMaxSubscribers", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return exception.Wrap(timererror.ExceptionTimerNotFound(), exception.NewCause("update max subscribers rows = 0", "SetMaxSubscribers", _PROVIDER))
+	}
+	return nil
+}
+
+var removeSubscriberQuery = fmt.Sprintf(`
+	DELETE FROM %s WHERE %s = $1 AND %s = $2 RETURNING %s
+`,
+	subscribersql.Table,
+	subscribersql.UserId,
+	subscribersql.TimerId,
+	subscribersql.CreatedAt,
+)
+
+// remove subscriber, returns subscription time to restore subscriber, zero time if user isn't subscriber
+func (s *Storage) RemoveSubscriber(ctx context.Context, timerId uuid.UUID, userId int64) (amidtime.DateTime, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.RemoveSubscriber")
+	defer span.End()
+	var subscribedAt amidtime.DateTime
+	err := s.p.Pool.QueryRow(ctx, removeSubscriberQuery, userId, timerId).Scan(&subscribedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return amidtime.DateTime{}, nil
+	}
+	if err != nil {
+		return amidtime.DateTime{}, Error(err, exception.NewCause("delete from subscribers table", "RemoveSubscriber", _PROVIDER))
+	}
+	return subscribedAt, nil
+}
+
+var restoreSubscriberQuery = fmt.Sprintf(`
+	INSERT INTO %s (%s,%s,%s) VALUES ($1,$2,$3)
+`,
+	subscribersql.Table,
+	subscribersql.UserId,
+	subscribersql.TimerId,
+	subscribersql.CreatedAt,
+)
+
+// subscribe removed user with original subscription time, so subscriber keeps place in subscribers order
+func (s *Storage) RestoreSubscriber(ctx context.Context, timerId uuid.UUID, userId int64, subscribedAt amidtime.DateTime) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.RestoreSubscriber")
+	defer span.End()
+	_, err := s.p.Pool.Exec(ctx, restoreSubscriberQuery, userId, timerId, subscribedAt)
+	if err != nil {
+		return Error(err, exception.NewCause("insert into subscribers table", "RestoreSubscriber", _PROVIDER))
+	}
+	return nil
+}
+
+var banQuery = fmt.Sprintf(`
+	INSERT INTO %s (%s, %s) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`,
+	timerbansql.Table,
+	timerbansql.TimerId,
+	timerbansql.UserId,
+)
+
+// ban user from subscribing to timer, repeated ban isn't error
+func (s *Storage) Ban(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.Ban")
+	defer span.End()
+	_, err := s.p.Pool.Exec(ctx, banQuery, timerId, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("insert into bans table", "Ban", _PROVIDER))
+	}
+	return nil
+}
+
+var unbanQuery = fmt.Sprintf(`
+	DELETE FROM %s WHERE %s = $1 AND %s = $2
+`,
+	timerbansql.Table,
+	timerbansql.TimerId,
+	timerbansql.UserId,
+)
+
+func (s *Storage) Unban(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.Unban")
+	defer span.End()
+	_, err := s.p.Pool.Exec(ctx, unbanQuery, timerId, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete from bans table", "Unban", _PROVIDER))
+	}
+	return nil
+}
+
+var isBannedQuery = fmt.Sprintf(`
+	SELECT EXISTS (SELECT 1 FROM %s WHERE %s = $1 AND %s = $2)
+`,
+	timerbansql.Table,
+	timerbansql.TimerId,
+	timerbansql.UserId,
+)
+
+var lockSubscribersLimitQuery = fmt.Sprintf(`
+	SELECT %s, %s FROM %s WHERE %s = $1 AND NOT %s FOR UPDATE
+`,
+	timersql.MaxSubscribers,
+	timersql.SubscribersCount,
+	timersql.Table,
+	timersql.ID,
+	timersql.IsDeleted,
+)
+
+// check user isn't banned, timer has place for subscriber and user hasn't reached subscriptions quota
+// timer row and user quota are locked until the end of tx, so concurrent subscriptions are checked one by one
+func checkSubscribeTx(ctx context.Context, tx pgx.Tx, timerId uuid.UUID, userId int64, quota timermodel.Quota) error {
+	var banned bool
+	err := tx.QueryRow(ctx, isBannedQuery, timerId, userId).Scan(&banned)
+	if err != nil {
+		return Error(err, exception.NewCause("select ban", "checkSubscribeTx", _PROVIDER))
+	}
+	if banned {
+		return exception.Wrap(timererror.ExceptionUserBanned(), exception.NewCause("user banned by creator", "checkSubscribeTx", _PROVIDER))
+	}
+	limit := new(timermodel.SubscribersLimit)
+	err = tx.QueryRow(ctx, lockSubscribersLimitQuery, timerId).Scan(&limit.MaxSubscribers, &limit.Count)
+	if err != nil {
+		return Error(err, exception.NewCause("lock subscribers limit", "checkSubscribeTx", _PROVIDER))
+	}
+	if !limit.Allows() {
+		return exception.Wrap(timererror.ExceptionSubscribersLimit(), exception.NewCause("compare subscribers count with limit", "checkSubscribeTx", _PROVIDER))
+	}
+	if quota.MaxSubscribers > 0 && limit.Count >= quota.MaxSubscribers {
+		return exception.Wrap(timererror.ExceptionSubscribersLimit(), exception.NewCause("compare subscribers count with quota", "checkSubscribeTx", _PROVIDER))
+	}
+	if quota.MaxSubscriptions == 0 {
+		return nil
+	}
+	err = lockUserQuotaTx(ctx, tx, subscriptionsQuotaLock, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("lock subscriptions quota", "checkSubscribeTx", _PROVIDER))
+	}
+	var count int
+	err = tx.QueryRow(ctx, userSubscriptionsCountQuery, userId).Scan(&count)
+	if err != nil {
+		return Error(err, exception.NewCause("select user subscriptions count", "checkSubscribeTx", _PROVIDER))
+	}
+	if count >= quota.MaxSubscriptions {
+		return exception.Wrap(timererror.ExceptionSubscriptionsQuota(), exception.NewCause("compare subscriptions count with quota", "checkSubscribeTx", _PROVIDER))
+	}
+	return nil
+}
+
+// subscribe user if user is allowed to subscribe, check and insert are done in one transaction
+func (s *Storage) SubscribeAllowed(ctx context.Context, timerId uuid.UUID, userId int64, quota timermodel.Quota) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.SubscribeAllowed")
+	defer span.End()
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "SubscribeAllowed", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	err = checkSubscribeTx(ctx, tx, timerId, userId, quota)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check subscribe allowed", "SubscribeAllowed", _PROVIDER))
+	}
+	_, err = tx.Exec(ctx, subscribeQuery, userId, timerId)
+	if err != nil {
+		return Error(err, exception.NewCause("insert into subscribers table", "SubscribeAllowed", _PROVIDER))
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "SubscribeAllowed", _PROVIDER))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package timerstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeAllowedUsers(t *testing.T) {
+	ctx := context.Background()
+	timer := randomTimer()
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+	err = testTimerStorage.SetMaxSubscribers(ctx, timer.ID, 2)
+	require.NoError(t, err, "set max subscribers failed")
+
+	banned := rand.Int63()
+	err = testTimerStorage.Ban(ctx, timer.ID, banned)
+	require.NoError(t, err, "ban failed")
+
+	users := []int64{rand.Int63(), banned, rand.Int63(), rand.Int63()}
+	errs, err := testTimerStorage.SubscribeAllowedUsers(ctx, timer.ID, users, timermodel.Quota{}, false)
+	require.NoError(t, err, "subscribe allowed users failed")
+	require.NoError(t, errs[0], "allowed user not subscribed")
+	require.ErrorIs(t, errs[1], timererror.ExceptionUserBanned(), "banned user subscribed")
+	require.NoError(t, errs[2], "allowed user not subscribed")
+	require.ErrorIs(t, errs[3], timererror.ExceptionSubscribersLimit(), "user over limit subscribed")
+
+	limit, err := testTimerStorage.SubscribersLimit(ctx, timer.ID)
+	require.NoError(t, err, "get subscribers limit failed")
+	require.Equal(t, 2, limit.Count, "wrong subscribers count")
+}
+
+func TestSubscribeAllowedUsersQuota(t *testing.T) {
+	ctx := context.Background()
+	userId := rand.Int63()
+	timers := randomTimerList(3)
+	for _, timer := range timers {
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+		require.NoError(t, err, "insert timer failed")
+	}
+	quota := timermodel.Quota{MaxSubscriptions: 2}
+	for i, timer := range timers {
+		errs, err := testTimerStorage.SubscribeAllowedUsers(ctx, timer.ID, []int64{userId}, quota, true)
+		require.NoError(t, err, "subscribe allowed users failed")
+		if i < quota.MaxSubscriptions {
+			require.NoError(t, errs[0], "user under quota not subscribed")
+		} else {
+			require.ErrorIs(t, errs[0], timererror.ExceptionSubscriptionsQuota(), "user over quota subscribed")
+		}
+	}
+}
+
+func TestRestoreSubscriber(t *testing.T) {
+	ctx := context.Background()
+	timer := randomTimer()
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+	first, second := rand.Int63(), rand.Int63()
+	for _, userId := range []int64{first, second} {
+		err = testTimerStorage.Subscribe(ctx, timer.ID, userId)
+		require.NoError(t, err, "subscribe failed")
+	}
+	subscribers, err := testTimerStorage.TimerSubscribersPage(ctx, timer.ID, 0, 10)
+	require.NoError(t, err, "get subscribers failed")
+	require.Len(t, subscribers, 2, "wrong subscribers count")
+
+	var removed *timermodel.Subscriber
+	for _, subscriber := range subscribers {
+		if subscriber.UserId == first {
+			removed = subscriber
+		}
+	}
+	require.NotNil(t, removed, "subscriber not found")
+
+	subscribedAt, err := testTimerStorage.RemoveSubscriber(ctx, timer.ID, first)
+	require.NoError(t, err, "remove subscriber failed")
+	require.Equal(t, removed.SubscribedAt.Unix(), subscribedAt.Unix(), "wrong subscription time of removed subscriber")
+	err = testTimerStorage.RestoreSubscriber(ctx, timer.ID, first, subscribedAt)
+	require.NoError(t, err, "restore subscriber failed")
+	restored, err := testTimerStorage.TimerSubscribersPage(ctx, timer.ID, 0, 10)
+	require.NoError(t, err, "get subscribers failed")
+	require.Equal(t, subscribers, restored, "restored subscriber changed")
+
+	// user who isn't subscriber has zero subscription time
+	subscribedAt, err = testTimerStorage.RemoveSubscriber(ctx, timer.ID, rand.Int63())
+	require.NoError(t, err, "remove not subscriber failed")
+	require.True(t, subscribedAt.T().IsZero(), "not subscriber removed")
+}
+
+func TestSetMaxSubscribersNotFound(t *testing.T) {
+	ctx := context.Background()
+	err := testTimerStorage.SetMaxSubscribers(ctx, uuid.New(), 2)
+	require.ErrorIs(t, err, timererror.ExceptionTimerNotFound(), "wrong error of not existing timer")
+}
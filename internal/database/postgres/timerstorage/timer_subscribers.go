@@ -7,6 +7,7 @@ import (
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/sqlutils"
 	"github.com/jackc/pgx/v5"
@@ -52,6 +53,8 @@ func scanTimerSubscribers(row pgx.Row, timerSubscribers *timermodel.TimerSubscri
 }
 
 func (s *Storage) TimerWithSubscribers(ctx context.Context, offset, limit int) ([]*timermodel.TimerSubscribers, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.TimerWithSubscribers")
+	defer span.End()
 	rows, err := s.p.Pool.Query(ctx, timerSubscribersQuery, limit, offset)
 	if err != nil {
 		return nil, Error(err, exception.NewCause("timer subscriber query", "TimerWithSubscrribers", _PROVIDER))
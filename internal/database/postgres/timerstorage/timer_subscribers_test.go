@@ -30,10 +30,10 @@ func TestTimerWithSubscribers(t *testing.T) {
 
 	for _, timer := range timerList {
 		if rand.Intn(5)%2 == 0 {
-			err = testTimerStorage.InsertDateTimer(ctx, randomUserList[0], timer.CreateTimer())
+			err = testTimerStorage.InsertDateTimer(ctx, randomUserList[0], timer.CreateTimer(), 0)
 			require.NoError(t, err, "insert date timer failed")
 		} else {
-			err = testTimerStorage.InsertDateTimer(ctx, randomUserList[0], timer.CreateTimer())
+			err = testTimerStorage.InsertDateTimer(ctx, randomUserList[0], timer.CreateTimer(), 0)
 			require.NoError(t, err, "insert countdown timer failed")
 		}
 		err = testTimerStorage.SubscribeAll(ctx, timer.ID, randomUserList[1:]...)
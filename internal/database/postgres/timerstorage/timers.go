@@ -3,15 +3,21 @@ package timerstorage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/colorsql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/countdowntimersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/foldersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/placementsql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/tagsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timerbansql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/typesql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/sqlutils"
 	"github.com/google/uuid"
@@ -21,7 +27,7 @@ import (
 var insertTimerQuery = fmt.Sprintf(
 	`
 	INSERT INTO %s 
-		(%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
+		(%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 	VALUES 
 		(
 			$1,
@@ -33,7 +39,12 @@ var insertTimerQuery = fmt.Sprintf(
 			$7,
 			(SELECT %s FROM %s WHERE %s = $8),
 			$9,
-			$10
+			$10,
+			$11,
+			$12,
+			$13,
+			$14,
+			$15
 		)
 	`,
 
@@ -51,6 +62,11 @@ var insertTimerQuery = fmt.Sprintf(
 	timersql.ColorId,
 	timersql.WithMusic,
 	timersql.Duration,
+	timersql.TimeZone,
+	timersql.CustomColor,
+	timersql.CustomGradient,
+	timersql.SoundId,
+	timersql.Volume,
 
 	// select type id from types
 	typesql.ID,
@@ -63,21 +79,33 @@ var insertTimerQuery = fmt.Sprintf(
 	colorsql.Color,
 )
 
-func insertTimerTx(ctx context.Context, tx pgx.Tx, creator int64, timer *timermodel.CreateTimer) error {
-	var err error
+// insert timer and subscribe creator, timers quota of creator is checked in the same transaction
+func insertTimerTx(ctx context.Context, tx pgx.Tx, creator int64, timer *timermodel.CreateTimer, maxTimers int) error {
+	err := checkTimersQuotaTx(ctx, tx, creator, maxTimers)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check timers quota", "insertTimerTx", _PROVIDER))
+	}
+	zone, utc := timer.Zone(time.Now())
+	customColor, customGradient := timer.CustomColor.Columns()
+	soundId, volume := timer.Sound()
 	_, err = tx.Exec(
 		ctx,
 		insertTimerQuery,
 		timer.ID,
-		timer.UTC,
+		utc,
 		creator,
 		timer.EndTime,
 		timer.Type,
 		timer.Name,
 		timer.Description,
 		timer.Color,
-		timer.WithMusic,
+		soundId != 0,
 		timer.DefaultDuration(),
+		zone,
+		customColor,
+		customGradient,
+		soundId,
+		volume,
 	)
 	if err != nil {
 		return Error(err, exception.NewCause("insert timer into storage", "insertTimerTx", _PROVIDER))
@@ -89,14 +117,17 @@ func insertTimerTx(ctx context.Context, tx pgx.Tx, creator int64, timer *timermo
 	return nil
 }
 
-func (s *Storage) InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+// insert date timer, 0 max timers means creator has no timers quota
+func (s *Storage) InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.InsertDateTimer")
+	defer span.End()
 	tx, err := s.p.Pool.Begin(ctx)
 	if err != nil {
 		return Error(err, exception.NewCause("begin tx", "InsertDateTimer", _PROVIDER))
 	}
 	defer tx.Rollback(ctx)
 	timer.Type = timerfields.DATE
-	err = insertTimerTx(ctx, tx, creator, timer)
+	err = insertTimerTx(ctx, tx, creator, timer, maxTimers)
 	if err != nil {
 		return Error(err, exception.NewCause("insert date timer into storage", "InsertDateTimer", _PROVIDER))
 	}
@@ -109,14 +140,18 @@ func (s *Storage) InsertDateTimer(ctx context.Context, creator int64, timer *tim
 
 var deleteTimerQuery = fmt.Sprintf(
 	`
-	UPDATE %s SET %s = true WHERE %s = $1
+	UPDATE %s SET %s = true, %s = %s + 1 WHERE %s = $1
 	`,
 	timersql.Table,
 	timersql.IsDeleted,
+	timersql.Version,
+	timersql.Version,
 	timersql.ID,
 )
 
 func (s *Storage) DeleteTimer(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.DeleteTimer")
+	defer span.End()
 	cmd, err := s.p.Pool.Exec(ctx, deleteTimerQuery, id)
 	if err != nil {
 		return Error(err, exception.NewCause("delete timer query", "DeleteTimer", _PROVIDER))
@@ -127,54 +162,113 @@ func (s *Storage) DeleteTimer(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// template select timer query,
-// need add GROUP BY timerId, colorId, typeId and ORDER BY
+// alias of subscribers table joined for user who requests timers
+const viewerTable = "viewer"
 
-var selectTimerQueryTemplate = fmt.Sprintf(
-	`SELECT 
-		%s,coalesce(%s, false), coalesce(%s, NULL)
+// group by of select timer query
+var timerGroupBy = sqlutils.Full(
+	countdowntimersql.TimerId,
+	timersql.ID,
+	colorsql.ID,
+	typesql.ID,
+) + fmt.Sprintf(",%[1]s.%[2]s,%[1]s.%[3]s,%[1]s.%[4]s,%[1]s.%[5]s,%[1]s.%[6]s", viewerTable, subscribersql.TimerId, subscribersql.UserId, subscribersql.CreatedAt, subscribersql.SoundId, subscribersql.Volume) +
+	"," + sqlutils.Full(placementsql.FolderId, placementsql.IsPinned, placementsql.Position)
+
+// template select timer query, viewer is sql expression of user id who requests timer, NULL if timer isn't requested by user
+// need add GROUP BY timerGroupBy and ORDER BY
+func selectTimerQuery(viewer string) string {
+	return fmt.Sprintf(
+		`SELECT 
+		%s,coalesce(%s, false), coalesce(%s, NULL),
+		%s,
+		CASE WHEN %s IS NULL THEN '' WHEN %s = %s THEN '%s' WHEN %s.%s IS NOT NULL THEN '%s' ELSE '%s' END,
+		%s.%s,
+		%s,
+		coalesce(%s, 0), %s, %s.%s, %s.%s,
+		%s, coalesce(%s, false), ARRAY(SELECT %s FROM %s WHERE %s = %s AND %s = %s ORDER BY %s)
 	FROM %s 
 	INNER JOIN %s ON %s = %s AND NOT %s
 	INNER JOIN %s ON %s = %s
-	LEFT JOIN %s ON %s = %s`,
-	// selectable variables
-	sqlutils.Full(
-		timersql.ID,
-		timersql.UTC,
-		timersql.Creator,
-		timersql.EndTime,
-		typesql.Type,
-		timersql.Name,
-		timersql.Description,
-		colorsql.Color,
-		timersql.WithMusic,
-		timersql.Duration,
-	),
-	sqlutils.Full(countdowntimersql.IsPaused),
-	sqlutils.Full(countdowntimersql.PauseTime),
-
-	// from timers
-	timersql.Table,
-
-	// inner join colors
-	colorsql.Table,
-	sqlutils.Full(timersql.ColorId),
-	sqlutils.Full(colorsql.ID),
-	// AND NOT is_deleted
-	sqlutils.Full(timersql.IsDeleted),
-
-	// inner join types
-	typesql.Table,
-	sqlutils.Full(timersql.TypeId),
-	sqlutils.Full(typesql.ID),
-
-	// left join on countdowntimers for coalesce(is_paused, false) field
-	countdowntimersql.Table,
-	sqlutils.Full(timersql.ID),
-	sqlutils.Full(countdowntimersql.TimerId),
-)
+	LEFT JOIN %s ON %s = %s
+	LEFT JOIN %s AS %s ON %s.%s = %s AND %s.%s = %s
+	LEFT JOIN %s ON %s = %s AND %s = %s`,
+		// selectable variables
+		sqlutils.Full(
+			timersql.ID,
+			timersql.UTC,
+			timersql.Creator,
+			timersql.EndTime,
+			typesql.Type,
+			timersql.Name,
+			timersql.Description,
+			colorsql.Color,
+			timersql.WithMusic,
+			timersql.Duration,
+		),
+		sqlutils.Full(countdowntimersql.IsPaused),
+		sqlutils.Full(countdowntimersql.PauseTime),
+
+		// denormalized subscribers count
+		sqlutils.Full(timersql.SubscribersCount),
+
+		// viewer relation
+		viewer,
+		sqlutils.Full(timersql.Creator), viewer, timerfields.OWNER,
+		viewerTable, subscribersql.UserId, timerfields.SUBSCRIBER,
+		timerfields.NONE,
+
+		// viewer subscription time
+		viewerTable, subscribersql.CreatedAt,
+
+		sqlutils.Full(timersql.Version, timersql.TimeZone, timersql.CustomColor, timersql.CustomGradient),
+
+		// timer sound and viewer sound
+		sqlutils.Full(timersql.SoundId),
+		sqlutils.Full(timersql.Volume),
+		viewerTable, subscribersql.SoundId,
+		viewerTable, subscribersql.Volume,
+
+		// viewer folder, pin and tags
+		sqlutils.Full(placementsql.FolderId),
+		sqlutils.Full(placementsql.IsPinned),
+		tagsql.Tag, tagsql.Table,
+		sqlutils.Full(tagsql.TimerId), sqlutils.Full(timersql.ID),
+		sqlutils.Full(tagsql.UserId), viewer,
+		tagsql.Tag,
+
+		// from timers
+		timersql.Table,
+
+		// inner join colors
+		colorsql.Table,
+		sqlutils.Full(timersql.ColorId),
+		sqlutils.Full(colorsql.ID),
+		// AND NOT is_deleted
+		sqlutils.Full(timersql.IsDeleted),
+
+		// inner join types
+		typesql.Table,
+		sqlutils.Full(timersql.TypeId),
+		sqlutils.Full(typesql.ID),
+
+		// left join on countdowntimers for coalesce(is_paused, false) field
+		countdowntimersql.Table,
+		sqlutils.Full(timersql.ID),
+		sqlutils.Full(countdowntimersql.TimerId),
+
+		// left join viewer subscription
+		subscribersql.Table, viewerTable,
+		viewerTable, subscribersql.TimerId, sqlutils.Full(timersql.ID),
+		viewerTable, subscribersql.UserId, viewer,
+
+		// left join viewer placement
+		placementsql.Table,
+		sqlutils.Full(placementsql.TimerId), sqlutils.Full(timersql.ID),
+		sqlutils.Full(placementsql.UserId), viewer,
+	)
+}
 
-func timerQueryTemplate(query string) string {
+func timerQueryTemplate(viewer string, query string) string {
 	return fmt.Sprintf(
 		`
 		%s
@@ -184,23 +278,21 @@ func timerQueryTemplate(query string) string {
 		GROUP BY %s
 		`,
 
-		selectTimerQueryTemplate,
+		selectTimerQuery(viewer),
 
 		// added query
 		query,
 
-		// group by
-		sqlutils.Full(
-			countdowntimersql.TimerId,
-			timersql.ID,
-			colorsql.ID,
-			typesql.ID,
-		),
+		timerGroupBy,
 	)
 }
 
+// offset of timer zone is derived at scan time
 func scanTimer(row pgx.Row, timer *timermodel.Timer) error {
-	return row.Scan(
+	var customColor, customGradient timerfields.HexColor
+	var subscriberSoundId *timerfields.SoundId
+	var subscriberVolume *timerfields.Volume
+	err := row.Scan(
 		&timer.ID,
 		&timer.UTC,
 		&timer.Creator,
@@ -213,12 +305,37 @@ func scanTimer(row pgx.Row, timer *timermodel.Timer) error {
 		&timer.Duration,
 		&timer.IsPaused,
 		&timer.PauseTime,
+		&timer.SubscribersCount,
+		&timer.Relation,
+		&timer.SubscribedAt,
+		&timer.Version,
+		&timer.TimeZone,
+		&customColor,
+		&customGradient,
+		&timer.SoundId,
+		&timer.Volume,
+		&subscriberSoundId,
+		&subscriberVolume,
+		&timer.FolderId,
+		&timer.IsPinned,
+		&timer.Tags,
 	)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	timer.Offset = timer.TimeZone.Offset(now)
+	timer.UTC = timer.TimeZone.UTC(now, timer.UTC)
+	timer.CustomColor = timerfields.NewCustomColor(customColor, customGradient)
+	timer.SubscriberSound = timermodel.NewSubscriberSound(subscriberSoundId, subscriberVolume)
+	return nil
 }
 
-var timerQuery = timerQueryTemplate(fmt.Sprintf(`WHERE %s = $1`, sqlutils.Full(timersql.ID)))
+var timerQuery = timerQueryTemplate("NULL::bigint", fmt.Sprintf(`WHERE %s = $1`, sqlutils.Full(timersql.ID)))
 
 func (s *Storage) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.Timer")
+	defer span.End()
 	row := s.p.Pool.QueryRow(ctx, timerQuery, timerId)
 	timer := new(timermodel.Timer)
 	err := scanTimer(row, timer)
@@ -228,10 +345,46 @@ func (s *Storage) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Tim
 	return timer, nil
 }
 
+var userTimerQuery = timerQueryTemplate("$2::bigint", fmt.Sprintf(`WHERE %s = $1`, sqlutils.Full(timersql.ID)))
+
+// timer with relation of user to timer
+func (s *Storage) UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.UserTimer")
+	defer span.End()
+	row := s.p.Pool.QueryRow(ctx, userTimerQuery, timerId, userId)
+	timer := new(timermodel.Timer)
+	err := scanTimer(row, timer)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("error while scan timer", "UserTimer", _PROVIDER))
+	}
+	return timer, nil
+}
+
+// filter of user timer lists, $1 is user id, $4 is folder id, $5 is tag, NULL skips filter
+var timerListFilter = fmt.Sprintf(
+	`($4::uuid IS NULL OR %s = $4) AND ($5::varchar IS NULL OR EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s = $1 AND %s = $5))`,
+	sqlutils.Full(placementsql.FolderId),
+	tagsql.Table,
+	sqlutils.Full(tagsql.TimerId),
+	sqlutils.Full(timersql.ID),
+	sqlutils.Full(tagsql.UserId),
+	sqlutils.Full(tagsql.Tag),
+)
+
+// pinned timers go first, then manually ordered timers, other timers are ordered by creation time
+var timerListOrder = fmt.Sprintf(
+	`coalesce(%s, false) DESC, %s ASC NULLS LAST, %s`,
+	sqlutils.Full(placementsql.IsPinned),
+	sqlutils.Full(placementsql.Position),
+	sqlutils.Full(timersql.CreatedAt, timersql.ID),
+)
+
 var userSubscriptionsQuery = fmt.Sprintf(`
 	%s
 	INNER JOIN %s ON %s = %s AND %s = $1 AND %s != $1
 
+	WHERE %s
+
 	GROUP BY %s
 
 	ORDER BY %s
@@ -240,7 +393,7 @@ var userSubscriptionsQuery = fmt.Sprintf(`
 	OFFSET $3
 `,
 	// default select timer query
-	selectTimerQueryTemplate,
+	selectTimerQuery("$1::bigint"),
 
 	subscribersql.Table,
 	// inner join by timer id
@@ -251,40 +404,33 @@ var userSubscriptionsQuery = fmt.Sprintf(`
 	// and user id not equal to creator
 	sqlutils.Full(timersql.Creator),
 
-	sqlutils.Full(
-		countdowntimersql.TimerId,
-		timersql.ID,
-		colorsql.ID,
-		typesql.ID,
-	),
-	sqlutils.Full(timersql.CreatedAt, timersql.ID),
+	timerListFilter,
+	timerGroupBy,
+	timerListOrder,
 )
 
 // return list of user subcriptions on timers
 func (s *Storage) UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
-	rows, err := s.p.Pool.Query(ctx, userSubscriptionsQuery, userId, limit, offset)
-	if err != nil {
-		return nil, Error(err, exception.NewCause("user subscriptions query", "UserSubscriptions", _PROVIDER))
-	}
-	timers, err := sqlutils.ScanList(rows, scanTimer)
+	ctx, span := tracing.Start(ctx, "timerstorage.UserSubscriptions")
+	defer span.End()
+	timers, err := s.timerList(ctx, userSubscriptionsQuery, userId, nil, offset, limit)
 	if err != nil {
-		return nil, Error(err, exception.NewCause("scan rows into timer list", "UserSubcriptions", _PROVIDER))
+		return nil, exception.Wrap(err, exception.NewCause("get user subscriptions", "UserSubscriptions", _PROVIDER))
 	}
 	return timers, nil
 }
 
 var createdTimers = timerQueryTemplate(
-	fmt.Sprintf(`WHERE %s = $1`, sqlutils.Full(timersql.Creator)),
-) + fmt.Sprintf("ORDER BY %s LIMIT $2 OFFSET $3", sqlutils.Full(timersql.CreatedAt, timersql.ID))
+	"$1::bigint",
+	fmt.Sprintf(`WHERE %s = $1 AND %s`, sqlutils.Full(timersql.Creator), timerListFilter),
+) + fmt.Sprintf("ORDER BY %s LIMIT $2 OFFSET $3", timerListOrder)
 
 func (s *Storage) UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
-	rows, err := s.p.Pool.Query(ctx, createdTimers, userId, limit, offset)
-	if err != nil {
-		return nil, Error(err, exception.NewCause("user created timers query", "UserCreatedTimers", _PROVIDER))
-	}
-	timers, err := sqlutils.ScanList(rows, scanTimer)
+	ctx, span := tracing.Start(ctx, "timerstorage.UserCreatedTimers")
+	defer span.End()
+	timers, err := s.timerList(ctx, createdTimers, userId, nil, offset, limit)
 	if err != nil {
-		return nil, Error(err, exception.NewCause("scan rows into timer list", "UserCreatedTimers", _PROVIDER))
+		return nil, exception.Wrap(err, exception.NewCause("get user created timers", "UserCreatedTimers", _PROVIDER))
 	}
 	return timers, nil
 }
@@ -292,13 +438,14 @@ func (s *Storage) UserCreatedTimers(ctx context.Context, userId int64, offset, l
 var userTimersQuery = fmt.Sprintf(`
 %s
 INNER JOIN %s ON %s = %s AND %s = $1
+WHERE %s
 GROUP BY %s
 ORDER BY %s
 LIMIT $2
 OFFSET $3
 `,
 	// default select timer query
-	selectTimerQueryTemplate,
+	selectTimerQuery("$1::bigint"),
 
 	subscribersql.Table,
 	// inner join by timer id
@@ -307,24 +454,59 @@ OFFSET $3
 	// inner join by userId = $1
 	sqlutils.Full(subscribersql.UserId),
 
-	sqlutils.Full(
-		countdowntimersql.TimerId,
-		timersql.ID,
-		colorsql.ID,
-		typesql.ID,
-	),
-	sqlutils.Full(timersql.CreatedAt, timersql.ID),
+	timerListFilter,
+	timerGroupBy,
+	timerListOrder,
 )
 
 // return list of all user timers include subcriptions
 func (s *Storage) UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
-	rows, err := s.p.Pool.Query(ctx, userTimersQuery, userId, limit, offset)
+	ctx, span := tracing.Start(ctx, "timerstorage.UserTimers")
+	defer span.End()
+	timers, err := s.timerList(ctx, userTimersQuery, userId, nil, offset, limit)
 	if err != nil {
-		return nil, Error(err, exception.NewCause("user timers query", "UserTimers", _PROVIDER))
+		return nil, exception.Wrap(err, exception.NewCause("get user timers", "UserTimers", _PROVIDER))
+	}
+	return timers, nil
+}
+
+// user timers of filter list, only timers in folder and with tag of filter are returned
+func (s *Storage) FilteredTimers(ctx context.Context, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.FilteredTimers")
+	defer span.End()
+	var query string
+	switch filter.List {
+	case timermodel.CreatedTimers:
+		query = createdTimers
+	case timermodel.Subscriptions:
+		query = userSubscriptionsQuery
+	default:
+		query = userTimersQuery
+	}
+	timers, err := s.timerList(ctx, query, userId, filter, offset, limit)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get filtered timers", "FilteredTimers", _PROVIDER))
+	}
+	return timers, nil
+}
+
+// query list of timers, nil filter skips filtering
+func (s *Storage) timerList(ctx context.Context, query string, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error) {
+	var folderId *uuid.UUID
+	var tag *string
+	if filter != nil {
+		folderId = filter.FolderId
+		if filter.Tag != "" {
+			tag = &filter.Tag
+		}
+	}
+	rows, err := s.p.Pool.Query(ctx, query, userId, limit, offset, folderId, tag)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("timer list query", "timerList", _PROVIDER))
 	}
 	timers, err := sqlutils.ScanList(rows, scanTimer)
 	if err != nil {
-		return nil, Error(err, exception.NewCause("scan rows into timer list", "UserTimers", _PROVIDER))
+		return nil, Error(err, exception.NewCause("scan rows into timer list", "timerList", _PROVIDER))
 	}
 	return timers, nil
 }
@@ -338,6 +520,8 @@ var subscribeQuery = fmt.Sprintf(`
 )
 
 func (s *Storage) Subscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.Subscribe")
+	defer span.End()
 	_, err := s.p.Pool.Exec(ctx, subscribeQuery, userId, timerId)
 	if err != nil {
 		return Error(err, exception.NewCause("insert into subcribers table", "Subcribe", _PROVIDER))
@@ -346,6 +530,8 @@ func (s *Storage) Subscribe(ctx context.Context, timerId uuid.UUID, userId int64
 }
 
 func (s *Storage) SubscribeAll(ctx context.Context, timerId uuid.UUID, userIds ...int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.SubscribeAll")
+	defer span.End()
 	tx, err := s.p.Pool.Begin(ctx)
 	if err != nil {
 		return Error(err, exception.NewCause("begin tx", "SubscribeAll", _PROVIDER))
@@ -387,9 +573,76 @@ var unsubcribeQuery = fmt.Sprintf(`
 )
 
 func (s *Storage) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.Unsubscribe")
+	defer span.End()
 	_, err := s.p.Pool.Exec(ctx, unsubcribeQuery, userId, timerId)
 	if err != nil {
 		return Error(err, exception.NewCause("insert into subcribers table", "Subcribe", _PROVIDER))
 	}
 	return nil
 }
+
+var anonymizeUserTimersQuery = fmt.Sprintf(`
+	UPDATE %s SET %s = 0, %s = '', %s = NULL WHERE %s = $1 AND %s
+`,
+	timersql.Table,
+	timersql.Creator,
+	timersql.Name,
+	timersql.Description,
+	timersql.Creator,
+	timersql.IsDeleted,
+)
+
+var deleteUserSubscriptionsQuery = fmt.Sprintf(`
+	DELETE FROM %s WHERE %s = $1
+`,
+	subscribersql.Table,
+	subscribersql.UserId,
+)
+
+var deleteUserFoldersQuery = fmt.Sprintf(`
+	DELETE FROM %s WHERE %s = $1
+`,
+	foldersql.Table,
+	foldersql.UserId,
+)
+
+var deleteUserBansQuery = fmt.Sprintf(`
+	DELETE FROM %s WHERE %s = $1
+`,
+	timerbansql.Table,
+	timerbansql.UserId,
+)
+
+// erase user data from deleted timers, all user subscriptions with folders, tags and bans
+// deleted timers rows are kept for unread delete notifications of subscribers, only creator, name and description are cleared
+func (s *Storage) EraseUserTimers(ctx context.Context, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.EraseUserTimers")
+	defer span.End()
+	tx, err := s.p.Pool.Begin(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("begin tx", "EraseUserTimers", _PROVIDER))
+	}
+	defer tx.Rollback(ctx)
+	_, err = tx.Exec(ctx, anonymizeUserTimersQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("anonymize user timers", "EraseUserTimers", _PROVIDER))
+	}
+	_, err = tx.Exec(ctx, deleteUserSubscriptionsQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete user subscriptions", "EraseUserTimers", _PROVIDER))
+	}
+	_, err = tx.Exec(ctx, deleteUserFoldersQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete user folders", "EraseUserTimers", _PROVIDER))
+	}
+	_, err = tx.Exec(ctx, deleteUserBansQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete user bans", "EraseUserTimers", _PROVIDER))
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return Error(err, exception.NewCause("commit tx", "EraseUserTimers", _PROVIDER))
+	}
+	return nil
+}
@@ -2,14 +2,24 @@ package timerstorage_test
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/database/postgres/folderstorage"
+	"github.com/Tap-Team/timerapi/internal/database/postgres/soundstorage"
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/foldersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/placementsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/subscribersql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/tagsql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timerbansql"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
 	"github.com/Tap-Team/timerapi/pkg/amidstr"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/google/uuid"
@@ -46,7 +56,7 @@ func TestTimerCrud(t *testing.T) {
 	defer cancel()
 	timer := randomTimer()
 	t1 := *timer
-	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
@@ -84,7 +94,7 @@ func TestSubcribe(t *testing.T) {
 
 	timerList := randomTimerList(tam)
 	for _, timer := range timerList {
-		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 		if err != nil {
 			t.Fatalf("insert timer test failed, %s", err)
 		}
@@ -158,7 +168,7 @@ func TestUserTimers(t *testing.T) {
 	// in first create timer with random creator and subcribe with userid
 	for _, timer := range timerList {
 		ids = append(ids, timer.ID)
-		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 		if err != nil {
 			t.Fatalf("insert timer test failed, %s", err)
 		}
@@ -188,7 +198,7 @@ func TestUserTimers(t *testing.T) {
 	timerList = randomTimerList(tam, func(t *timermodel.Timer) { t.Creator = userId })
 	for _, timer := range timerList {
 		ids = append(ids, timer.ID)
-		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 		if err != nil {
 			t.Fatalf("insert timer test failed, %s", err)
 		}
@@ -221,14 +231,14 @@ func TestUserCreatedTimers(t *testing.T) {
 	ids := make([]uuid.UUID, 0)
 	timerList := randomTimerList(createdSize, func(t *timermodel.Timer) { t.Creator = userId })
 	for _, timer := range timerList {
-		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 		require.NoError(t, err, "insert timer failed")
 		ids = append(ids, timer.ID)
 	}
 	subSize := 100
 	timerList = randomTimerList(subSize)
 	for _, timer := range timerList {
-		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 		require.NoError(t, err, "insert timer for subscribe failed")
 		ids = append(ids, timer.ID)
 		err = testTimerStorage.Subscribe(ctx, timer.ID, userId)
@@ -259,13 +269,13 @@ func TestUserTimersIsPaused(t *testing.T) {
 	countdownTimerId := uuid.New()
 
 	timer := randomTimer(func(t *timermodel.Timer) { t.ID = dateTimerId; t.Creator = userId })
-	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	require.NoError(t, err, "insert date timer failed")
 	timer = randomTimer(func(t *timermodel.Timer) { t.ID = countdownTimerId; t.Creator = userId })
-	err = testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer())
+	err = testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	require.NoError(t, err, "insert  countdown timer failed")
 
-	err = testTimerStorage.UpdatePauseTime(ctx, countdownTimerId, amidtime.Now(), true)
+	err = testTimerStorage.UpdatePauseTime(ctx, countdownTimerId, amidtime.Now(), true, 0)
 	require.NoError(t, err, "set countdown timer pause time failed")
 
 	dateTimer, err := testTimerStorage.Timer(ctx, dateTimerId)
@@ -282,3 +292,132 @@ func TestUserTimersIsPaused(t *testing.T) {
 	err = testTimerStorage.DeleteTimer(ctx, countdownTimerId)
 	require.NoError(t, err, "delete countdowntimer failed")
 }
+
+func TestTimerViewerFields(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := randomTimer()
+	subscriberId, otherId := rand.Int63(), rand.Int63()
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+	err = testTimerStorage.Subscribe(ctx, timer.ID, subscriberId)
+	require.NoError(t, err, "subscribe failed")
+
+	cases := []struct {
+		userId     int64
+		relation   timerfields.Relation
+		subscribed bool
+	}{
+		{userId: timer.Creator, relation: timerfields.OWNER, subscribed: true},
+		{userId: subscriberId, relation: timerfields.SUBSCRIBER, subscribed: true},
+		{userId: otherId, relation: timerfields.NONE, subscribed: false},
+	}
+	for _, cs := range cases {
+		userTimer, err := testTimerStorage.UserTimer(ctx, timer.ID, cs.userId)
+		require.NoError(t, err, "get user timer failed")
+		require.Equal(t, 1, userTimer.SubscribersCount, "wrong subscribers count")
+		require.Equal(t, cs.relation, userTimer.Relation, "wrong relation")
+		require.Equal(t, cs.subscribed, userTimer.SubscribedAt.Unix() > 0, "wrong subscribed at")
+	}
+
+	timers, err := testTimerStorage.UserSubscriptions(ctx, subscriberId, 0, math.MaxInt)
+	require.NoError(t, err, "user subscriptions failed")
+	require.Len(t, timers, 1, "wrong subscriptions count")
+	require.Equal(t, timerfields.SUBSCRIBER, timers[0].Relation, "wrong relation in list")
+
+	err = testTimerStorage.Unsubscribe(ctx, timer.ID, subscriberId)
+	require.NoError(t, err, "unsubscribe failed")
+	userTimer, err := testTimerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, 0, userTimer.SubscribersCount, "subscribers count not decreased")
+	require.Empty(t, userTimer.Relation, "relation without viewer")
+}
+
+func TestTimerTimeZone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := time.Now()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err, "load location failed")
+	_, berlinOffset := now.In(berlin).Zone()
+
+	cases := []struct {
+		utc       int16
+		zone      timerfields.TimeZone
+		expZone   timerfields.TimeZone
+		expUTC    int16
+		expOffset int
+	}{
+		{utc: 0, zone: "Europe/Berlin", expZone: "Europe/Berlin", expUTC: int16(berlinOffset / 3600), expOffset: berlinOffset},
+		// legacy timer without zone
+		{utc: 3, expZone: "Etc/GMT-3", expUTC: 3, expOffset: 3 * 3600},
+		{utc: -5, expZone: "Etc/GMT+5", expUTC: -5, expOffset: -5 * 3600},
+		{utc: 0, expZone: timerfields.UTCZone, expUTC: 0, expOffset: 0},
+	}
+	for _, cs := range cases {
+		timer := randomTimer(func(t *timermodel.Timer) { t.UTC = cs.utc; t.TimeZone = cs.zone })
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+		require.NoError(t, err, "insert timer failed")
+		tm, err := testTimerStorage.Timer(ctx, timer.ID)
+		require.NoError(t, err, "get timer failed")
+		require.Equal(t, cs.expZone, tm.TimeZone, "wrong time zone")
+		require.Equal(t, cs.expUTC, tm.UTC, "wrong utc")
+		require.Equal(t, cs.expOffset, tm.Offset, "wrong offset")
+	}
+
+	// utc stored before DST transition is stale, utc is derived from zone on read
+	timer := randomTimer(func(t *timermodel.Timer) { t.TimeZone = "Europe/Berlin"; t.Type = timerfields.COUNTDOWN })
+	err = testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+	query := fmt.Sprintf(`UPDATE %s SET %s = 0 WHERE %s = $1`, timersql.Table, timersql.UTC, timersql.ID)
+	_, err = testPostgres.Pool.Exec(ctx, query, timer.ID)
+	require.NoError(t, err, "set stale utc failed")
+	tm, err := testTimerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, int16(berlinOffset/3600), tm.UTC, "utc not derived from zone")
+	countdown, err := testTimerStorage.CountdownTimer(ctx, timer.ID)
+	require.NoError(t, err, "get countdown timer failed")
+	require.Equal(t, int16(berlinOffset/3600), countdown.UTC, "utc of countdown timer not derived from zone")
+}
+
+// user rows of tables which reference subscriptions are removed by cascade, so they are checked explicitly
+func TestEraseUserTimers(t *testing.T) {
+	ctx := context.Background()
+	folderStorage := folderstorage.New(testPostgres)
+	soundStorage := soundstorage.New(testPostgres)
+	userId := rand.Int63()
+
+	own := randomTimer(func(t *timermodel.Timer) { t.Creator = userId })
+	other := randomTimer()
+	banned := randomTimer()
+	for _, timer := range []*timermodel.Timer{own, other, banned} {
+		err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+		require.NoError(t, err, "insert timer failed")
+	}
+	require.NoError(t, testTimerStorage.Subscribe(ctx, other.ID, userId), "subscribe failed")
+
+	folder := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "folder"}
+	require.NoError(t, folderStorage.InsertFolder(ctx, folder, 0), "insert folder failed")
+	for _, timer := range []*timermodel.Timer{own, other} {
+		require.NoError(t, folderStorage.MoveTimer(ctx, timer.ID, userId, &folder.ID), "move timer failed")
+		require.NoError(t, folderStorage.SetTimerTags(ctx, timer.ID, userId, []foldermodel.Tag{"work"}), "set tags failed")
+	}
+	err := soundStorage.SetSubscriberSound(ctx, other.ID, userId, &timermodel.SubscriberSound{SoundId: 2, Volume: 50})
+	require.NoError(t, err, "set subscriber sound failed")
+	require.NoError(t, testTimerStorage.Ban(ctx, banned.ID, userId), "ban failed")
+
+	// erasure deletes own timers before erase of timers data
+	require.NoError(t, testTimerStorage.DeleteTimer(ctx, own.ID), "delete timer failed")
+	require.NoError(t, testTimerStorage.EraseUserTimers(ctx, userId), "erase user timers failed")
+
+	for _, table := range []string{placementsql.Table, tagsql.Table, subscribersql.Table, foldersql.Table, timerbansql.Table} {
+		var count int
+		err := testPostgres.Pool.QueryRow(ctx, fmt.Sprintf("SELECT count(*) FROM %s WHERE user_id = $1", table), userId).Scan(&count)
+		require.NoError(t, err, "count rows failed")
+		require.Zero(t, count, "rows of user left in %s", table)
+	}
+	var creator int64
+	err = testPostgres.Pool.QueryRow(ctx, "SELECT creator FROM timers WHERE id = $1", own.ID).Scan(&creator)
+	require.NoError(t, err, "select deleted timer failed")
+	require.Zero(t, creator, "creator of deleted timer not erased")
+}
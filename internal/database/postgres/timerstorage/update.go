@@ -4,31 +4,42 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/colorsql"
 	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/sqlutils"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 var updateEndTimeQuery = fmt.Sprintf(
-	`UPDATE %s SET %s = $1 WHERE %s = $2`,
+	`UPDATE %s SET %s = $1, %s = %s + 1 WHERE %s = $2 AND %s`,
 	timersql.Table,
 	timersql.EndTime,
+	timersql.Version,
+	timersql.Version,
 	timersql.ID,
+	versionCondition("$3"),
 )
 
-func (s *Storage) UpdateTime(ctx context.Context, timerId uuid.UUID, endTime amidtime.DateTime) error {
-	cmd, err := s.p.Pool.Exec(ctx, updateEndTimeQuery, endTime, timerId)
+// every mutation of timer takes expected version of timer, 0 version is any version
+// version is checked and incremented by the same update, stale version is version mismatch error
+func (s *Storage) UpdateTime(ctx context.Context, timerId uuid.UUID, endTime amidtime.DateTime, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.UpdateTime")
+	defer span.End()
+	cmd, err := s.p.Pool.Exec(ctx, updateEndTimeQuery, endTime, timerId, version)
 	if err != nil {
 		return Error(err, exception.NewCause("update timer endTime", "UpdateTime", _PROVIDER))
 	}
 	if cmd.RowsAffected() == 0 {
-		return timererror.ExceptionTimerNotFound()
+		return s.versionError(ctx, timerId, version, timererror.ExceptionTimerNotFound())
 	}
 	if cmd.RowsAffected() > 1 {
 		return Error(errors.New("many than 1 rows was updated"), exception.NewCause("update timer end time", "UpdateTime", _PROVIDER))
@@ -36,13 +47,51 @@ func (s *Storage) UpdateTime(ctx context.Context, timerId uuid.UUID, endTime ami
 	return nil
 }
 
+var adjustTimeQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = %s + make_interval(secs => $1::bigint), %s = %s + $1::bigint, %s = %s + 1 WHERE %s = $2 AND %s RETURNING %s, %s`,
+	timersql.Table,
+	timersql.EndTime,
+	timersql.EndTime,
+	timersql.Duration,
+	timersql.Duration,
+	timersql.Version,
+	timersql.Version,
+	timersql.ID,
+	versionCondition("$3"),
+	timersql.EndTime,
+	timersql.Duration,
+)
+
+// shift end time and duration by delta seconds, shift is relative so concurrent adjustments are summed
+// returns end time and duration after shift
+func (s *Storage) AdjustTime(ctx context.Context, timerId uuid.UUID, delta int64, version int64) (amidtime.DateTime, int64, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.AdjustTime")
+	defer span.End()
+	var endTime amidtime.DateTime
+	var duration int64
+	err := s.p.Pool.QueryRow(ctx, adjustTimeQuery, delta, timerId, version).Scan(&endTime, &duration)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = s.versionError(ctx, timerId, version, timererror.ExceptionTimerNotFound())
+		return endTime, 0, Error(err, exception.NewCause("adjust timer time", "AdjustTime", _PROVIDER))
+	}
+	if err != nil {
+		return endTime, 0, Error(err, exception.NewCause("adjust timer time", "AdjustTime", _PROVIDER))
+	}
+	return endTime, duration, nil
+}
+
 var updateTimerQuery = fmt.Sprintf(
 	`
 	UPDATE %s 
 	SET %s = $1, %s = $2, %s = %s, %s = $4, %s = $5,
-	%s = %s + extract(epoch FROM ($5 - %s))
+	%s = %s + extract(epoch FROM ($5 - %s)),
+	%s = CASE WHEN $7 THEN $8 ELSE %s END,
+	%s = CASE WHEN $7 THEN $9 ELSE %s END,
+	%s = CASE WHEN $4 THEN coalesce($10, %s, $12) ELSE NULL END,
+	%s = coalesce(nullif($11, 0), %s),
+	%s = %s + 1
 	FROM %s 
-	WHERE %s = $6 AND %s = $3
+	WHERE %s = $6 AND %s = $3 AND %s
 	`,
 
 	timersql.Table,
@@ -58,29 +107,121 @@ var updateTimerQuery = fmt.Sprintf(
 	timersql.Duration,
 	timersql.Duration,
 	timersql.EndTime,
+
+	// update custom color only if it is set
+	timersql.CustomColor,
+	sqlutils.Full(timersql.CustomColor),
+	timersql.CustomGradient,
+	sqlutils.Full(timersql.CustomGradient),
+
+	// sound of timer with music isn't changed if sound isn't set, timer without music is silent
+	timersql.SoundId,
+	sqlutils.Full(timersql.SoundId),
+	timersql.Volume,
+	sqlutils.Full(timersql.Volume),
+
+	// increment version
+	timersql.Version,
+	sqlutils.Full(timersql.Version),
 	// update from
 	colorsql.Table,
 
 	// where timer id = $5 and color = $3
 	sqlutils.Full(timersql.ID),
 	sqlutils.Full(colorsql.Color),
+	versionCondition("$13"),
 )
 
-func (s *Storage) UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings) error {
+// custom color isn't changed if settings custom color is nil, custom color with empty hex clears it
+// sound and volume aren't changed if they are empty, default sound is set if timer without sound gets music
+func (s *Storage) UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.UpdateTimer")
+	defer span.End()
+	customColor, customGradient := timerSettings.CustomColor.Columns()
 	cmd, err := s.p.Pool.Exec(
 		ctx,
 		updateTimerQuery,
 		timerSettings.Name, timerSettings.Description, timerSettings.Color, timerSettings.WithMusic, timerSettings.EndTime,
 		timerId,
+		timerSettings.CustomColor != nil, customColor, customGradient,
+		timerSettings.SoundId, timerSettings.Volume, timerfields.DefaultSound,
+		version,
 	)
 	if err != nil {
 		return Error(err, exception.NewCause("update timer", "UpdateTimer", _PROVIDER))
 	}
 	if cmd.RowsAffected() == 0 {
-		return Error(timererror.ExceptionTimerNotFound(), exception.NewCause("update timer endTime", "UpdateTimer", _PROVIDER))
+		err = s.versionError(ctx, timerId, version, timererror.ExceptionTimerNotFound())
+		return Error(err, exception.NewCause("update timer", "UpdateTimer", _PROVIDER))
 	}
 	if cmd.RowsAffected() > 1 {
 		return Error(errors.New("many than 1 rows was updated"), exception.NewCause("update timer end time", "UpdateTimer", _PROVIDER))
 	}
 	return nil
 }
+
+// update only fields of patch, duration is changed together with end time
+func (s *Storage) PatchTimer(ctx context.Context, timerId uuid.UUID, patch *timermodel.TimerPatch, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerstorage.PatchTimer")
+	defer span.End()
+	args := make([]any, 0, 7)
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	set := []string{fmt.Sprintf("%s = %s + 1", timersql.Version, sqlutils.Full(timersql.Version))}
+	if patch.Name != nil {
+		set = append(set, fmt.Sprintf("%s = %s", timersql.Name, arg(*patch.Name)))
+	}
+	if patch.Description != nil {
+		set = append(set, fmt.Sprintf("%s = %s", timersql.Description, arg(*patch.Description)))
+	}
+	if patch.Color != nil {
+		set = append(set, fmt.Sprintf(
+			"%s = (SELECT %s FROM %s WHERE %s = %s)",
+			timersql.ColorId, colorsql.ID, colorsql.Table, colorsql.Color, arg(*patch.Color),
+		))
+	}
+	// empty hex clears custom color
+	if patch.CustomColor != nil {
+		set = append(set,
+			fmt.Sprintf("%s = %s", timersql.CustomColor, arg(patch.CustomColor.Hex)),
+			fmt.Sprintf("%s = %s", timersql.CustomGradient, arg(patch.CustomColor.Gradient)),
+		)
+	}
+	if patch.WithMusic != nil {
+		set = append(set, fmt.Sprintf("%s = %s", timersql.WithMusic, arg(*patch.WithMusic)))
+	}
+	// sound 0 makes timer silent, withMusic without sound keeps sound or sets default sound
+	switch {
+	case patch.SoundId != nil:
+		set = append(set, fmt.Sprintf("%s = %s", timersql.SoundId, arg(*patch.SoundId)))
+	case patch.WithMusic != nil && *patch.WithMusic:
+		set = append(set, fmt.Sprintf("%s = coalesce(%s, %s)", timersql.SoundId, timersql.SoundId, arg(timerfields.DefaultSound)))
+	case patch.WithMusic != nil:
+		set = append(set, fmt.Sprintf("%s = NULL", timersql.SoundId))
+	}
+	if patch.Volume != nil {
+		set = append(set, fmt.Sprintf("%s = %s", timersql.Volume, arg(*patch.Volume)))
+	}
+	if patch.EndTime != nil {
+		endTime := arg(*patch.EndTime)
+		set = append(set,
+			fmt.Sprintf("%s = %s", timersql.EndTime, endTime),
+			fmt.Sprintf("%s = %s + extract(epoch FROM (%s - %s))", timersql.Duration, timersql.Duration, endTime, timersql.EndTime),
+		)
+	}
+	query := fmt.Sprintf(
+		`UPDATE %s SET %s WHERE %s = %s AND %s`,
+		timersql.Table, strings.Join(set, ", "), timersql.ID, arg(timerId), versionCondition(arg(version)),
+	)
+	cmd, err := s.p.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return Error(err, exception.NewCause("patch timer", "PatchTimer", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		err = s.versionError(ctx, timerId, version, timererror.ExceptionTimerNotFound())
+		return Error(err, exception.NewCause("patch timer", "PatchTimer", _PROVIDER))
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/pkg/amidstr"
@@ -52,18 +53,18 @@ func TestUpdateTime(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	timer := randomTimer()
-	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
 	timer2 := randomTimer()
-	err = testTimerStorage.InsertDateTimer(ctx, timer2.Creator, timer2.CreateTimer())
+	err = testTimerStorage.InsertDateTimer(ctx, timer2.Creator, timer2.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
 	duration := int64(rand.Int31n(10000))
 	endTime := amidtime.DateTime(timer.EndTime.T().Add(time.Second * time.Duration(duration)))
-	err = testTimerStorage.UpdateTime(ctx, timer.ID, endTime)
+	err = testTimerStorage.UpdateTime(ctx, timer.ID, endTime, 0)
 	if err != nil {
 		t.Fatalf("update timer test failed, %s", err)
 	}
@@ -80,12 +81,12 @@ func TestUpdateTimer(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	timer := randomTimer()
-	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+	err = testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
 	timer2 := randomTimer()
-	err = testTimerStorage.InsertDateTimer(ctx, timer2.Creator, timer2.CreateTimer())
+	err = testTimerStorage.InsertDateTimer(ctx, timer2.Creator, timer2.CreateTimer(), 0)
 	if err != nil {
 		t.Fatalf("insert timer test failed, %s", err)
 	}
@@ -96,7 +97,7 @@ func TestUpdateTimer(t *testing.T) {
 		}
 	})
 	addedDuration := settings.EndTime.Unix() - timer.EndTime.Unix()
-	err = testTimerStorage.UpdateTimer(ctx, timer.ID, settings)
+	err = testTimerStorage.UpdateTimer(ctx, timer.ID, settings, 0)
 	if err != nil {
 		t.Fatalf("update timer test failed, %s", err)
 	}
@@ -109,3 +110,92 @@ func TestUpdateTimer(t *testing.T) {
 	}
 	require.Equal(t, timer.Duration+addedDuration, tm.Duration, "duration not updated")
 }
+
+func TestUpdateTimerSound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := randomTimer()
+	timer.WithMusic = false
+	err := testTimerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+
+	sound := func() *timermodel.Timer {
+		tm, err := testTimerStorage.Timer(ctx, timer.ID)
+		require.NoError(t, err, "get timer failed")
+		require.Equal(t, tm.WithMusic, tm.SoundId != 0, "with music and sound differ")
+		return tm
+	}
+	update := func(opt timerSettingsOption) {
+		settings := randomTimerSettings(opt)
+		settings.EndTime = timer.EndTime
+		err := testTimerStorage.UpdateTimer(ctx, timer.ID, settings, 0)
+		require.NoError(t, err, "update timer failed")
+	}
+
+	// music without sound gets default sound
+	update(func(t *timermodel.TimerSettings) { t.WithMusic = true })
+	require.Equal(t, timerfields.DefaultSound, sound().SoundId, "default sound not set")
+
+	update(func(t *timermodel.TimerSettings) { t.WithMusic, t.SoundId, t.Volume = true, 3, 50 })
+	tm := sound()
+	require.Equal(t, timerfields.SoundId(3), tm.SoundId, "sound not updated")
+	require.Equal(t, timerfields.Volume(50), tm.Volume, "volume not updated")
+
+	// old clients keep sound and volume
+	update(func(t *timermodel.TimerSettings) { t.WithMusic = true })
+	tm = sound()
+	require.Equal(t, timerfields.SoundId(3), tm.SoundId, "sound changed by settings without sound")
+	require.Equal(t, timerfields.Volume(50), tm.Volume, "volume changed by settings without volume")
+
+	update(func(t *timermodel.TimerSettings) { t.WithMusic = false })
+	require.Zero(t, sound().SoundId, "sound of silent timer not cleared")
+
+	// patch of sound sets music
+	patch := &timermodel.TimerPatch{SoundId: new(timerfields.SoundId)}
+	*patch.SoundId = 2
+	require.NoError(t, patch.Validate(), "validate patch failed")
+	err = testTimerStorage.PatchTimer(ctx, timer.ID, patch, 0)
+	require.NoError(t, err, "patch timer failed")
+	tm = sound()
+	require.Equal(t, timerfields.SoundId(2), tm.SoundId, "sound not patched")
+	require.True(t, tm.WithMusic, "music not patched")
+}
+
+func TestUpdateTimerVersion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := randomTimer(func(t *timermodel.Timer) { t.Type = timerfields.COUNTDOWN })
+	err := testTimerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
+	require.NoError(t, err, "insert timer failed")
+
+	version, err := testTimerStorage.TimerVersion(ctx, timer.ID)
+	require.NoError(t, err, "get timer version failed")
+
+	// stale version changes nothing
+	err = testTimerStorage.UpdateTimer(ctx, timer.ID, randomTimerSettings(), version+1)
+	require.ErrorIs(t, err, timererror.ExceptionVersionMismatch(), "update with stale version")
+	_, _, err = testTimerStorage.AdjustTime(ctx, timer.ID, 60, version+1)
+	require.ErrorIs(t, err, timererror.ExceptionVersionMismatch(), "adjust with stale version")
+	err = testTimerStorage.UpdatePauseTime(ctx, timer.ID, amidtime.Now(), true, version+1)
+	require.ErrorIs(t, err, timererror.ExceptionVersionMismatch(), "pause with stale version")
+	tm, err := testTimerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, version, tm.Version, "version changed by stale mutation")
+	require.False(t, tm.IsPaused, "timer paused by stale mutation")
+
+	// every mutation bumps version once
+	err = testTimerStorage.UpdatePauseTime(ctx, timer.ID, amidtime.Now(), true, version)
+	require.NoError(t, err, "pause with current version failed")
+	err = testTimerStorage.UpdateTimeAndPause(ctx, timer.ID, timer.EndTime, amidtime.DateTime{}, false, version+1)
+	require.NoError(t, err, "start with current version failed")
+	err = testTimerStorage.UpdateTime(ctx, timer.ID, timer.EndTime, version+2)
+	require.NoError(t, err, "update time with current version failed")
+	// 0 version matches any version
+	endTime, duration, err := testTimerStorage.AdjustTime(ctx, timer.ID, 60, 0)
+	require.NoError(t, err, "adjust with any version failed")
+	tm, err = testTimerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer failed")
+	require.Equal(t, tm.EndTime.Unix(), endTime.Unix(), "adjust returned wrong end time")
+	require.Equal(t, tm.Duration, duration, "adjust returned wrong duration")
+	require.Equal(t, version+4, tm.Version, "wrong version after mutations")
+}
@@ -0,0 +1,50 @@
+package timerstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/timersql"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+)
+
+var timerVersionQuery = fmt.Sprintf(`
+	SELECT %s FROM %s WHERE %s = $1 AND NOT %s
+`,
+	timersql.Version,
+	timersql.Table,
+	timersql.ID,
+	timersql.IsDeleted,
+)
+
+func (s *Storage) TimerVersion(ctx context.Context, timerId uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "timerstorage.TimerVersion")
+	defer span.End()
+	var version int64
+	err := s.p.Pool.QueryRow(ctx, timerVersionQuery, timerId).Scan(&version)
+	if err != nil {
+		return 0, Error(err, exception.NewCause("select timer version", "TimerVersion", _PROVIDER))
+	}
+	return version, nil
+}
+
+// condition of mutation with expected version, param is placeholder of version, 0 version matches any version
+func versionCondition(param string) string {
+	return fmt.Sprintf("(%s::bigint = 0 OR %s = %s::bigint)", param, sqlutils.Full(timersql.Version), param)
+}
+
+// error of mutation which changed nothing, timer isn't found or expected version is stale
+func (s *Storage) versionError(ctx context.Context, timerId uuid.UUID, version int64, notFound error) error {
+	if version == 0 {
+		return notFound
+	}
+	_, err := s.TimerVersion(ctx, timerId)
+	if err != nil {
+		return err
+	}
+	return exception.Wrap(timererror.ExceptionVersionMismatch(), exception.NewCause("compare version", "versionError", _PROVIDER))
+}
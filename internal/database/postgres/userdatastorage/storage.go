@@ -0,0 +1,130 @@
+package userdatastorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/userdataerror"
+	"github.com/Tap-Team/timerapi/internal/model/userdatamodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/erasuresql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/jackc/pgx/v5"
+)
+
+const _PROVIDER = "internal/database/postgres/userdatastorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+func Error(err error, cause exception.Cause) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return exception.Wrap(userdataerror.ExceptionErasureNotFound(), cause)
+	default:
+		return exception.Wrap(err, cause)
+	}
+}
+
+func scanErasure(row pgx.Row, erasure *userdatamodel.Erasure) error {
+	err := row.Scan(&erasure.UserId, &erasure.RequestedAt, &erasure.CompletedAt)
+	erasure.Completed = !erasure.CompletedAt.T().IsZero()
+	return err
+}
+
+var startErasureQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s) VALUES ($1)
+	ON CONFLICT (%s) DO UPDATE SET
+		%s = CASE WHEN %s IS NULL THEN %s ELSE now() END,
+		%s = NULL
+	RETURNING %s, %s, %s`,
+	erasuresql.Table,
+	erasuresql.UserId,
+
+	erasuresql.UserId,
+	// unfinished erasure keeps request time
+	erasuresql.RequestedAt,
+	sqlutils.Full(erasuresql.CompletedAt),
+	sqlutils.Full(erasuresql.RequestedAt),
+	erasuresql.CompletedAt,
+
+	erasuresql.UserId,
+	erasuresql.RequestedAt,
+	erasuresql.CompletedAt,
+)
+
+// start new user erasure or continue unfinished one
+func (s *Storage) StartErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure := new(userdatamodel.Erasure)
+	err := scanErasure(s.p.Pool.QueryRow(ctx, startErasureQuery, userId), erasure)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("upsert erasure", "StartErasure", _PROVIDER))
+	}
+	return erasure, nil
+}
+
+var completeErasureQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = now() WHERE %s = $1 RETURNING %s, %s, %s`,
+	erasuresql.Table,
+	erasuresql.CompletedAt,
+	erasuresql.UserId,
+
+	erasuresql.UserId,
+	erasuresql.RequestedAt,
+	erasuresql.CompletedAt,
+)
+
+func (s *Storage) CompleteErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure := new(userdatamodel.Erasure)
+	err := scanErasure(s.p.Pool.QueryRow(ctx, completeErasureQuery, userId), erasure)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("update erasure", "CompleteErasure", _PROVIDER))
+	}
+	return erasure, nil
+}
+
+var erasureQuery = fmt.Sprintf(
+	`SELECT %s, %s, %s FROM %s WHERE %s = $1`,
+	erasuresql.UserId,
+	erasuresql.RequestedAt,
+	erasuresql.CompletedAt,
+	erasuresql.Table,
+	erasuresql.UserId,
+)
+
+func (s *Storage) Erasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure := new(userdatamodel.Erasure)
+	err := scanErasure(s.p.Pool.QueryRow(ctx, erasureQuery, userId), erasure)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("select erasure", "Erasure", _PROVIDER))
+	}
+	return erasure, nil
+}
+
+var pendingErasuresQuery = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s IS NULL ORDER BY %s`,
+	erasuresql.UserId,
+	erasuresql.Table,
+	erasuresql.CompletedAt,
+	erasuresql.RequestedAt,
+)
+
+// users of unfinished erasures
+func (s *Storage) PendingErasures(ctx context.Context) ([]int64, error) {
+	rows, err := s.p.Pool.Query(ctx, pendingErasuresQuery)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("select pending erasures", "PendingErasures", _PROVIDER))
+	}
+	userIds, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan pending erasures", "PendingErasures", _PROVIDER))
+	}
+	return userIds, nil
+}
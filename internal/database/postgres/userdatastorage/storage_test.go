@@ -0,0 +1,115 @@
+package userdatastorage_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/database/postgres/userdatastorage"
+	"github.com/Tap-Team/timerapi/internal/errorutils/userdataerror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/erasuresql"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPostgres        *postgres.Postgres
+	testUserDataStorage *userdatastorage.Storage
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("TZ", "UTC")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, terminate, err := postgres.NewContainer(ctx, postgres.DEFAULT_MIGRATION_PATH)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer terminate(ctx)
+	testPostgres = p
+	testUserDataStorage = userdatastorage.New(p)
+	m.Run()
+}
+
+// requested_at has seconds precision, so tests move request time to past instead of sleep
+func setRequestedAt(t *testing.T, ctx context.Context, userId int64, requestedAt time.Time) {
+	query := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE %s = $2`, erasuresql.Table, erasuresql.RequestedAt, erasuresql.UserId)
+	_, err := testPostgres.Pool.Exec(ctx, query, requestedAt, userId)
+	require.NoError(t, err, "set requested at failed")
+}
+
+func TestErasure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+
+	_, err := testUserDataStorage.Erasure(ctx, userId)
+	require.ErrorIs(t, err, userdataerror.ExceptionErasureNotFound(), "not started erasure found")
+	_, err = testUserDataStorage.CompleteErasure(ctx, userId)
+	require.ErrorIs(t, err, userdataerror.ExceptionErasureNotFound(), "not started erasure completed")
+
+	erasure, err := testUserDataStorage.StartErasure(ctx, userId)
+	require.NoError(t, err, "start erasure failed")
+	require.Equal(t, userId, erasure.UserId, "wrong erasure user")
+	require.False(t, erasure.Completed, "started erasure completed")
+	require.True(t, erasure.CompletedAt.T().IsZero(), "started erasure has completed at")
+
+	// unfinished erasure keeps request time
+	requestedAt := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	setRequestedAt(t, ctx, userId, requestedAt)
+	erasure, err = testUserDataStorage.StartErasure(ctx, userId)
+	require.NoError(t, err, "continue erasure failed")
+	require.Equal(t, requestedAt.Unix(), erasure.RequestedAt.Unix(), "request time of unfinished erasure changed")
+
+	erasure, err = testUserDataStorage.CompleteErasure(ctx, userId)
+	require.NoError(t, err, "complete erasure failed")
+	require.True(t, erasure.Completed, "erasure not completed")
+	require.False(t, erasure.CompletedAt.T().IsZero(), "completed erasure without completed at")
+	require.Equal(t, requestedAt.Unix(), erasure.RequestedAt.Unix(), "request time changed by complete")
+
+	stored, err := testUserDataStorage.Erasure(ctx, userId)
+	require.NoError(t, err, "get erasure failed")
+	require.Equal(t, erasure, stored, "stored erasure not equal completed")
+
+	// completed erasure is restarted with new request time
+	erasure, err = testUserDataStorage.StartErasure(ctx, userId)
+	require.NoError(t, err, "restart erasure failed")
+	require.False(t, erasure.Completed, "restarted erasure completed")
+	require.True(t, erasure.CompletedAt.T().IsZero(), "restarted erasure has completed at")
+	require.Greater(t, erasure.RequestedAt.Unix(), requestedAt.Unix(), "request time of restarted erasure not updated")
+}
+
+func TestPendingErasures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userIds := []int64{rand.Int63(), rand.Int63(), rand.Int63()}
+	completed := rand.Int63()
+	now := time.Now()
+	for i, userId := range append(userIds, completed) {
+		_, err := testUserDataStorage.StartErasure(ctx, userId)
+		require.NoError(t, err, "start erasure failed")
+		// last user requested erasure first
+		setRequestedAt(t, ctx, userId, now.Add(-time.Minute*time.Duration(i+1)))
+	}
+	_, err := testUserDataStorage.CompleteErasure(ctx, completed)
+	require.NoError(t, err, "complete erasure failed")
+
+	pending, err := testUserDataStorage.PendingErasures(ctx)
+	require.NoError(t, err, "get pending erasures failed")
+	// other tests could leave pending erasures
+	own := make(map[int64]bool)
+	for _, userId := range append(userIds, completed) {
+		own[userId] = true
+	}
+	filtered := make([]int64, 0, len(userIds))
+	for _, userId := range pending {
+		if own[userId] {
+			filtered = append(filtered, userId)
+		}
+	}
+	require.Equal(t, []int64{userIds[2], userIds[1], userIds[0]}, filtered, "wrong pending erasures")
+}
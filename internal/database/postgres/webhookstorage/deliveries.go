@@ -0,0 +1,96 @@
+package webhookstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/webhookdeliverysql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var insertDeliveryQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s,%s,%s,%s,%s) VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+	webhookdeliverysql.Table,
+	webhookdeliverysql.ID,
+	webhookdeliverysql.WebhookId,
+	webhookdeliverysql.Event,
+	webhookdeliverysql.Attempt,
+	webhookdeliverysql.StatusCode,
+	webhookdeliverysql.Error,
+	webhookdeliverysql.IsSuccess,
+)
+
+func (s *Storage) InsertDelivery(ctx context.Context, delivery *webhookmodel.Delivery) error {
+	var deliveryErr *string
+	if delivery.Error != "" {
+		deliveryErr = &delivery.Error
+	}
+	_, err := s.p.Pool.Exec(
+		ctx,
+		insertDeliveryQuery,
+		delivery.ID,
+		delivery.WebhookId,
+		string(delivery.Event),
+		delivery.Attempt,
+		delivery.StatusCode,
+		deliveryErr,
+		delivery.IsSuccess,
+	)
+	if err != nil {
+		return Error(err, exception.NewCause("insert delivery", "InsertDelivery", _PROVIDER))
+	}
+	return nil
+}
+
+var webhookDeliveriesQuery = fmt.Sprintf(
+	`SELECT %s FROM %s WHERE %s = $1 ORDER BY %s DESC, %s DESC LIMIT $2 OFFSET $3`,
+	sqlutils.Full(
+		webhookdeliverysql.ID,
+		webhookdeliverysql.WebhookId,
+		webhookdeliverysql.Event,
+		webhookdeliverysql.Attempt,
+		webhookdeliverysql.StatusCode,
+		webhookdeliverysql.Error,
+		webhookdeliverysql.IsSuccess,
+		webhookdeliverysql.CreatedAt,
+	),
+	webhookdeliverysql.Table,
+	sqlutils.Full(webhookdeliverysql.WebhookId),
+	sqlutils.Full(webhookdeliverysql.CreatedAt),
+	sqlutils.Full(webhookdeliverysql.Attempt),
+)
+
+func scanDelivery(row pgx.Row, delivery *webhookmodel.Delivery) error {
+	var deliveryErr *string
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookId,
+		&delivery.Event,
+		&delivery.Attempt,
+		&delivery.StatusCode,
+		&deliveryErr,
+		&delivery.IsSuccess,
+		&delivery.CreatedAt,
+	)
+	if deliveryErr != nil {
+		delivery.Error = *deliveryErr
+	}
+	return err
+}
+
+// delivery log of webhook, newest first
+func (s *Storage) WebhookDeliveries(ctx context.Context, webhookId uuid.UUID, offset, limit int) ([]*webhookmodel.Delivery, error) {
+	rows, err := s.p.Pool.Query(ctx, webhookDeliveriesQuery, webhookId, limit, offset)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("webhook deliveries query", "WebhookDeliveries", _PROVIDER))
+	}
+	deliveries, err := sqlutils.ScanList(rows, scanDelivery)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan delivery list", "WebhookDeliveries", _PROVIDER))
+	}
+	return deliveries, nil
+}
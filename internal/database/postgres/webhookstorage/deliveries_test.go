@@ -0,0 +1,65 @@
+package webhookstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	webhook := randomWebhook(rand.Int63())
+	err := testWebhookStorage.InsertWebhook(ctx, webhook)
+	require.NoError(t, err, "insert webhook failed")
+
+	deliveryId := uuid.New()
+	for attempt := 1; attempt <= 3; attempt++ {
+		delivery := &webhookmodel.Delivery{
+			ID:         deliveryId,
+			WebhookId:  webhook.ID,
+			Event:      webhookmodel.Expire,
+			Attempt:    attempt,
+			StatusCode: 500,
+			Error:      "internal server error",
+		}
+		if attempt == 3 {
+			delivery.StatusCode = 200
+			delivery.Error = ""
+			delivery.IsSuccess = true
+		}
+		err = testWebhookStorage.InsertDelivery(ctx, delivery)
+		require.NoError(t, err, "insert delivery failed")
+	}
+
+	// newest attempt first
+	deliveries, err := testWebhookStorage.WebhookDeliveries(ctx, webhook.ID, 0, 10)
+	require.NoError(t, err, "get deliveries failed")
+	require.Len(t, deliveries, 3, "wrong deliveries count")
+	for i, delivery := range deliveries {
+		require.Equal(t, 3-i, delivery.Attempt, "wrong deliveries order")
+	}
+	require.True(t, deliveries[0].IsSuccess, "success not saved")
+	require.Empty(t, deliveries[0].Error, "error of success delivery")
+	require.Equal(t, "internal server error", deliveries[1].Error, "delivery error not saved")
+
+	deliveries, err = testWebhookStorage.WebhookDeliveries(ctx, webhook.ID, 1, 1)
+	require.NoError(t, err, "get deliveries page failed")
+	require.Len(t, deliveries, 1, "wrong page size")
+	require.Equal(t, 2, deliveries[0].Attempt, "wrong page offset")
+
+	err = testWebhookStorage.InsertDelivery(ctx, &webhookmodel.Delivery{ID: uuid.New(), WebhookId: uuid.New(), Event: webhookmodel.Expire, Attempt: 1})
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "delivery of not existing webhook inserted")
+
+	// deliveries are deleted with webhook
+	err = testWebhookStorage.DeleteWebhook(ctx, webhook.ID)
+	require.NoError(t, err, "delete webhook failed")
+	deliveries, err = testWebhookStorage.WebhookDeliveries(ctx, webhook.ID, 0, 10)
+	require.NoError(t, err, "get deliveries failed")
+	require.Empty(t, deliveries, "deliveries not deleted with webhook")
+}
@@ -0,0 +1,38 @@
+package webhookstorage
+
+import (
+	"errors"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/webhookdeliverysql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const _PROVIDER = "internal/database/postgres/webhookstorage"
+
+type Storage struct {
+	p *postgres.Postgres
+}
+
+func New(p *postgres.Postgres) *Storage {
+	return &Storage{p: p}
+}
+
+func Error(err error, cause exception.Cause) error {
+	pgerr := new(pgconn.PgError)
+	if errors.As(err, &pgerr) {
+		switch pgerr.ConstraintName {
+		case webhookdeliverysql.FK_Webhooks:
+			return exception.Wrap(webhookerror.ExceptionWebhookNotFound(), cause)
+		}
+	}
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return exception.Wrap(webhookerror.ExceptionWebhookNotFound(), cause)
+	default:
+		return exception.Wrap(err, cause)
+	}
+}
@@ -0,0 +1,26 @@
+package webhookstorage_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/database/postgres/webhookstorage"
+	"github.com/Tap-Team/timerapi/pkg/postgres"
+)
+
+var testWebhookStorage *webhookstorage.Storage
+
+func TestMain(m *testing.M) {
+	os.Setenv("TZ", "UTC")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, terminate, err := postgres.NewContainer(ctx, postgres.DEFAULT_MIGRATION_PATH)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer terminate(ctx)
+	testWebhookStorage = webhookstorage.New(p)
+	m.Run()
+}
@@ -0,0 +1,216 @@
+package webhookstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/internal/sqlmodel/webhooksql"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/sqlutils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func eventsToStrings(events []webhookmodel.EventType) []string {
+	s := make([]string, 0, len(events))
+	for _, e := range events {
+		s = append(s, string(e))
+	}
+	return s
+}
+
+var insertWebhookQuery = fmt.Sprintf(
+	`INSERT INTO %s (%s,%s,%s,%s,%s) VALUES ($1,$2,$3,$4,$5)`,
+	webhooksql.Table,
+	webhooksql.ID,
+	webhooksql.UserId,
+	webhooksql.URL,
+	webhooksql.Secret,
+	webhooksql.Events,
+)
+
+func (s *Storage) InsertWebhook(ctx context.Context, webhook *webhookmodel.Webhook) error {
+	_, err := s.p.Pool.Exec(ctx, insertWebhookQuery, webhook.ID, webhook.UserId, webhook.URL, webhook.Secret, eventsToStrings(webhook.Events))
+	if err != nil {
+		return Error(err, exception.NewCause("insert webhook", "InsertWebhook", _PROVIDER))
+	}
+	return nil
+}
+
+var deleteWebhookQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1`,
+	webhooksql.Table,
+	webhooksql.ID,
+)
+
+func (s *Storage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	cmd, err := s.p.Pool.Exec(ctx, deleteWebhookQuery, id)
+	if err != nil {
+		return Error(err, exception.NewCause("delete webhook", "DeleteWebhook", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(webhookerror.ExceptionWebhookNotFound(), exception.NewCause("delete webhook rows = 0", "DeleteWebhook", _PROVIDER))
+	}
+	return nil
+}
+
+var selectWebhookQuery = fmt.Sprintf(
+	`SELECT %s FROM %s`,
+	sqlutils.Full(
+		webhooksql.ID,
+		webhooksql.UserId,
+		webhooksql.URL,
+		webhooksql.Secret,
+		webhooksql.Events,
+		webhooksql.IsEnabled,
+		webhooksql.FailureCount,
+		webhooksql.CreatedAt,
+	),
+	webhooksql.Table,
+)
+
+func scanWebhook(row pgx.Row, webhook *webhookmodel.Webhook) error {
+	var events []string
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.UserId,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.IsEnabled,
+		&webhook.FailureCount,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	webhook.Events = make([]webhookmodel.EventType, 0, len(events))
+	for _, e := range events {
+		webhook.Events = append(webhook.Events, webhookmodel.EventType(e))
+	}
+	return nil
+}
+
+var webhookQuery = selectWebhookQuery + fmt.Sprintf(` WHERE %s = $1`, sqlutils.Full(webhooksql.ID))
+
+func (s *Storage) Webhook(ctx context.Context, id uuid.UUID) (*webhookmodel.Webhook, error) {
+	webhook := new(webhookmodel.Webhook)
+	err := scanWebhook(s.p.Pool.QueryRow(ctx, webhookQuery, id), webhook)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan webhook", "Webhook", _PROVIDER))
+	}
+	return webhook, nil
+}
+
+var userWebhooksQuery = selectWebhookQuery + fmt.Sprintf(
+	` WHERE %s = $1 ORDER BY %s`,
+	sqlutils.Full(webhooksql.UserId),
+	sqlutils.Full(webhooksql.CreatedAt, webhooksql.ID),
+)
+
+func (s *Storage) UserWebhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error) {
+	rows, err := s.p.Pool.Query(ctx, userWebhooksQuery, userId)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("user webhooks query", "UserWebhooks", _PROVIDER))
+	}
+	webhooks, err := sqlutils.ScanList(rows, scanWebhook)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan webhook list", "UserWebhooks", _PROVIDER))
+	}
+	return webhooks, nil
+}
+
+// enabled webhooks of users which subscribed on event
+var usersEventWebhooksQuery = selectWebhookQuery + fmt.Sprintf(
+	` WHERE %s = ANY($1) AND %s AND $2 = ANY(%s)`,
+	sqlutils.Full(webhooksql.UserId),
+	sqlutils.Full(webhooksql.IsEnabled),
+	sqlutils.Full(webhooksql.Events),
+)
+
+func (s *Storage) UsersEventWebhooks(ctx context.Context, userIds []int64, event webhookmodel.EventType) ([]*webhookmodel.Webhook, error) {
+	rows, err := s.p.Pool.Query(ctx, usersEventWebhooksQuery, userIds, string(event))
+	if err != nil {
+		return nil, Error(err, exception.NewCause("users event webhooks query", "UsersEventWebhooks", _PROVIDER))
+	}
+	webhooks, err := sqlutils.ScanList(rows, scanWebhook)
+	if err != nil {
+		return nil, Error(err, exception.NewCause("scan webhook list", "UsersEventWebhooks", _PROVIDER))
+	}
+	return webhooks, nil
+}
+
+var enableWebhookQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = true, %s = 0 WHERE %s = $1`,
+	webhooksql.Table,
+	webhooksql.IsEnabled,
+	webhooksql.FailureCount,
+	webhooksql.ID,
+)
+
+// enable webhook and reset failures counter
+func (s *Storage) EnableWebhook(ctx context.Context, id uuid.UUID) error {
+	cmd, err := s.p.Pool.Exec(ctx, enableWebhookQuery, id)
+	if err != nil {
+		return Error(err, exception.NewCause("enable webhook", "EnableWebhook", _PROVIDER))
+	}
+	if cmd.RowsAffected() == 0 {
+		return Error(webhookerror.ExceptionWebhookNotFound(), exception.NewCause("enable webhook rows = 0", "EnableWebhook", _PROVIDER))
+	}
+	return nil
+}
+
+var webhookFailedQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = %s + 1, %s = %s + 1 < $2 WHERE %s = $1 RETURNING %s`,
+	webhooksql.Table,
+	webhooksql.FailureCount,
+	webhooksql.FailureCount,
+	webhooksql.IsEnabled,
+	webhooksql.FailureCount,
+	webhooksql.ID,
+	webhooksql.IsEnabled,
+)
+
+// increment failures counter, webhook is disabled when counter reach maxFailures
+// returns true if webhook is still enabled
+func (s *Storage) WebhookFailed(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error) {
+	var enabled bool
+	err := s.p.Pool.QueryRow(ctx, webhookFailedQuery, id, maxFailures).Scan(&enabled)
+	if err != nil {
+		return false, Error(err, exception.NewCause("increment webhook failures", "WebhookFailed", _PROVIDER))
+	}
+	return enabled, nil
+}
+
+var webhookSucceededQuery = fmt.Sprintf(
+	`UPDATE %s SET %s = 0 WHERE %s = $1 AND %s != 0`,
+	webhooksql.Table,
+	webhooksql.FailureCount,
+	webhooksql.ID,
+	webhooksql.FailureCount,
+)
+
+func (s *Storage) WebhookSucceeded(ctx context.Context, id uuid.UUID) error {
+	_, err := s.p.Pool.Exec(ctx, webhookSucceededQuery, id)
+	if err != nil {
+		return Error(err, exception.NewCause("reset webhook failures", "WebhookSucceeded", _PROVIDER))
+	}
+	return nil
+}
+
+var deleteUserWebhooksQuery = fmt.Sprintf(
+	`DELETE FROM %s WHERE %s = $1`,
+	webhooksql.Table,
+	webhooksql.UserId,
+)
+
+// delete all user webhooks with deliveries, no error if user has no webhooks
+func (s *Storage) DeleteUserWebhooks(ctx context.Context, userId int64) error {
+	_, err := s.p.Pool.Exec(ctx, deleteUserWebhooksQuery, userId)
+	if err != nil {
+		return Error(err, exception.NewCause("delete user webhooks", "DeleteUserWebhooks", _PROVIDER))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package webhookstorage_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/amidstr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomWebhook(userId int64, events ...webhookmodel.EventType) *webhookmodel.Webhook {
+	if len(events) == 0 {
+		events = []webhookmodel.EventType{webhookmodel.Expire, webhookmodel.Update}
+	}
+	return &webhookmodel.Webhook{
+		ID:        uuid.New(),
+		UserId:    userId,
+		URL:       "https://" + amidstr.MakeString(10) + ".test/hook",
+		Secret:    amidstr.MakeString(64),
+		Events:    events,
+		IsEnabled: true,
+	}
+}
+
+func TestWebhookCrud(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+	webhooks := []*webhookmodel.Webhook{randomWebhook(userId), randomWebhook(userId, webhookmodel.Stop)}
+	for _, webhook := range webhooks {
+		err := testWebhookStorage.InsertWebhook(ctx, webhook)
+		require.NoError(t, err, "insert webhook failed")
+	}
+
+	webhook, err := testWebhookStorage.Webhook(ctx, webhooks[0].ID)
+	require.NoError(t, err, "get webhook failed")
+	require.Equal(t, webhooks[0].URL, webhook.URL, "wrong url")
+	require.Equal(t, webhooks[0].Secret, webhook.Secret, "wrong secret")
+	require.Equal(t, webhooks[0].Events, webhook.Events, "wrong events")
+	require.True(t, webhook.IsEnabled, "new webhook disabled")
+	require.Zero(t, webhook.FailureCount, "new webhook has failures")
+
+	userWebhooks, err := testWebhookStorage.UserWebhooks(ctx, userId)
+	require.NoError(t, err, "get user webhooks failed")
+	require.Len(t, userWebhooks, len(webhooks), "wrong user webhooks count")
+
+	err = testWebhookStorage.DeleteWebhook(ctx, webhooks[0].ID)
+	require.NoError(t, err, "delete webhook failed")
+	_, err = testWebhookStorage.Webhook(ctx, webhooks[0].ID)
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "deleted webhook found")
+	err = testWebhookStorage.DeleteWebhook(ctx, webhooks[0].ID)
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "deleted webhook deleted again")
+
+	err = testWebhookStorage.DeleteUserWebhooks(ctx, userId)
+	require.NoError(t, err, "delete user webhooks failed")
+	userWebhooks, err = testWebhookStorage.UserWebhooks(ctx, userId)
+	require.NoError(t, err, "get user webhooks failed")
+	require.Empty(t, userWebhooks, "user webhooks not deleted")
+	// user without webhooks is not error
+	err = testWebhookStorage.DeleteUserWebhooks(ctx, userId)
+	require.NoError(t, err, "delete webhooks of user without webhooks failed")
+}
+
+func TestUsersEventWebhooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first, second, other := rand.Int63(), rand.Int63(), rand.Int63()
+	expire := randomWebhook(first, webhookmodel.Expire)
+	stop := randomWebhook(first, webhookmodel.Stop)
+	disabled := randomWebhook(second, webhookmodel.Expire)
+	otherUser := randomWebhook(other, webhookmodel.Expire)
+	for _, webhook := range []*webhookmodel.Webhook{expire, stop, disabled, otherUser} {
+		err := testWebhookStorage.InsertWebhook(ctx, webhook)
+		require.NoError(t, err, "insert webhook failed")
+	}
+	enabled, err := testWebhookStorage.WebhookFailed(ctx, disabled.ID, 1)
+	require.NoError(t, err, "webhook failed failed")
+	require.False(t, enabled, "webhook not disabled")
+
+	webhooks, err := testWebhookStorage.UsersEventWebhooks(ctx, []int64{first, second}, webhookmodel.Expire)
+	require.NoError(t, err, "get users event webhooks failed")
+	require.Len(t, webhooks, 1, "wrong event webhooks count")
+	require.Equal(t, expire.ID, webhooks[0].ID, "wrong event webhook")
+}
+
+func TestWebhookFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	maxFailures := 3
+	webhook := randomWebhook(rand.Int63())
+	err := testWebhookStorage.InsertWebhook(ctx, webhook)
+	require.NoError(t, err, "insert webhook failed")
+
+	// success resets failures counter
+	enabled, err := testWebhookStorage.WebhookFailed(ctx, webhook.ID, maxFailures)
+	require.NoError(t, err, "webhook failed failed")
+	require.True(t, enabled, "webhook disabled before max failures")
+	err = testWebhookStorage.WebhookSucceeded(ctx, webhook.ID)
+	require.NoError(t, err, "webhook succeeded failed")
+	stored, err := testWebhookStorage.Webhook(ctx, webhook.ID)
+	require.NoError(t, err, "get webhook failed")
+	require.Zero(t, stored.FailureCount, "failures not reset by success")
+
+	for i := 1; i <= maxFailures; i++ {
+		enabled, err = testWebhookStorage.WebhookFailed(ctx, webhook.ID, maxFailures)
+		require.NoError(t, err, "webhook failed failed")
+		require.Equal(t, i < maxFailures, enabled, "wrong enabled after %d failures", i)
+	}
+	stored, err = testWebhookStorage.Webhook(ctx, webhook.ID)
+	require.NoError(t, err, "get webhook failed")
+	require.False(t, stored.IsEnabled, "webhook not disabled")
+	require.Equal(t, maxFailures, stored.FailureCount, "wrong failures count")
+
+	err = testWebhookStorage.EnableWebhook(ctx, webhook.ID)
+	require.NoError(t, err, "enable webhook failed")
+	stored, err = testWebhookStorage.Webhook(ctx, webhook.ID)
+	require.NoError(t, err, "get webhook failed")
+	require.True(t, stored.IsEnabled, "webhook not enabled")
+	require.Zero(t, stored.FailureCount, "failures not reset by enable")
+
+	err = testWebhookStorage.EnableWebhook(ctx, uuid.New())
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "not existing webhook enabled")
+	_, err = testWebhookStorage.WebhookFailed(ctx, uuid.New(), maxFailures)
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "failure of not existing webhook counted")
+}
@@ -0,0 +1,66 @@
+package idempotencystorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/idempotencymodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/redis/go-redis/v9"
+)
+
+const _PROVIDER = "internal/database/redis/idempotencystorage"
+
+type Storage struct {
+	rc *redis.Client
+}
+
+func New(rc *redis.Client) *Storage {
+	return &Storage{rc: rc}
+}
+
+func keyPrefix(key string) string {
+	return "idempotency_" + key
+}
+
+// save not completed record if key is new, otherwise return saved record and false
+func (s *Storage) Start(ctx context.Context, key string, hash string, ttl time.Duration) (*idempotencymodel.Record, bool, error) {
+	record := &idempotencymodel.Record{Hash: hash}
+	ok, err := s.rc.SetNX(ctx, keyPrefix(key), record, ttl).Result()
+	if err != nil {
+		return nil, false, exception.Wrap(err, exception.NewCause("set record", "Start", _PROVIDER))
+	}
+	if ok {
+		return record, true, nil
+	}
+	saved := new(idempotencymodel.Record)
+	err = s.rc.Get(ctx, keyPrefix(key)).Scan(saved)
+	// record expired between set and get, key can be started again
+	if errors.Is(err, redis.Nil) {
+		return s.Start(ctx, key, hash, ttl)
+	}
+	if err != nil {
+		return nil, false, exception.Wrap(err, exception.NewCause("get saved record", "Start", _PROVIDER))
+	}
+	return saved, false, nil
+}
+
+// save completed record with response
+func (s *Storage) Complete(ctx context.Context, key string, record *idempotencymodel.Record, ttl time.Duration) error {
+	record.Completed = true
+	err := s.rc.Set(ctx, keyPrefix(key), record, ttl).Err()
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("set completed record", "Complete", _PROVIDER))
+	}
+	return nil
+}
+
+// delete record, request with key can be repeated
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	err := s.rc.Del(ctx, keyPrefix(key)).Err()
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete record", "Delete", _PROVIDER))
+	}
+	return nil
+}
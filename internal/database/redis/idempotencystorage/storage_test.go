@@ -0,0 +1,57 @@
+package idempotencystorage_test
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/database/redis/idempotencystorage"
+	"github.com/Tap-Team/timerapi/pkg/rediscontainer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testIdempotencyStorage *idempotencystorage.Storage
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	rc, term, err := rediscontainer.New(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer term(ctx)
+	testIdempotencyStorage = idempotencystorage.New(rc)
+	m.Run()
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	key := uuid.NewString()
+
+	record, ok, err := testIdempotencyStorage.Start(ctx, key, "hash", time.Minute)
+	require.NoError(t, err, "start failed")
+	require.True(t, ok, "new key not started")
+
+	saved, ok, err := testIdempotencyStorage.Start(ctx, key, "hash", time.Minute)
+	require.NoError(t, err, "repeated start failed")
+	require.False(t, ok, "key started twice")
+	require.False(t, saved.Completed, "in progress record completed")
+
+	record.Status = http.StatusCreated
+	record.Body = []byte(`{"id":1}`)
+	err = testIdempotencyStorage.Complete(ctx, key, record, time.Minute)
+	require.NoError(t, err, "complete failed")
+	saved, _, err = testIdempotencyStorage.Start(ctx, key, "hash", time.Minute)
+	require.NoError(t, err, "start completed failed")
+	require.Equal(t, *record, *saved, "wrong saved record")
+
+	err = testIdempotencyStorage.Delete(ctx, key)
+	require.NoError(t, err, "delete failed")
+	_, ok, err = testIdempotencyStorage.Start(ctx, key, "hash", time.Minute)
+	require.NoError(t, err, "start deleted failed")
+	require.True(t, ok, "deleted key not started")
+}
@@ -0,0 +1,60 @@
+package ratelimitstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/redis/go-redis/v9"
+)
+
+const _PROVIDER = "internal/database/redis/ratelimitstorage"
+
+type Storage struct {
+	rc *redis.Client
+}
+
+func New(rc *redis.Client) *Storage {
+	return &Storage{rc: rc}
+}
+
+func bucketPrefix(key string) string {
+	return "ratelimit_" + key
+}
+
+// token bucket is refilled by rate tokens per second up to burst tokens,
+// redis time is used so bucket is shared by all replicas
+// returns 1 and 0 if token is taken, otherwise 0 and milliseconds to wait for next token
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate))
+return {allowed, wait}
+`)
+
+// take one token from bucket of key, if bucket is empty returns false and time until next token
+func (s *Storage) Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	result, err := tokenBucketScript.Run(ctx, s.rc, []string{bucketPrefix(key)}, rate, burst).Int64Slice()
+	if err != nil {
+		return false, 0, exception.Wrap(err, exception.NewCause("run token bucket script", "Take", _PROVIDER))
+	}
+	return result[0] == 1, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,53 @@
+package ratelimitstorage_test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/database/redis/ratelimitstorage"
+	"github.com/Tap-Team/timerapi/pkg/rediscontainer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testRateLimitStorage *ratelimitstorage.Storage
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	rc, term, err := rediscontainer.New(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer term(ctx)
+	testRateLimitStorage = ratelimitstorage.New(rc)
+	m.Run()
+}
+
+func TestTake(t *testing.T) {
+	ctx := context.Background()
+	key := uuid.NewString()
+	burst := 3
+	for i := 0; i < burst; i++ {
+		ok, _, err := testRateLimitStorage.Take(ctx, key, 1, burst)
+		require.NoError(t, err, "take failed")
+		require.True(t, ok, "token not taken from full bucket")
+	}
+	ok, wait, err := testRateLimitStorage.Take(ctx, key, 1, burst)
+	require.NoError(t, err, "take failed")
+	require.False(t, ok, "token taken from empty bucket")
+	require.True(t, wait > 0 && wait <= time.Second, "wrong wait time %s", wait)
+
+	// other key has own bucket
+	ok, _, err = testRateLimitStorage.Take(ctx, uuid.NewString(), 1, burst)
+	require.NoError(t, err, "take failed")
+	require.True(t, ok, "token not taken from other bucket")
+
+	time.Sleep(wait)
+	ok, _, err = testRateLimitStorage.Take(ctx, key, 1, burst)
+	require.NoError(t, err, "take failed")
+	require.True(t, ok, "bucket not refilled")
+}
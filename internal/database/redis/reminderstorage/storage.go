@@ -0,0 +1,80 @@
+package reminderstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/remindermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const _PROVIDER = "internal/database/redis/reminderstorage"
+
+const (
+	// sorted set of reminder keys by due time in unix milliseconds
+	dueKey = "reminders_due"
+	// hash of reminders by reminder key
+	remindersKey = "reminders"
+)
+
+type Storage struct {
+	rc *redis.Client
+}
+
+func New(rc *redis.Client) *Storage {
+	return &Storage{rc: rc}
+}
+
+// user has at most one reminder about timer
+func reminderKey(userId int64, timerId uuid.UUID) string {
+	return fmt.Sprintf("%d_%s", userId, timerId)
+}
+
+// save reminder, repeated snooze of the same timer replace previous reminder
+func (s *Storage) Schedule(ctx context.Context, reminder remindermodel.Reminder, due time.Time) error {
+	key := reminderKey(reminder.UserId, reminder.Timer.ID)
+	_, err := s.rc.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, remindersKey, key, reminder)
+		p.ZAdd(ctx, dueKey, redis.Z{Score: float64(due.UnixMilli()), Member: key})
+		return nil
+	})
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("save reminder", "Schedule", _PROVIDER))
+	}
+	return nil
+}
+
+// remove at most limit reminders with due time before now and return them,
+// script is atomic so every reminder is taken by one replica
+var takeDueScript = redis.NewScript(`
+local keys = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+local reminders = {}
+for _, key in ipairs(keys) do
+	local reminder = redis.call('HGET', KEYS[2], key)
+	redis.call('ZREM', KEYS[1], key)
+	redis.call('HDEL', KEYS[2], key)
+	if reminder then
+		table.insert(reminders, reminder)
+	end
+end
+return reminders
+`)
+
+func (s *Storage) TakeDue(ctx context.Context, now time.Time, limit int) ([]remindermodel.Reminder, error) {
+	result, err := takeDueScript.Run(ctx, s.rc, []string{dueKey, remindersKey}, strconv.FormatInt(now.UnixMilli(), 10), limit).StringSlice()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("run take due script", "TakeDue", _PROVIDER))
+	}
+	reminders := make([]remindermodel.Reminder, len(result))
+	for i, data := range result {
+		err = reminders[i].UnmarshalBinary([]byte(data))
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("unmarshal reminder", "TakeDue", _PROVIDER))
+		}
+	}
+	return reminders, nil
+}
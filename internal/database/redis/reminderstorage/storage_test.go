@@ -0,0 +1,56 @@
+package reminderstorage_test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/database/redis/reminderstorage"
+	"github.com/Tap-Team/timerapi/internal/model/remindermodel"
+	"github.com/Tap-Team/timerapi/internal/testdatamodule"
+	"github.com/Tap-Team/timerapi/pkg/rediscontainer"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testReminderStorage *reminderstorage.Storage
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	rc, term, err := rediscontainer.New(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer term(ctx)
+	testReminderStorage = reminderstorage.New(rc)
+	m.Run()
+}
+
+func TestTakeDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	timer := testdatamodule.RandomTimer()
+	reminder := remindermodel.Reminder{UserId: 1, Timer: *timer}
+
+	err := testReminderStorage.Schedule(ctx, reminder, now.Add(time.Minute))
+	require.NoError(t, err, "schedule failed")
+	reminders, err := testReminderStorage.TakeDue(ctx, now, 10)
+	require.NoError(t, err, "take due failed")
+	require.Empty(t, reminders, "reminder taken before due time")
+
+	// repeated snooze replace reminder
+	err = testReminderStorage.Schedule(ctx, reminder, now.Add(time.Minute*2))
+	require.NoError(t, err, "schedule failed")
+	reminders, err = testReminderStorage.TakeDue(ctx, now.Add(time.Minute*3), 10)
+	require.NoError(t, err, "take due failed")
+	require.Len(t, reminders, 1, "wrong reminders count")
+	require.Equal(t, reminder.UserId, reminders[0].UserId, "wrong user")
+	require.Equal(t, timer.ID, reminders[0].Timer.ID, "wrong timer")
+
+	// reminder is taken once
+	reminders, err = testReminderStorage.TakeDue(ctx, now.Add(time.Minute*3), 10)
+	require.NoError(t, err, "take due failed")
+	require.Empty(t, reminders, "reminder taken twice")
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -34,6 +35,8 @@ func timerPrefix(timerId uuid.UUID) string {
 
 // get subscribers by timerId
 func (s *Storage) TimerSubscribers(ctx context.Context, timerId uuid.UUID) (timermodel.Subscribers, error) {
+	ctx, span := tracing.Start(ctx, "subscriberstorage.TimerSubscribers")
+	defer span.End()
 	subscribers := make(timermodel.Subscribers)
 	err := s.rc.Get(ctx, timerPrefix(timerId)).Scan(&subscribers)
 	if err != nil {
@@ -43,6 +46,8 @@ func (s *Storage) TimerSubscribers(ctx context.Context, timerId uuid.UUID) (time
 }
 
 func (s *Storage) Subscribe(ctx context.Context, timerId uuid.UUID, userIds ...int64) error {
+	ctx, span := tracing.Start(ctx, "subscriberstorage.Subscribe")
+	defer span.End()
 	subscribers := make(timermodel.Subscribers)
 	err := s.rc.Get(ctx, timerPrefix(timerId)).Scan(&subscribers)
 	// if error with connection return error
@@ -65,6 +70,8 @@ func (s *Storage) Subscribe(ctx context.Context, timerId uuid.UUID, userIds ...i
 }
 
 func (s *Storage) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "subscriberstorage.Unsubscribe")
+	defer span.End()
 	subscribers := make(timermodel.Subscribers)
 	err := s.rc.Get(ctx, timerPrefix(timerId)).Scan(&subscribers)
 	if err != nil {
@@ -87,6 +94,8 @@ func (s *Storage) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int
 }
 
 func (s *Storage) DeleteTimer(ctx context.Context, timerId uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "subscriberstorage.DeleteTimer")
+	defer span.End()
 	err := s.rc.Del(ctx, timerPrefix(timerId)).Err()
 	if err != nil {
 		return Error(err, exception.NewCause("delete timer", "DeleteTimer", _PROVIDER))
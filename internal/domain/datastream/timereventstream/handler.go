@@ -39,6 +39,34 @@ func New() *EventHandler {
 	}
 }
 
+// number of events waiting in all streams
+func (h *EventHandler) QueueDepth() int {
+	depth := 0
+	h.streamStorage.RLock()
+	for _, es := range h.streamStorage.storage {
+		depth += len(es.stream)
+	}
+	h.streamStorage.RUnlock()
+	return depth
+}
+
+// number of subscribed timers of every stream
+func (h *EventHandler) Subscriptions() []int {
+	h.streamStorage.RLock()
+	streams := make([]*EventStream, 0, len(h.streamStorage.storage))
+	for _, es := range h.streamStorage.storage {
+		streams = append(streams, es)
+	}
+	h.streamStorage.RUnlock()
+	subscriptions := make([]int, 0, len(streams))
+	for _, es := range streams {
+		es.timers.RLock()
+		subscriptions = append(subscriptions, len(es.timers.storage))
+		es.timers.RUnlock()
+	}
+	return subscriptions
+}
+
 func (h *EventHandler) Send(event timerevent.TimerEvent) {
 	// get event stream which subscribe on timer
 	subscribers := make([]uuid.UUID, 0)
@@ -5,12 +5,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/metrics"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/internal/timerservice"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/graceful"
 	"github.com/google/uuid"
 )
 
@@ -20,7 +23,7 @@ type TimerStorage interface {
 	DeleteTimer(ctx context.Context, id uuid.UUID) error
 	Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error)
 	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
-	UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool) error
+	UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool, version int64) error
 }
 
 type SubscriberCacheStorage interface {
@@ -32,6 +35,11 @@ type NotificationStorage interface {
 	InsertNotification(ctx context.Context, userId int64, notification notification.Notification) error
 }
 
+// listener get every notification with all timer subscribers, online and offline
+type Listener interface {
+	SendNotification(n notification.NotificationSubscribers)
+}
+
 type StreamHandler struct {
 	mu *sync.Mutex
 	// map of user to stream
@@ -43,6 +51,11 @@ type StreamHandler struct {
 	// stream to send notification to handler
 	ch chan notification.Notification
 
+	listeners []Listener
+
+	// runs notification handlers, waited on shutdown
+	worker *graceful.Worker
+
 	timerservice        timerservice.TimerServiceClient
 	timerStorage        TimerStorage
 	subscriberStorage   SubscriberCacheStorage
@@ -65,13 +78,39 @@ func New(
 		subscribers:    make(map[int64]map[uuid.UUID]*UserStream),
 		serviceStreams: make(map[uuid.UUID]*ServiceStream),
 		ch:             make(chan notification.Notification, 1024),
+		worker:         graceful.NewWorker(),
 	}
 }
 
+// add listener of notifications, should be called before Start
+func (sh *StreamHandler) Listen(listener Listener) {
+	sh.mu.Lock()
+	sh.listeners = append(sh.listeners, listener)
+	sh.mu.Unlock()
+}
+
 func (sh *StreamHandler) Send(notification notification.Notification) {
 	sh.ch <- notification
 }
 
+// number of notifications waiting in handler queue
+func (sh *StreamHandler) QueueDepth() int {
+	return len(sh.ch)
+}
+
+// handle notification synchronously, used by tools which don't start handler
+// nobody is online in such tools, so notifications are saved in storage for every subscriber
+func (sh *StreamHandler) Handle(ctx context.Context, n notification.Notification) {
+	switch n.Type() {
+	case notification.Delete:
+		sh.timerDelete(ctx, n.Timer())
+	case notification.Removed:
+		sh.recipients(ctx, n)
+	}
+}
+
+// read timer ticks and notifications until ctx is done
+// cancelling ctx closes ticker stream, queued notifications are handled before return
 func (sh *StreamHandler) Start(ctx context.Context) error {
 	stream, err := sh.timerservice.TimerTick(ctx)
 	if err != nil {
@@ -81,39 +120,91 @@ func (sh *StreamHandler) Start(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
+			sh.drainQueue()
 			return nil
 		case timerIds, ok := <-stream:
 			if !ok {
 				continue
 			}
 			for _, timerId := range timerIds {
-				go sh.timerExpired(ctx, timerId)
+				timerId := timerId
+				sh.worker.Go(func(ctx context.Context) { sh.timerExpired(ctx, timerId) })
 			}
 		case n, ok := <-sh.ch:
 			if !ok {
 				continue
 			}
-			switch n.Type() {
-			case notification.Delete:
-				go sh.timerDelete(ctx, n.Timer())
-			}
+			sh.handle(n)
 		}
 	}
 }
 
+// wait for running handlers, after ctx deadline handlers are cancelled
+// should be called after Start returned
+func (sh *StreamHandler) Drain(ctx context.Context) error {
+	return sh.worker.Drain(ctx)
+}
+
+// handle notifications left in queue without blocking
+func (sh *StreamHandler) drainQueue() {
+	for {
+		select {
+		case n := <-sh.ch:
+			sh.handle(n)
+		default:
+			return
+		}
+	}
+}
+
+func (sh *StreamHandler) handle(n notification.Notification) {
+	switch n.Type() {
+	case notification.Delete:
+		sh.worker.Go(func(ctx context.Context) { sh.timerDelete(ctx, n.Timer()) })
+	case notification.Removed:
+		sh.worker.Go(func(ctx context.Context) { sh.recipients(ctx, n) })
+	}
+}
+
 // send notification for every subscriber
 // if subscriber offline save notification in storage
 func (sh *StreamHandler) notification(ctx context.Context, ntion notification.Notification) {
 	timerSubscribers, err := sh.subscriberStorage.TimerSubscribers(ctx, ntion.TimerId())
 	if err != nil {
+		logging.FromContext(ctx).Error("failed get timer subscribers, notification isn't sent", logging.Err(err))
 		return
 	}
+	sh.send(ctx, ntion, timerSubscribers.Array())
+}
 
+// send notification only to users listed in notification
+func (sh *StreamHandler) recipients(ctx context.Context, n notification.Notification) {
+	ns, ok := n.(notification.NotificationSubscribers)
+	if !ok {
+		return
+	}
+	ctx = logging.With(ctx, "timer_id", n.TimerId(), "notification_type", n.Type())
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	sh.send(ctx, &notification.NotificationDTO{Ntype: n.Type(), NTimer: n.Timer()}, ns.Subscribers())
+}
+
+// send notification to users
+// if user offline save notification in storage
+func (sh *StreamHandler) send(ctx context.Context, ntion notification.Notification, userIds []int64) {
+	start := time.Now()
+	defer func() { metrics.ObserveFanout(string(ntion.Type()), len(userIds), time.Since(start)) }()
 	offlineSubs := make([]int64, 0)
 
 	sh.mu.Lock()
+	if len(sh.listeners) != 0 {
+		withSubscribers := notification.NewWithSubscribers(ntion, userIds)
+		for _, listener := range sh.listeners {
+			listener.SendNotification(withSubscribers)
+		}
+	}
 	// in range send to every stream subscriber notification, if user offline send to external service
-	for userId := range timerSubscribers {
+	for _, userId := range userIds {
 		if ntion.Type() == notification.Delete && ntion.Timer().Creator == userId {
 			continue
 		}
@@ -131,7 +222,10 @@ func (sh *StreamHandler) notification(ctx context.Context, ntion notification.No
 
 	// save unreaded notification in storage
 	for _, userId := range offlineSubs {
-		sh.notificationStorage.InsertNotification(ctx, userId, ntion)
+		err := sh.notificationStorage.InsertNotification(ctx, userId, ntion)
+		if err != nil {
+			logging.FromContext(ctx).Error("failed save notification of offline user", "user_id", userId, logging.Err(err))
+		}
 	}
 
 	sh.mu.Lock()
@@ -146,23 +240,29 @@ func (sh *StreamHandler) notification(ctx context.Context, ntion notification.No
 }
 
 func (sh *StreamHandler) timerDelete(ctx context.Context, timer timermodel.Timer) {
+	ctx = logging.With(ctx, "timer_id", timer.ID, "notification_type", notification.Delete)
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 	// send notification for every subscriber
 	sh.notification(ctx, notification.NewDelete(timer))
 	// delete timer from storage
-	sh.subscriberStorage.DeleteTimer(ctx, timer.ID)
+	if err := sh.subscriberStorage.DeleteTimer(ctx, timer.ID); err != nil {
+		logging.FromContext(ctx).Error("failed delete timer from subscriber storage", logging.Err(err))
+	}
 }
 
 func (sh *StreamHandler) timerExpired(ctx context.Context, timerId uuid.UUID) {
+	ctx = logging.With(ctx, "timer_id", timerId, "notification_type", notification.Expired)
 	// create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 	timer, err := sh.timerStorage.Timer(ctx, timerId)
 	if err != nil {
+		logging.FromContext(ctx).Error("failed get expired timer", logging.Err(err))
 		return
 	}
+	metrics.TimerExpired()
 
 	// send notification for every subscriber
 	sh.notification(ctx, notification.NewExpired(*timer))
@@ -176,9 +276,13 @@ func (sh *StreamHandler) clearExpiredTimer(ctx context.Context, timer timermodel
 	switch timer.Type {
 	case timerfields.DATE:
 		// delete timer from storage
-		sh.timerStorage.DeleteTimer(ctx, timer.ID)
+		if err := sh.timerStorage.DeleteTimer(ctx, timer.ID); err != nil {
+			logging.FromContext(ctx).Error("failed delete expired timer", logging.Err(err))
+		}
 		// delete timer from subsriber storage with them subscribers
-		sh.subscriberStorage.DeleteTimer(ctx, timer.ID)
+		if err := sh.subscriberStorage.DeleteTimer(ctx, timer.ID); err != nil {
+			logging.FromContext(ctx).Error("failed delete expired timer from subscriber storage", logging.Err(err))
+		}
 	case timerfields.COUNTDOWN:
 		/*
 				to reset timer we need 2 things
@@ -203,6 +307,8 @@ func (sh *StreamHandler) clearExpiredTimer(ctx context.Context, timer timermodel
 			2 = 2
 		*/
 		pauseTime := amidtime.DateTime(time.Unix(timer.EndTime.Unix()-timer.Duration, 0))
-		sh.timerStorage.UpdatePauseTime(ctx, timer.ID, pauseTime, true)
+		if err := sh.timerStorage.UpdatePauseTime(ctx, timer.ID, pauseTime, true, 0); err != nil {
+			logging.FromContext(ctx).Error("failed reset expired countdown timer", logging.Err(err))
+		}
 	}
 }
@@ -85,10 +85,10 @@ func testData(t *testing.T, ctx context.Context, opts ...testdatamodule.TimerOpt
 	for _, timer := range timers {
 		switch timer.Type {
 		case timerfields.COUNTDOWN:
-			err := timerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer())
+			err := timerStorage.InsertCountdownTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 			require.NoError(t, err, "failed insert countdown timer")
 		case timerfields.DATE:
-			err := timerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer())
+			err := timerStorage.InsertDateTimer(ctx, timer.Creator, timer.CreateTimer(), 0)
 			require.NoError(t, err, "failed insert date timer")
 		}
 		err := timerService.Add(ctx, timer.ID, timer.EndTime.Unix())
@@ -0,0 +1,325 @@
+package webhookstream
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/graceful"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+)
+
+const _PROVIDER = "internal/domain/datastream/webhookstream"
+
+const (
+	HeaderEvent     = "X-Timer-Event"
+	HeaderDelivery  = "X-Timer-Delivery"
+	HeaderTimestamp = "X-Timer-Timestamp"
+	// hex encoded HMAC-SHA256 of "timestamp.body" with webhook secret, prefixed with "sha256="
+	HeaderSignature = "X-Timer-Signature"
+)
+
+type WebhookStorage interface {
+	UsersEventWebhooks(ctx context.Context, userIds []int64, event webhookmodel.EventType) ([]*webhookmodel.Webhook, error)
+	InsertDelivery(ctx context.Context, delivery *webhookmodel.Delivery) error
+	WebhookFailed(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error)
+	WebhookSucceeded(ctx context.Context, id uuid.UUID) error
+}
+
+type SubscriberCacheStorage interface {
+	TimerSubscribers(ctx context.Context, timerId uuid.UUID) (timermodel.Subscribers, error)
+}
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type EventSender interface {
+	Send(event timerevent.TimerEvent)
+}
+
+type Config struct {
+	// attempts of one payload delivery
+	MaxAttempts int
+	// delay before second attempt, every next delay is doubled
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// webhook is disabled after MaxFailures failed deliveries in a row
+	MaxFailures int
+	// timeout of one request
+	Timeout time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxBackoff:  time.Minute,
+		MaxFailures: 10,
+		Timeout:     time.Second * 10,
+	}
+}
+
+// http client which connects only to public addresses,
+// address is checked after resolve, so host can't be rebinded to private address after webhook is created
+func NewHttpClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: time.Second * 30,
+		Control: controlPublicAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// proxy is usually private address, requests are sent directly
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+func controlPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !webhookmodel.PublicIP(ip) {
+		return fmt.Errorf("address %s is not public", host)
+	}
+	return nil
+}
+
+// job to resolve webhooks of subscribers and deliver payload
+type job struct {
+	event       webhookmodel.EventType
+	timerId     uuid.UUID
+	subscribers []int64
+	data        any
+}
+
+type Dispatcher struct {
+	storage           WebhookStorage
+	subscriberStorage SubscriberCacheStorage
+	client            HttpClient
+	config            Config
+
+	ch chan job
+	// runs dispatches and deliveries, waited on shutdown
+	worker *graceful.Worker
+}
+
+func New(
+	storage WebhookStorage,
+	subscriberStorage SubscriberCacheStorage,
+	client HttpClient,
+	config Config,
+) *Dispatcher {
+	return &Dispatcher{
+		storage:           storage,
+		subscriberStorage: subscriberStorage,
+		client:            client,
+		config:            config,
+		ch:                make(chan job, 1024),
+		worker:            graceful.NewWorker(),
+	}
+}
+
+func (d *Dispatcher) enqueue(j job) {
+	select {
+	case d.ch <- j:
+	default:
+		slog.Warn("webhook queue is full, event dropped", "event", j.event, "timer_id", j.timerId)
+	}
+}
+
+// send timer event to webhooks of timer subscribers, subscribers are taken from cache storage
+func (d *Dispatcher) SendEvent(event timerevent.TimerEvent) {
+	etype, ok := webhookmodel.TimerEventType(event)
+	if !ok {
+		return
+	}
+	d.enqueue(job{event: etype, timerId: event.TimerId(), data: event})
+}
+
+// send notification to webhooks of notification subscribers
+func (d *Dispatcher) SendNotification(n notification.NotificationSubscribers) {
+	etype, ok := webhookmodel.NotificationEventType(n)
+	if !ok {
+		return
+	}
+	data := notification.NotificationDTO{Ntype: n.Type(), NTimer: n.Timer()}
+	d.enqueue(job{event: etype, timerId: n.TimerId(), subscribers: n.Subscribers(), data: data})
+}
+
+type eventSender struct {
+	next       EventSender
+	dispatcher *Dispatcher
+}
+
+func (s *eventSender) Send(event timerevent.TimerEvent) {
+	s.next.Send(event)
+	s.dispatcher.SendEvent(event)
+}
+
+// wrap event sender, every event will be sent to next sender and to webhooks
+func (d *Dispatcher) EventSender(next EventSender) EventSender {
+	return &eventSender{next: next, dispatcher: d}
+}
+
+// dispatch queued jobs until ctx is done, jobs left in queue are dispatched before return
+func (d *Dispatcher) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case j := <-d.ch:
+					d.worker.Go(func(ctx context.Context) { d.dispatch(ctx, j) })
+				default:
+					return
+				}
+			}
+		case j := <-d.ch:
+			d.worker.Go(func(ctx context.Context) { d.dispatch(ctx, j) })
+		}
+	}
+}
+
+// wait for running deliveries, after ctx deadline deliveries are cancelled
+// should be called after Start returned
+func (d *Dispatcher) Drain(ctx context.Context) error {
+	return d.worker.Drain(ctx)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, j job) {
+	ctx = logging.With(ctx, "timer_id", j.timerId, "event", j.event)
+	subscribers := j.subscribers
+	if subscribers == nil {
+		subs, err := d.subscriberStorage.TimerSubscribers(ctx, j.timerId)
+		if err != nil {
+			logging.FromContext(ctx).Error("failed get timer subscribers, webhooks aren't sent", logging.Err(err))
+			return
+		}
+		subscribers = subs.Array()
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+	webhooks, err := d.storage.UsersEventWebhooks(ctx, subscribers, j.event)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed get webhooks of timer", logging.Err(err))
+		return
+	}
+	for _, webhook := range webhooks {
+		payload := webhookmodel.Payload{
+			ID:        uuid.New(),
+			Event:     j.event,
+			TimerId:   j.timerId,
+			CreatedAt: time.Now().Unix(),
+			Data:      j.data,
+		}
+		webhook := webhook
+		d.worker.Go(func(ctx context.Context) { d.Deliver(ctx, webhook, &payload) })
+	}
+}
+
+// deliver payload to webhook with retries, every attempt is saved in delivery log
+// returns true if payload was delivered
+func (d *Dispatcher) Deliver(ctx context.Context, webhook *webhookmodel.Webhook, payload *webhookmodel.Payload) bool {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("failed marshal webhook payload", "delivery_id", payload.ID, "timer_id", payload.TimerId, logging.Err(err))
+		return false
+	}
+	backoff := d.config.Backoff
+	for attempt := 1; attempt <= d.config.MaxAttempts; attempt++ {
+		delivery := &webhookmodel.Delivery{
+			ID:        payload.ID,
+			WebhookId: webhook.ID,
+			Event:     payload.Event,
+			Attempt:   attempt,
+		}
+		delivery.StatusCode, err = d.post(ctx, webhook, payload, body)
+		delivery.IsSuccess = err == nil
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if err := d.storage.InsertDelivery(ctx, delivery); err != nil {
+			slog.Error("failed save webhook delivery", "delivery_id", payload.ID, "webhook_id", webhook.ID, logging.Err(err))
+		}
+		if delivery.IsSuccess {
+			if err := d.storage.WebhookSucceeded(ctx, webhook.ID); err != nil {
+				slog.Error("failed reset webhook failures", "webhook_id", webhook.ID, logging.Err(err))
+			}
+			return true
+		}
+		if attempt == d.config.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > d.config.MaxBackoff {
+			backoff = d.config.MaxBackoff
+		}
+	}
+	enabled, err := d.storage.WebhookFailed(ctx, webhook.ID, d.config.MaxFailures)
+	if err != nil {
+		slog.Error("failed update webhook failures", "webhook_id", webhook.ID, logging.Err(err))
+	}
+	if err == nil && !enabled {
+		slog.Warn("webhook disabled after failed deliveries", "webhook_id", webhook.ID, "user_id", webhook.UserId, "failures", d.config.MaxFailures)
+	}
+	return false
+}
+
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send one request, returns response status code
+func (d *Dispatcher) post(ctx context.Context, webhook *webhookmodel.Webhook, payload *webhookmodel.Payload, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, exception.Wrap(err, exception.NewCause("create request", "post", _PROVIDER))
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(payload.Event))
+	req.Header.Set(HeaderDelivery, payload.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
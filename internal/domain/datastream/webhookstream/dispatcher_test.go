@@ -0,0 +1,170 @@
+package webhookstream_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/datastream/webhookstream"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStorage struct {
+	mu         sync.Mutex
+	deliveries []*webhookmodel.Delivery
+	failures   int
+	enabled    bool
+}
+
+func (s *fakeStorage) UsersEventWebhooks(ctx context.Context, userIds []int64, event webhookmodel.EventType) ([]*webhookmodel.Webhook, error) {
+	return nil, nil
+}
+
+func (s *fakeStorage) InsertDelivery(ctx context.Context, delivery *webhookmodel.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, delivery)
+	return nil
+}
+
+func (s *fakeStorage) WebhookFailed(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures++
+	s.enabled = s.failures < maxFailures
+	return s.enabled, nil
+}
+
+func (s *fakeStorage) WebhookSucceeded(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = 0
+	return nil
+}
+
+type fakeSubscribers struct{}
+
+func (fakeSubscribers) TimerSubscribers(ctx context.Context, timerId uuid.UUID) (timermodel.Subscribers, error) {
+	return timermodel.Subscribers{}, nil
+}
+
+func testConfig() webhookstream.Config {
+	return webhookstream.Config{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  time.Millisecond * 4,
+		MaxFailures: 2,
+		Timeout:     time.Second,
+	}
+}
+
+func testWebhook(url string) *webhookmodel.Webhook {
+	return &webhookmodel.Webhook{
+		ID:        uuid.New(),
+		UserId:    1,
+		URL:       url,
+		Secret:    "secret",
+		Events:    []webhookmodel.EventType{webhookmodel.Expire},
+		IsEnabled: true,
+	}
+}
+
+func TestDeliverSignature(t *testing.T) {
+	var (
+		signature, expected string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(webhookstream.HeaderTimestamp), 10, 64)
+		signature = r.Header.Get(webhookstream.HeaderSignature)
+		expected = webhookstream.Sign("secret", ts, body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	storage := &fakeStorage{}
+	dispatcher := webhookstream.New(storage, fakeSubscribers{}, server.Client(), testConfig())
+	payload := &webhookmodel.Payload{ID: uuid.New(), Event: webhookmodel.Expire, TimerId: uuid.New()}
+
+	ok := dispatcher.Deliver(context.Background(), testWebhook(server.URL), payload)
+	require.True(t, ok)
+	require.Equal(t, expected, signature)
+	require.Len(t, storage.deliveries, 1)
+	require.True(t, storage.deliveries[0].IsSuccess)
+	require.Equal(t, http.StatusOK, storage.deliveries[0].StatusCode)
+}
+
+func TestDeliverRetry(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	storage := &fakeStorage{}
+	dispatcher := webhookstream.New(storage, fakeSubscribers{}, server.Client(), testConfig())
+	payload := &webhookmodel.Payload{ID: uuid.New(), Event: webhookmodel.Expire, TimerId: uuid.New()}
+
+	ok := dispatcher.Deliver(context.Background(), testWebhook(server.URL), payload)
+	require.True(t, ok)
+	require.Equal(t, 3, calls)
+	require.Len(t, storage.deliveries, 3)
+	for i, delivery := range storage.deliveries {
+		require.Equal(t, i+1, delivery.Attempt)
+		require.Equal(t, payload.ID, delivery.ID)
+		require.Equal(t, i == 2, delivery.IsSuccess)
+	}
+	require.Equal(t, 0, storage.failures)
+}
+
+func TestDeliverDisable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	storage := &fakeStorage{enabled: true}
+	dispatcher := webhookstream.New(storage, fakeSubscribers{}, server.Client(), testConfig())
+	webhook := testWebhook(server.URL)
+
+	for i := 0; i < 2; i++ {
+		payload := &webhookmodel.Payload{ID: uuid.New(), Event: webhookmodel.Expire, TimerId: uuid.New()}
+		ok := dispatcher.Deliver(context.Background(), webhook, payload)
+		require.False(t, ok)
+	}
+	require.Len(t, storage.deliveries, 6)
+	require.Equal(t, 2, storage.failures)
+	require.False(t, storage.enabled)
+}
+
+func TestDeliverPrivateAddress(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	storage := &fakeStorage{enabled: true}
+	dispatcher := webhookstream.New(storage, fakeSubscribers{}, webhookstream.NewHttpClient(), testConfig())
+	payload := &webhookmodel.Payload{ID: uuid.New(), Event: webhookmodel.Expire, TimerId: uuid.New()}
+
+	// test server listens on loopback address
+	ok := dispatcher.Deliver(context.Background(), testWebhook(server.URL), payload)
+	require.False(t, ok, "payload delivered to private address")
+	require.Zero(t, calls, "request sent to private address")
+	require.Len(t, storage.deliveries, 3)
+}
@@ -0,0 +1,212 @@
+package calendarusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/ical"
+	"github.com/google/uuid"
+)
+
+const _PROVIDER = "internal/domain/usecase/calendarusecase"
+
+const (
+	feedName = "Таймеры"
+	// timers are loaded into feed by pages
+	feedPageSize = 100
+	// max count of timers in one feed
+	feedMaxTimers = 1000
+	// max count of timers created from one file, other events are rejected
+	importMaxTimers = 100
+)
+
+type FeedStorage interface {
+	FeedToken(ctx context.Context, userId int64) (string, error)
+	SetFeedToken(ctx context.Context, userId int64, token string) error
+	FeedUser(ctx context.Context, token string) (int64, error)
+	DeleteFeed(ctx context.Context, userId int64) error
+}
+
+type TimerStorage interface {
+	Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error)
+	UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+}
+
+type TimerUseCase interface {
+	Create(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error
+}
+
+type UseCase struct {
+	feedStorage  FeedStorage
+	timerStorage TimerStorage
+	timerUseCase TimerUseCase
+	feedURL      string
+}
+
+func New(feedStorage FeedStorage, timerStorage TimerStorage, timerUseCase TimerUseCase, feedURL string) *UseCase {
+	return &UseCase{
+		feedStorage:  feedStorage,
+		timerStorage: timerStorage,
+		timerUseCase: timerUseCase,
+		feedURL:      strings.TrimSuffix(feedURL, "/"),
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (uc *UseCase) feed(token string) *calendarmodel.Feed {
+	return &calendarmodel.Feed{Token: token, URL: uc.feedURL + "/" + token + ".ics"}
+}
+
+// ics file of one timer
+func (uc *UseCase) TimerCalendar(ctx context.Context, timerId uuid.UUID) ([]byte, error) {
+	timer, err := uc.timerStorage.Timer(ctx, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get timer", "TimerCalendar", _PROVIDER))
+	}
+	if !calendarmodel.Exportable(timer) {
+		return nil, exception.Wrap(calendarerror.ExceptionTimerPaused(), exception.NewCause("check timer exportable", "TimerCalendar", _PROVIDER))
+	}
+	calendar := calendarmodel.Calendar("", []*timermodel.Timer{timer}, time.Now())
+	b, err := calendar.Bytes()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("encode calendar", "TimerCalendar", _PROVIDER))
+	}
+	return b, nil
+}
+
+// user feed, feed token is created on first call
+func (uc *UseCase) Feed(ctx context.Context, userId int64) (*calendarmodel.Feed, error) {
+	token, err := uc.feedStorage.FeedToken(ctx, userId)
+	if err == nil {
+		return uc.feed(token), nil
+	}
+	if !errors.Is(err, calendarerror.ExceptionFeedNotFound()) {
+		return nil, exception.Wrap(err, exception.NewCause("get feed token", "Feed", _PROVIDER))
+	}
+	feed, err := uc.ResetFeed(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("create feed token", "Feed", _PROVIDER))
+	}
+	return feed, nil
+}
+
+// existing user feed, unlike Feed token isn't created
+func (uc *UseCase) UserFeed(ctx context.Context, userId int64) (*calendarmodel.Feed, error) {
+	token, err := uc.feedStorage.FeedToken(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get feed token", "UserFeed", _PROVIDER))
+	}
+	return uc.feed(token), nil
+}
+
+// delete user feed, feed url stops working
+func (uc *UseCase) DeleteFeed(ctx context.Context, userId int64) error {
+	err := uc.feedStorage.DeleteFeed(ctx, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete feed token", "DeleteFeed", _PROVIDER))
+	}
+	return nil
+}
+
+// replace feed token, old feed url stops working
+func (uc *UseCase) ResetFeed(ctx context.Context, userId int64) (*calendarmodel.Feed, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("generate token", "ResetFeed", _PROVIDER))
+	}
+	err = uc.feedStorage.SetFeedToken(ctx, userId, token)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("set feed token", "ResetFeed", _PROVIDER))
+	}
+	return uc.feed(token), nil
+}
+
+// calendar of all user timers and subscriptions, built on every request so changes of timers are visible on next refresh
+func (uc *UseCase) FeedCalendar(ctx context.Context, token string) ([]byte, error) {
+	userId, err := uc.feedStorage.FeedUser(ctx, token)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get feed user", "FeedCalendar", _PROVIDER))
+	}
+	timers := make([]*timermodel.Timer, 0)
+	for offset := 0; offset < feedMaxTimers; offset += feedPageSize {
+		page, err := uc.timerStorage.UserTimers(ctx, userId, offset, feedPageSize)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("get user timers", "FeedCalendar", _PROVIDER))
+		}
+		timers = append(timers, page...)
+		if len(page) < feedPageSize {
+			break
+		}
+	}
+	calendar := calendarmodel.Calendar(feedName, timers, time.Now())
+	calendar.RefreshInterval = calendarmodel.FeedRefreshInterval
+	b, err := calendar.Bytes()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("encode calendar", "FeedCalendar", _PROVIDER))
+	}
+	return b, nil
+}
+
+// error code of rejected timer
+func reason(err error) string {
+	var e exception.CodeTypedError
+	if errors.As(err, &e) {
+		return exception.MakeCode(e)
+	}
+	return "common_internal"
+}
+
+// create date timers from events of ics file, floating times are read in loc
+// returns report of every event, error is returned only if file can't be read
+func (uc *UseCase) Import(ctx context.Context, userId int64, r io.Reader, loc *time.Location) ([]*calendarmodel.ImportResult, error) {
+	events, err := ical.Parse(r, loc)
+	if err != nil {
+		return nil, exception.Wrap(calendarerror.ExceptionWrongCalendar(), exception.NewCause("parse calendar", "Import", _PROVIDER))
+	}
+	now := time.Now()
+	report := make([]*calendarmodel.ImportResult, 0, len(events))
+	attempts := 0
+	for i := range events {
+		timer, result := calendarmodel.ImportTimer(&events[i], now)
+		report = append(report, result)
+		if timer == nil {
+			continue
+		}
+		if attempts >= importMaxTimers {
+			result.Status = calendarmodel.Rejected
+			result.Reason = calendarmodel.ReasonLimitExceeded
+			continue
+		}
+		attempts++
+		err := timer.Validate()
+		if err == nil {
+			err = uc.timerUseCase.Create(ctx, userId, timer)
+		}
+		if err != nil {
+			result.Status = calendarmodel.Rejected
+			result.Reason = reason(err)
+			continue
+		}
+		result.Status = calendarmodel.Created
+		result.TimerId = &timer.ID
+		result.EndTime = &timer.EndTime
+	}
+	return report, nil
+}
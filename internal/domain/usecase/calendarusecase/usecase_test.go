@@ -0,0 +1,254 @@
+package calendarusecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/calendarusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type feedStorage struct {
+	tokens map[int64]string
+}
+
+func (s *feedStorage) FeedToken(ctx context.Context, userId int64) (string, error) {
+	token, ok := s.tokens[userId]
+	if !ok {
+		return "", calendarerror.ExceptionFeedNotFound()
+	}
+	return token, nil
+}
+
+func (s *feedStorage) SetFeedToken(ctx context.Context, userId int64, token string) error {
+	s.tokens[userId] = token
+	return nil
+}
+
+func (s *feedStorage) DeleteFeed(ctx context.Context, userId int64) error {
+	delete(s.tokens, userId)
+	return nil
+}
+
+func (s *feedStorage) FeedUser(ctx context.Context, token string) (int64, error) {
+	for userId, t := range s.tokens {
+		if t == token {
+			return userId, nil
+		}
+	}
+	return 0, calendarerror.ExceptionFeedNotFound()
+}
+
+type timerUseCase struct {
+	created []*timermodel.CreateTimer
+}
+
+func (uc *timerUseCase) Create(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+	if timer.Name == "fail" {
+		return timererror.ExceptionTimerExists()
+	}
+	uc.created = append(uc.created, timer)
+	return nil
+}
+
+type timerStorage struct {
+	timers []*timermodel.Timer
+}
+
+func (s *timerStorage) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error) {
+	for _, timer := range s.timers {
+		if timer.ID == timerId {
+			return timer, nil
+		}
+	}
+	return nil, timererror.ExceptionTimerNotFound()
+}
+
+func (s *timerStorage) UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	if offset >= len(s.timers) {
+		return []*timermodel.Timer{}, nil
+	}
+	end := offset + limit
+	if end > len(s.timers) {
+		end = len(s.timers)
+	}
+	return s.timers[offset:end], nil
+}
+
+func dateTimer(utc int16, end time.Time) *timermodel.Timer {
+	return &timermodel.Timer{
+		ID:          uuid.New(),
+		UTC:         utc,
+		Creator:     1,
+		EndTime:     amidtime.DateTime(end),
+		Type:        timerfields.DATE,
+		Name:        "Отпуск, наконец",
+		Description: "море; солнце",
+		Color:       timerfields.RED,
+	}
+}
+
+func TestTimerCalendar(t *testing.T) {
+	ctx := context.Background()
+	end := time.Date(2026, 12, 31, 21, 0, 0, 0, time.UTC)
+	timer := dateTimer(3, end)
+	countdown := &timermodel.Timer{
+		ID:       uuid.New(),
+		EndTime:  amidtime.DateTime(end),
+		Type:     timerfields.COUNTDOWN,
+		Duration: 600,
+		IsPaused: true,
+	}
+	uc := calendarusecase.New(&feedStorage{}, &timerStorage{timers: []*timermodel.Timer{timer, countdown}}, nil, "")
+
+	b, err := uc.TimerCalendar(ctx, timer.ID)
+	require.NoError(t, err)
+	ics := string(b)
+	for _, line := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"TZID:UTC+03:00\r\n",
+		"TZOFFSETTO:+0300\r\n",
+		"UID:" + timer.ID.String() + "@timerapi\r\n",
+		"DTSTART;TZID=UTC+03:00:20270101T000000\r\n",
+		"SUMMARY:Отпуск\\, наконец\r\n",
+		"DESCRIPTION:море\\; солнце\r\n",
+		"COLOR:red\r\n",
+		"TRIGGER;RELATED=END:-PT1H\r\n",
+		"TRIGGER;RELATED=END:PT0S\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		require.Contains(t, ics, line)
+	}
+
+	_, err = uc.TimerCalendar(ctx, countdown.ID)
+	require.ErrorIs(t, err, calendarerror.ExceptionTimerPaused())
+
+	_, err = uc.TimerCalendar(ctx, uuid.New())
+	require.ErrorIs(t, err, timererror.ExceptionTimerNotFound())
+}
+
+func TestFeed(t *testing.T) {
+	ctx := context.Background()
+	var userId int64 = 1
+	timers := make([]*timermodel.Timer, 0, 150)
+	for i := 0; i < 150; i++ {
+		timers = append(timers, dateTimer(0, time.Now().Add(time.Hour*time.Duration(i+1))))
+	}
+	uc := calendarusecase.New(&feedStorage{tokens: map[int64]string{}}, &timerStorage{timers: timers}, nil, "https://timer.test/calendar/")
+
+	feed, err := uc.Feed(ctx, userId)
+	require.NoError(t, err)
+	require.Equal(t, "https://timer.test/calendar/"+feed.Token+".ics", feed.URL)
+
+	same, err := uc.Feed(ctx, userId)
+	require.NoError(t, err)
+	require.Equal(t, feed.Token, same.Token)
+
+	b, err := uc.FeedCalendar(ctx, feed.Token)
+	require.NoError(t, err)
+	require.Equal(t, len(timers), strings.Count(string(b), "BEGIN:VEVENT"))
+	require.Contains(t, string(b), "REFRESH-INTERVAL;VALUE=DURATION:PT15M\r\n")
+	for _, line := range strings.Split(string(b), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+
+	reset, err := uc.ResetFeed(ctx, userId)
+	require.NoError(t, err)
+	require.NotEqual(t, feed.Token, reset.Token)
+
+	_, err = uc.FeedCalendar(ctx, feed.Token)
+	require.True(t, errors.Is(err, calendarerror.ExceptionFeedNotFound()))
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	future := now.Add(time.Hour * 48).Format("20060102T150405")
+	past := now.Add(-time.Hour * 48).Format("20060102T150405")
+	birthday := now.AddDate(-10, 0, -1).Format("20060102")
+	longName := strings.Repeat("я", timerfields.NameMaxSize+10)
+
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:future",
+		"DTSTART;TZID=Europe/Moscow:" + future,
+		"SUMMARY:Встреча\\, важная",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:past",
+		"DTSTART:" + past + "Z",
+		"SUMMARY:Прошло",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:birthday",
+		"DTSTART;VALUE=DATE:" + birthday,
+		"RRULE:FREQ=YEARLY;BYMONTH=1",
+		"SUMMARY:" + longName[:40],
+		" " + longName[40:],
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:nostart",
+		"SUMMARY:Без начала",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:fail",
+		"DTSTART:" + future + "Z",
+		"SUMMARY:fail",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	tuc := &timerUseCase{}
+	uc := calendarusecase.New(&feedStorage{}, &timerStorage{}, tuc, "")
+	report, err := uc.Import(ctx, 1, strings.NewReader(ics), time.UTC)
+	require.NoError(t, err)
+	require.Len(t, report, 5)
+
+	results := make(map[string]*calendarmodel.ImportResult)
+	for _, r := range report {
+		results[r.UID] = r
+	}
+
+	require.Equal(t, calendarmodel.Created, results["future"].Status)
+	require.Equal(t, calendarmodel.Skipped, results["past"].Status)
+	require.Equal(t, calendarmodel.ReasonPast, results["past"].Reason)
+	require.Equal(t, calendarmodel.Created, results["birthday"].Status)
+	require.ElementsMatch(t,
+		[]string{calendarmodel.NoteApproximateRule, calendarmodel.NoteNextOccurrence, calendarmodel.NoteAllDay, calendarmodel.NoteNameTruncated},
+		results["birthday"].Notes,
+	)
+	require.Equal(t, calendarmodel.Rejected, results["nostart"].Status)
+	require.Equal(t, calendarmodel.ReasonNoStart, results["nostart"].Reason)
+	require.Equal(t, calendarmodel.Rejected, results["fail"].Status)
+	require.Equal(t, "timer_exists", results["fail"].Reason)
+
+	require.Len(t, tuc.created, 2)
+	meeting := tuc.created[0]
+	require.Equal(t, timerfields.DATE, meeting.Type)
+	require.Equal(t, int16(3), meeting.UTC)
+	require.Equal(t, timerfields.Name("Встреча, важная"), meeting.Name)
+	require.Equal(t, *results["future"].TimerId, meeting.ID)
+
+	birthdayTimer := tuc.created[1]
+	require.Len(t, []rune(string(birthdayTimer.Name)), timerfields.NameMaxSize)
+	require.True(t, birthdayTimer.EndTime.T().After(now))
+	require.True(t, birthdayTimer.EndTime.T().Before(now.AddDate(1, 0, 0)))
+
+	_, err = uc.Import(ctx, 1, strings.NewReader("not a calendar"), time.UTC)
+	require.ErrorIs(t, err, calendarerror.ExceptionWrongCalendar())
+}
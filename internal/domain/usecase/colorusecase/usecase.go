@@ -0,0 +1,130 @@
+package colorusecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/colormodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const _PROVIDER = "internal/domain/usecase/colorusecase"
+
+// first retry of failed load, every next retry is doubled up to ttl
+const minBackoff = time.Second
+
+type ColorStorage interface {
+	Colors(ctx context.Context) ([]*colormodel.Color, error)
+}
+
+// cached palette from colors table, palette is loaded on start and reloaded by Refresh every ttl
+// failed load is retried after backoff, stale palette is kept until next successful load
+type UseCase struct {
+	storage ColorStorage
+	ttl     time.Duration
+
+	mu     sync.RWMutex
+	colors []*colormodel.Color
+	names  map[timerfields.Color]struct{}
+	// error of last failed load, returned until retry if palette isn't loaded
+	err     error
+	backoff time.Duration
+	// time of next load
+	loadAt time.Time
+}
+
+func New(storage ColorStorage, ttl time.Duration) *UseCase {
+	return &UseCase{storage: storage, ttl: ttl}
+}
+
+// load palette before serving requests, failed load is logged and retried by Refresh or by first request
+func (uc *UseCase) Load(ctx context.Context) {
+	uc.load(ctx)
+}
+
+// reload palette every ttl until ctx is done
+func (uc *UseCase) Refresh(ctx context.Context) {
+	for {
+		uc.mu.RLock()
+		wait := time.Until(uc.loadAt)
+		uc.mu.RUnlock()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		uc.load(ctx)
+	}
+}
+
+func (uc *UseCase) Colors(ctx context.Context) ([]*colormodel.Color, error) {
+	colors, _, err := uc.palette(ctx)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get palette", "Colors", _PROVIDER))
+	}
+	return colors, nil
+}
+
+// check color is in palette
+func (uc *UseCase) ValidateColor(ctx context.Context, color timerfields.Color) error {
+	_, names, err := uc.palette(ctx)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("get palette", "ValidateColor", _PROVIDER))
+	}
+	if _, ok := names[color]; !ok {
+		return exception.Wrap(timererror.ExceptionColorNotFound(), exception.NewCause("find color in palette", "ValidateColor", _PROVIDER))
+	}
+	return nil
+}
+
+// cached palette, palette which isn't loaded yet is loaded with request ctx if backoff is passed
+func (uc *UseCase) palette(ctx context.Context) ([]*colormodel.Color, map[timerfields.Color]struct{}, error) {
+	uc.mu.RLock()
+	colors, names, err, loadAt := uc.colors, uc.names, uc.err, uc.loadAt
+	uc.mu.RUnlock()
+	if names != nil {
+		return colors, names, nil
+	}
+	if err != nil && time.Now().Before(loadAt) {
+		return nil, nil, err
+	}
+	err = uc.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.colors, uc.names, nil
+}
+
+// load palette from storage, failed load is logged and next load is delayed by backoff
+func (uc *UseCase) load(ctx context.Context) error {
+	colors, err := uc.storage.Colors(ctx)
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if err != nil {
+		uc.backoff *= 2
+		if uc.backoff < minBackoff {
+			uc.backoff = minBackoff
+		}
+		if uc.backoff > uc.ttl {
+			uc.backoff = uc.ttl
+		}
+		uc.err = exception.Wrap(err, exception.NewCause("get colors from storage", "load", _PROVIDER))
+		uc.loadAt = time.Now().Add(uc.backoff)
+		logging.FromContext(ctx).Error("failed load color palette", "backoff", uc.backoff, "stale", uc.names != nil, logging.Err(err))
+		return uc.err
+	}
+	names := make(map[timerfields.Color]struct{}, len(colors))
+	for _, color := range colors {
+		names[color.Name] = struct{}{}
+	}
+	uc.colors, uc.names, uc.err = colors, names, nil
+	uc.backoff = 0
+	uc.loadAt = time.Now().Add(uc.ttl)
+	return nil
+}
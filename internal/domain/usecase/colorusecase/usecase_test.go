@@ -0,0 +1,98 @@
+package colorusecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/colormodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/stretchr/testify/require"
+)
+
+type colorStorage struct {
+	mu     sync.Mutex
+	colors []*colormodel.Color
+	err    error
+	calls  int
+}
+
+func (s *colorStorage) Colors(ctx context.Context) ([]*colormodel.Color, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.colors, s.err
+}
+
+func (s *colorStorage) set(colors []*colormodel.Color, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.colors, s.err = colors, err
+}
+
+func (s *colorStorage) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestColors(t *testing.T) {
+	ctx := context.Background()
+	storage := &colorStorage{colors: []*colormodel.Color{colormodel.NewColor(timerfields.DEFAULT, "#818C99", "")}}
+	uc := colorusecase.New(storage, time.Minute)
+	uc.Load(ctx)
+
+	colors, err := uc.Colors(ctx)
+	require.NoError(t, err, "get colors failed")
+	require.Equal(t, storage.colors, colors, "wrong colors")
+	require.Equal(t, 1, storage.count(), "colors not cached")
+
+	// stale colors are kept if storage fails
+	storage.set(nil, errors.New("storage failed"))
+	uc.Load(ctx)
+	colors, err = uc.Colors(ctx)
+	require.NoError(t, err, "stale colors not returned")
+	require.Len(t, colors, 1, "wrong stale colors")
+}
+
+func TestColorsBackoff(t *testing.T) {
+	ctx := context.Background()
+	storage := &colorStorage{err: errors.New("storage failed")}
+	uc := colorusecase.New(storage, time.Minute)
+	uc.Load(ctx)
+
+	// failed load isn't retried by requests until backoff is passed
+	_, err := uc.Colors(ctx)
+	require.ErrorIs(t, err, storage.err, "wrong error")
+	err = uc.ValidateColor(ctx, timerfields.DEFAULT)
+	require.ErrorIs(t, err, storage.err, "color validated by empty palette")
+	require.Equal(t, 1, storage.count(), "failed load retried before backoff")
+
+	storage.set([]*colormodel.Color{colormodel.NewColor(timerfields.DEFAULT, "#818C99", "")}, nil)
+	time.Sleep(time.Second)
+	err = uc.ValidateColor(ctx, timerfields.DEFAULT)
+	require.NoError(t, err, "palette not loaded after backoff")
+	require.Equal(t, 2, storage.count(), "palette not loaded by request")
+}
+
+func TestValidateColor(t *testing.T) {
+	ctx := context.Background()
+	storage := &colorStorage{colors: []*colormodel.Color{colormodel.NewColor(timerfields.DEFAULT, "#818C99", "")}}
+	uc := colorusecase.New(storage, time.Millisecond*100)
+	uc.Load(ctx)
+
+	require.NoError(t, uc.ValidateColor(ctx, timerfields.DEFAULT), "palette color not found")
+	require.ErrorIs(t, uc.ValidateColor(ctx, "ORANGE"), timererror.ExceptionColorNotFound(), "unknown color found")
+	require.Equal(t, 1, storage.count(), "request reloaded palette")
+
+	// new color is found after refresh
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go uc.Refresh(ctx)
+	storage.set(append(storage.colors, colormodel.NewColor("ORANGE", "#FF8800", "")), nil)
+	require.Eventually(t, func() bool { return uc.ValidateColor(ctx, "ORANGE") == nil }, time.Second, time.Millisecond*10, "new color not found")
+}
@@ -5,9 +5,11 @@ import (
 	"time"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
 	"github.com/Tap-Team/timerapi/internal/model/timerevent"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/saga"
@@ -16,10 +18,15 @@ import (
 
 const _PROVIDER = "internal/domain/usecase/countdowntimerusecase"
 
+// max absolute shift of adjust, greater shift overflows time.Duration of end time
+const MAX_ADJUST_DELTA = 10 * 365 * 24 * 60 * 60
+
 type TimerUpdater interface {
 	CountdownTimer(ctx context.Context, timerId uuid.UUID) (*timermodel.CountdownTimer, error)
-	UpdateTime(ctx context.Context, timerId uuid.UUID, endTime amidtime.DateTime) error
-	UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool) error
+	UpdateTime(ctx context.Context, timerId uuid.UUID, endTime amidtime.DateTime, version int64) error
+	AdjustTime(ctx context.Context, timerId uuid.UUID, delta int64, version int64) (amidtime.DateTime, int64, error)
+	UpdatePauseTime(ctx context.Context, timerId uuid.UUID, pauseTime amidtime.DateTime, isPaused bool, version int64) error
+	UpdateTimeAndPause(ctx context.Context, timerId uuid.UUID, endTime, pauseTime amidtime.DateTime, isPaused bool, version int64) error
 	TimerPause(ctx context.Context, timerId uuid.UUID) (*timermodel.TimerPause, error)
 }
 
@@ -27,6 +34,8 @@ type EventSender interface {
 	Send(event timerevent.TimerEvent)
 }
 
+// every mutation takes expected timer version, 0 version is any version
+// storage update is the last step of saga, so failed mutation doesn't change version
 type UseCase struct {
 	timerService timerservice.TimerServiceClient
 	updater      TimerUpdater
@@ -45,7 +54,10 @@ func New(
 	}
 }
 
-func (uc *UseCase) Stop(ctx context.Context, timerId uuid.UUID, userId int64, pauseTime int64) error {
+// pause time is server time, clients with skewed clocks can't pause timer into past or future
+func (uc *UseCase) Stop(ctx context.Context, timerId uuid.UUID, userId int64, version int64) error {
+	ctx, span := tracing.Start(ctx, "countdowntimerusecase.Stop")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
@@ -57,35 +69,35 @@ func (uc *UseCase) Stop(ctx context.Context, timerId uuid.UUID, userId int64, pa
 		return exception.Wrap(timererror.ExceptionTimerIsPaused(), exception.NewCause("check timer not paused", "Stop", _PROVIDER))
 	}
 
-	saga := new(saga.Saga)
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "countdowntimerusecase.Stop")
 	defer saga.Rollback()
 
-	ptime := amidtime.DateTime(time.Unix(pauseTime, 0))
+	ptime := amidtime.Now()
 
 	// stop timer in timer service
 	err = uc.timerService.Stop(ctx, timerId)
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("stop in timer service", "Stop", _PROVIDER))
 	}
-	saga.Register(func() {
-		uc.timerService.Start(ctx, timerId, timer.EndTime.Unix())
+	saga.Register(func() error {
+		return uc.timerService.Start(ctx, timerId, timer.EndTime.Unix())
 	})
 
 	// set pause time in storage
-	err = uc.updater.UpdatePauseTime(ctx, timerId, ptime, true)
+	err = uc.updater.UpdatePauseTime(ctx, timerId, ptime, true, version)
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("update pause time in storage", "Stop", _PROVIDER))
 	}
-	saga.Register(func() {
-		uc.updater.UpdatePauseTime(ctx, timerId, amidtime.DateTime{}, false)
-	})
 
 	uc.sender.Send(timerevent.NewStop(timerId, ptime))
 	saga.OK()
 	return nil
 }
 
-func (uc *UseCase) Start(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+func (uc *UseCase) Start(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "countdowntimerusecase.Start")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
@@ -101,28 +113,22 @@ func (uc *UseCase) Start(ctx context.Context, timerId uuid.UUID, userId int64) (
 	// count the time for which the timer was stopped
 	endTime := amidtime.DateTime(timer.EndTime.T().Add(timeInPause))
 
-	saga := new(saga.Saga)
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "countdowntimerusecase.Start")
 	defer saga.Rollback()
 
-	err = uc.updater.UpdateTime(ctx, timerId, endTime)
-	if err != nil {
-		return nil, exception.Wrap(err, exception.NewCause("update end time in storage", "Start", _PROVIDER))
-	}
-	saga.Register(func() { uc.updater.UpdateTime(ctx, timerId, timer.EndTime) })
-
-	// update status in storage
-	err = uc.updater.UpdatePauseTime(ctx, timerId, amidtime.DateTime{}, false)
-	if err != nil {
-		return nil, exception.Wrap(err, exception.NewCause("update pause time in storage", "Start", _PROVIDER))
-	}
-	saga.Register(func() { uc.updater.UpdatePauseTime(ctx, timerId, timer.PauseTime, true) })
-
 	// start timer in timer service
 	err = uc.timerService.Start(ctx, timerId, endTime.Unix())
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("start timer in timer service", "Start", _PROVIDER))
 	}
-	saga.Register(func() { uc.timerService.Stop(ctx, timerId) })
+	saga.Register(func() error { return uc.timerService.Stop(ctx, timerId) })
+
+	// update end time and status in storage
+	err = uc.updater.UpdateTimeAndPause(ctx, timerId, endTime, amidtime.DateTime{}, false, version)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("update end time and pause time in storage", "Start", _PROVIDER))
+	}
 
 	uc.sender.Send(timerevent.NewStart(timerId, endTime))
 	saga.OK()
@@ -138,7 +144,9 @@ func (uc *UseCase) Start(ctx context.Context, timerId uuid.UUID, userId int64) (
 	return t, nil
 }
 
-func (uc *UseCase) Reset(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+func (uc *UseCase) Reset(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "countdowntimerusecase.Reset")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
@@ -149,24 +157,30 @@ func (uc *UseCase) Reset(ctx context.Context, timerId uuid.UUID, userId int64) (
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("check timer", "Reset", _PROVIDER))
 	}
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "countdowntimerusecase.Reset")
 	defer saga.Rollback()
 	// add timer duration to end time
 	oldTimerEndTime := timer.EndTime
 	endTime = amidtime.DateTime(time.Now().Add(time.Second * time.Duration(timer.Duration)))
 
-	// update time in database
-	err = uc.updater.UpdateTime(ctx, timerId, endTime)
-	if err != nil {
-		return nil, exception.Wrap(err, exception.NewCause("update timer time", "Reset", _PROVIDER))
-	}
-	saga.Register(func() { uc.updater.UpdateTime(ctx, timerId, oldTimerEndTime) })
-
 	if timer.IsPaused {
 		pauseTime = amidtime.DateTime(time.Unix(endTime.Unix()-timer.Duration, 0))
-		err = uc.updater.UpdatePauseTime(ctx, timer.ID, pauseTime, true)
+		err = uc.updater.UpdateTimeAndPause(ctx, timer.ID, endTime, pauseTime, true, version)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("update timer time and pause time", "Reset", _PROVIDER))
+		}
+	} else {
+		// running timer expires in timer service by new end time
+		err = uc.timerService.Update(ctx, timerId, endTime.Unix())
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("update end time in timer service", "Reset", _PROVIDER))
+		}
+		saga.Register(func() error { return uc.timerService.Update(ctx, timerId, oldTimerEndTime.Unix()) })
+
+		err = uc.updater.UpdateTime(ctx, timerId, endTime, version)
 		if err != nil {
-			return nil, exception.Wrap(err, exception.NewCause("update timer pause time", "Reset", _PROVIDER))
+			return nil, exception.Wrap(err, exception.NewCause("update timer time", "Reset", _PROVIDER))
 		}
 	}
 	saga.OK()
@@ -179,6 +193,67 @@ func (uc *UseCase) Reset(ctx context.Context, timerId uuid.UUID, userId int64) (
 	return &timer.Timer, nil
 }
 
+// shift end time and duration of running or paused timer by delta seconds
+// time left after shift and duration should be not less than min timer duration
+// timer service and event get end time from storage, so concurrent adjustments don't desync timer service
+func (uc *UseCase) Adjust(ctx context.Context, timerId uuid.UUID, userId int64, delta int64, version int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "countdowntimerusecase.Adjust")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	if delta == 0 || delta > MAX_ADJUST_DELTA || delta < -MAX_ADJUST_DELTA {
+		return nil, exception.Wrap(timererror.ExceptionWrongDelta(), exception.NewCause("check delta", "Adjust", _PROVIDER))
+	}
+	timer, err := uc.checkCountDownTimer(ctx, timerId, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check timer", "Adjust", _PROVIDER))
+	}
+
+	endTime := amidtime.DateTime(timer.EndTime.T().Add(time.Duration(delta) * time.Second))
+	duration := timer.Duration + delta
+	// time left of paused timer is counted from pause time
+	from := time.Now()
+	if timer.IsPaused {
+		from = timer.PauseTime.T()
+	}
+	if endTime.Unix()-from.Unix() < timermodel.MIN_TIMER_DURATION || duration < timermodel.MIN_TIMER_DURATION {
+		return nil, exception.Wrap(timererror.ExceptionWrongTimerTime(), exception.NewCause("check time left", "Adjust", _PROVIDER))
+	}
+
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "countdowntimerusecase.Adjust")
+	defer saga.Rollback()
+
+	// paused timer isn't in timer service
+	if !timer.IsPaused {
+		err = uc.timerService.Update(ctx, timerId, endTime.Unix())
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("update end time in timer service", "Adjust", _PROVIDER))
+		}
+		saga.Register(func() error { return uc.timerService.Update(ctx, timerId, timer.EndTime.Unix()) })
+	}
+
+	computedEndTime := endTime
+	endTime, duration, err = uc.updater.AdjustTime(ctx, timerId, delta, version)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("adjust time in storage", "Adjust", _PROVIDER))
+	}
+	saga.OK()
+	// concurrent adjustment was summed by storage, timer service should get stored end time
+	if !timer.IsPaused && endTime.Unix() != computedEndTime.Unix() {
+		err = uc.timerService.Update(ctx, timerId, endTime.Unix())
+		if err != nil {
+			logging.FromContext(ctx).Error("failed update stored end time in timer service", logging.Err(err))
+		}
+	}
+	uc.sender.Send(timerevent.NewAdjust(timerId, delta, endTime, duration))
+
+	t := &timer.Timer
+	t.EndTime = endTime
+	t.Duration = duration
+	return t, nil
+}
+
 // check timer can be stopped or paused
 func (uc *UseCase) checkCountDownTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.CountdownTimer, error) {
 	timer, err := uc.updater.CountdownTimer(ctx, timerId)
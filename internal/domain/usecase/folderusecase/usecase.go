@@ -0,0 +1,236 @@
+package folderusecase
+
+import (
+	"context"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const _PROVIDER = "internal/domain/usecase/folderusecase"
+
+// max count of folders of one user
+const maxUserFolders = 100
+
+type FolderStorage interface {
+	InsertFolder(ctx context.Context, folder *foldermodel.Folder, maxFolders int) error
+	Folder(ctx context.Context, folderId uuid.UUID) (*foldermodel.Folder, error)
+	UserFolders(ctx context.Context, userId int64) ([]*foldermodel.Folder, error)
+	RenameFolder(ctx context.Context, folderId uuid.UUID, name foldermodel.Name) error
+	DeleteFolder(ctx context.Context, folderId uuid.UUID) error
+	OrderFolders(ctx context.Context, userId int64, folderIds []uuid.UUID) error
+
+	MoveTimer(ctx context.Context, timerId uuid.UUID, userId int64, folderId *uuid.UUID) error
+	PinTimer(ctx context.Context, timerId uuid.UUID, userId int64, isPinned bool) error
+	OrderTimers(ctx context.Context, userId int64, timerIds []uuid.UUID) error
+
+	SetTimerTags(ctx context.Context, timerId uuid.UUID, userId int64, tags []foldermodel.Tag) error
+	UserTags(ctx context.Context, userId int64) ([]*foldermodel.TagInfo, error)
+	RenameTag(ctx context.Context, userId int64, tag foldermodel.Tag, name foldermodel.Tag) error
+	DeleteTag(ctx context.Context, userId int64, tag foldermodel.Tag) error
+}
+
+type TimerStorage interface {
+	UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
+}
+
+type UseCase struct {
+	storage      FolderStorage
+	timerStorage TimerStorage
+}
+
+func New(storage FolderStorage, timerStorage TimerStorage) *UseCase {
+	return &UseCase{storage: storage, timerStorage: timerStorage}
+}
+
+// folders count is checked by storage in insert transaction
+func (uc *UseCase) Create(ctx context.Context, userId int64, settings *foldermodel.FolderSettings) (*foldermodel.Folder, error) {
+	ctx, span := tracing.Start(ctx, "folderusecase.Create")
+	defer span.End()
+	folder := &foldermodel.Folder{
+		ID:     uuid.New(),
+		UserId: userId,
+		Name:   settings.Name,
+	}
+	err := uc.storage.InsertFolder(ctx, folder, maxUserFolders)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("insert folder", "Create", _PROVIDER))
+	}
+	return folder, nil
+}
+
+// user folders in manual order
+func (uc *UseCase) Folders(ctx context.Context, userId int64) ([]*foldermodel.Folder, error) {
+	ctx, span := tracing.Start(ctx, "folderusecase.Folders")
+	defer span.End()
+	folders, err := uc.storage.UserFolders(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user folders", "Folders", _PROVIDER))
+	}
+	return folders, nil
+}
+
+func (uc *UseCase) Rename(ctx context.Context, folderId uuid.UUID, userId int64, settings *foldermodel.FolderSettings) (*foldermodel.Folder, error) {
+	ctx, span := tracing.Start(ctx, "folderusecase.Rename")
+	defer span.End()
+	folder, err := uc.checkAccess(ctx, folderId, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "Rename", _PROVIDER))
+	}
+	err = uc.storage.RenameFolder(ctx, folderId, settings.Name)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("rename folder", "Rename", _PROVIDER))
+	}
+	folder.Name = settings.Name
+	return folder, nil
+}
+
+// delete folder, timers of folder are kept and moved out of folders
+func (uc *UseCase) Delete(ctx context.Context, folderId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.Delete")
+	defer span.End()
+	_, err := uc.checkAccess(ctx, folderId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "Delete", _PROVIDER))
+	}
+	err = uc.storage.DeleteFolder(ctx, folderId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete folder", "Delete", _PROVIDER))
+	}
+	return nil
+}
+
+// set manual order of user folders, folders of other users are skipped
+func (uc *UseCase) Order(ctx context.Context, userId int64, order *foldermodel.Order) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.Order")
+	defer span.End()
+	err := uc.storage.OrderFolders(ctx, userId, order.Ids)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("order folders", "Order", _PROVIDER))
+	}
+	return nil
+}
+
+// move timer into user folder, nil folder moves timer out of folders
+func (uc *UseCase) MoveTimer(ctx context.Context, timerId uuid.UUID, userId int64, folderId *uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.MoveTimer")
+	defer span.End()
+	if folderId != nil {
+		_, err := uc.checkAccess(ctx, *folderId, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("check folder access", "MoveTimer", _PROVIDER))
+		}
+	}
+	err := uc.checkSubscriber(ctx, timerId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check subscriber", "MoveTimer", _PROVIDER))
+	}
+	err = uc.storage.MoveTimer(ctx, timerId, userId, folderId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("move timer", "MoveTimer", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) PinTimer(ctx context.Context, timerId uuid.UUID, userId int64, isPinned bool) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.PinTimer")
+	defer span.End()
+	err := uc.checkSubscriber(ctx, timerId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check subscriber", "PinTimer", _PROVIDER))
+	}
+	err = uc.storage.PinTimer(ctx, timerId, userId, isPinned)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("pin timer", "PinTimer", _PROVIDER))
+	}
+	return nil
+}
+
+// set manual order of timers, timers which user isn't subscribed on are skipped
+func (uc *UseCase) OrderTimers(ctx context.Context, userId int64, order *foldermodel.Order) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.OrderTimers")
+	defer span.End()
+	err := uc.storage.OrderTimers(ctx, userId, order.Ids)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("order timers", "OrderTimers", _PROVIDER))
+	}
+	return nil
+}
+
+// replace user tags of timer, tags are private, subscriber can tag timer of other user
+func (uc *UseCase) SetTimerTags(ctx context.Context, timerId uuid.UUID, userId int64, tags *foldermodel.Tags) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.SetTimerTags")
+	defer span.End()
+	err := uc.checkSubscriber(ctx, timerId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check subscriber", "SetTimerTags", _PROVIDER))
+	}
+	err = uc.storage.SetTimerTags(ctx, timerId, userId, tags.Tags)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("set timer tags", "SetTimerTags", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) Tags(ctx context.Context, userId int64) ([]*foldermodel.TagInfo, error) {
+	ctx, span := tracing.Start(ctx, "folderusecase.Tags")
+	defer span.End()
+	tags, err := uc.storage.UserTags(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user tags", "Tags", _PROVIDER))
+	}
+	return tags, nil
+}
+
+func (uc *UseCase) RenameTag(ctx context.Context, userId int64, tag foldermodel.Tag, rename *foldermodel.RenameTag) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.RenameTag")
+	defer span.End()
+	// renaming to the same name would delete tag
+	if tag == rename.Name {
+		return nil
+	}
+	err := uc.storage.RenameTag(ctx, userId, tag, rename.Name)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("rename tag", "RenameTag", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) DeleteTag(ctx context.Context, userId int64, tag foldermodel.Tag) error {
+	ctx, span := tracing.Start(ctx, "folderusecase.DeleteTag")
+	defer span.End()
+	err := uc.storage.DeleteTag(ctx, userId, tag)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete tag", "DeleteTag", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) checkAccess(ctx context.Context, folderId uuid.UUID, userId int64) (*foldermodel.Folder, error) {
+	folder, err := uc.storage.Folder(ctx, folderId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get folder by id", "checkAccess", _PROVIDER))
+	}
+	if folder.UserId != userId {
+		return nil, exception.Wrap(foldererror.ExceptionUserForbidden(), exception.NewCause("compare owner and userId", "checkAccess", _PROVIDER))
+	}
+	return folder, nil
+}
+
+// only subscribers (creator inclusive) organize timer
+func (uc *UseCase) checkSubscriber(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	timer, err := uc.timerStorage.UserTimer(ctx, timerId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("get user timer", "checkSubscriber", _PROVIDER))
+	}
+	if timer.Relation == timerfields.NONE {
+		return exception.Wrap(timererror.ExceptionUserForbidden(), exception.NewCause("check relation", "checkSubscriber", _PROVIDER))
+	}
+	return nil
+}
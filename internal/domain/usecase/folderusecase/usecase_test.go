@@ -0,0 +1,124 @@
+package folderusecase_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/folderusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFoldersLimit(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := folderusecase.NewMockFolderStorage(ctrl)
+
+	userId := rand.Int63()
+	storage.EXPECT().InsertFolder(gomock.Any(), gomock.Any(), 100).Return(foldererror.ExceptionTooManyFolders()).Times(1)
+
+	usecase := folderusecase.New(storage, nil)
+	_, err := usecase.Create(ctx, userId, &foldermodel.FolderSettings{Name: "folder"})
+	require.ErrorIs(t, err, foldererror.ExceptionTooManyFolders(), "wrong create error")
+}
+
+func TestFolderAccess(t *testing.T) {
+	ctx := context.Background()
+	userId := rand.Int63()
+	folder := &foldermodel.Folder{ID: uuid.New(), UserId: userId, Name: "folder"}
+
+	cases := []struct {
+		name   string
+		userId int64
+		err    error
+	}{
+		{name: "owner", userId: userId},
+		{name: "other user", userId: rand.Int63(), err: foldererror.ExceptionUserForbidden()},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := folderusecase.NewMockFolderStorage(ctrl)
+			storage.EXPECT().Folder(gomock.Any(), folder.ID).Return(folder, nil).Times(3)
+			// storage is changed only by owner
+			times := 0
+			if cs.err == nil {
+				times = 1
+			}
+			storage.EXPECT().RenameFolder(gomock.Any(), folder.ID, foldermodel.Name("renamed")).Return(nil).Times(times)
+			storage.EXPECT().DeleteFolder(gomock.Any(), folder.ID).Return(nil).Times(times)
+			timerStorage := folderusecase.NewMockTimerStorage(ctrl)
+			timerStorage.EXPECT().UserTimer(gomock.Any(), gomock.Any(), cs.userId).Return(&timermodel.Timer{Relation: timerfields.SUBSCRIBER}, nil).Times(times)
+			storage.EXPECT().MoveTimer(gomock.Any(), gomock.Any(), cs.userId, &folder.ID).Return(nil).Times(times)
+
+			usecase := folderusecase.New(storage, timerStorage)
+			_, err := usecase.Rename(ctx, folder.ID, cs.userId, &foldermodel.FolderSettings{Name: "renamed"})
+			require.ErrorIs(t, err, cs.err, "wrong rename error")
+			err = usecase.Delete(ctx, folder.ID, cs.userId)
+			require.ErrorIs(t, err, cs.err, "wrong delete error")
+			err = usecase.MoveTimer(ctx, uuid.New(), cs.userId, &folder.ID)
+			require.ErrorIs(t, err, cs.err, "wrong move timer error")
+		})
+	}
+
+	ctrl := gomock.NewController(t)
+	storage := folderusecase.NewMockFolderStorage(ctrl)
+	storage.EXPECT().Folder(gomock.Any(), gomock.Any()).Return(nil, foldererror.ExceptionFolderNotFound()).Times(1)
+	usecase := folderusecase.New(storage, nil)
+	err := usecase.Delete(ctx, uuid.New(), userId)
+	require.ErrorIs(t, err, foldererror.ExceptionFolderNotFound(), "wrong delete of not existing folder error")
+}
+
+func TestTimerSubscriber(t *testing.T) {
+	ctx := context.Background()
+	userId := rand.Int63()
+	timerId := uuid.New()
+	tags := []foldermodel.Tag{"work"}
+
+	cases := []struct {
+		name     string
+		relation timerfields.Relation
+		timerErr error
+		err      error
+	}{
+		{name: "owner", relation: timerfields.OWNER},
+		{name: "subscriber", relation: timerfields.SUBSCRIBER},
+		{name: "not subscriber", relation: timerfields.NONE, err: timererror.ExceptionUserForbidden()},
+		{name: "not existing timer", timerErr: timererror.ExceptionTimerNotFound(), err: timererror.ExceptionTimerNotFound()},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			timerStorage := folderusecase.NewMockTimerStorage(ctrl)
+			var timer *timermodel.Timer
+			if cs.timerErr == nil {
+				timer = &timermodel.Timer{ID: timerId, Relation: cs.relation}
+			}
+			timerStorage.EXPECT().UserTimer(gomock.Any(), timerId, userId).Return(timer, cs.timerErr).Times(3)
+			// storage is changed only by subscribers
+			times := 0
+			if cs.err == nil {
+				times = 1
+			}
+			storage := folderusecase.NewMockFolderStorage(ctrl)
+			storage.EXPECT().MoveTimer(gomock.Any(), timerId, userId, nil).Return(nil).Times(times)
+			storage.EXPECT().PinTimer(gomock.Any(), timerId, userId, true).Return(nil).Times(times)
+			storage.EXPECT().SetTimerTags(gomock.Any(), timerId, userId, tags).Return(nil).Times(times)
+
+			usecase := folderusecase.New(storage, timerStorage)
+			err := usecase.MoveTimer(ctx, timerId, userId, nil)
+			require.ErrorIs(t, err, cs.err, "wrong move timer error")
+			err = usecase.PinTimer(ctx, timerId, userId, true)
+			require.ErrorIs(t, err, cs.err, "wrong pin timer error")
+			err = usecase.SetTimerTags(ctx, timerId, userId, &foldermodel.Tags{Tags: tags})
+			require.ErrorIs(t, err, cs.err, "wrong set tags error")
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package healthusecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/healthmodel"
+)
+
+// check of dependency, info is shown in readiness even if dependency is down
+type Checker interface {
+	Check(ctx context.Context) (info string, err error)
+}
+
+type CheckerFunc func(ctx context.Context) (string, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type UseCase struct {
+	mu       sync.Mutex
+	checkers map[string]Checker
+	// timeout of every check
+	timeout time.Duration
+}
+
+func New(timeout time.Duration) *UseCase {
+	return &UseCase{checkers: make(map[string]Checker), timeout: timeout}
+}
+
+// register checker of dependency, should be called before serving requests
+func (uc *UseCase) Register(name string, checker Checker) {
+	uc.mu.Lock()
+	uc.checkers[name] = checker
+	uc.mu.Unlock()
+}
+
+// checks are run concurrently, slow dependency is down after timeout
+func (uc *UseCase) Ready(ctx context.Context) *healthmodel.Readiness {
+	uc.mu.Lock()
+	checkers := make(map[string]Checker, len(uc.checkers))
+	for name, checker := range uc.checkers {
+		checkers[name] = checker
+	}
+	uc.mu.Unlock()
+	readiness := &healthmodel.Readiness{
+		Status:       healthmodel.UP,
+		Dependencies: make(map[string]*healthmodel.Dependency, len(checkers)),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker Checker) {
+			defer wg.Done()
+			dependency := uc.check(ctx, checker)
+			mu.Lock()
+			readiness.Dependencies[name] = dependency
+			if dependency.Status != healthmodel.UP {
+				readiness.Status = healthmodel.DOWN
+			}
+			mu.Unlock()
+		}(name, checker)
+	}
+	wg.Wait()
+	return readiness
+}
+
+func (uc *UseCase) check(ctx context.Context, checker Checker) *healthmodel.Dependency {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	start := time.Now()
+	type result struct {
+		info string
+		err  error
+	}
+	// checker which ignores ctx can't block readiness
+	ch := make(chan result, 1)
+	go func() {
+		info, err := checker.Check(ctx)
+		ch <- result{info, err}
+	}()
+	dependency := &healthmodel.Dependency{Status: healthmodel.UP}
+	select {
+	case res := <-ch:
+		dependency.Info = res.info
+		if res.err != nil {
+			dependency.Status, dependency.Error = healthmodel.DOWN, res.err.Error()
+		}
+	case <-ctx.Done():
+		dependency.Status, dependency.Error = healthmodel.DOWN, ctx.Err().Error()
+	}
+	dependency.Latency = time.Since(start).Milliseconds()
+	return dependency
+}
@@ -0,0 +1,57 @@
+package healthusecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/healthusecase"
+	"github.com/Tap-Team/timerapi/internal/model/healthmodel"
+	"github.com/stretchr/testify/require"
+)
+
+func up(info string) healthusecase.CheckerFunc {
+	return func(ctx context.Context) (string, error) { return info, nil }
+}
+
+func TestReady(t *testing.T) {
+	ctx := context.Background()
+	uc := healthusecase.New(time.Millisecond * 100)
+
+	readiness := uc.Ready(ctx)
+	require.Equal(t, healthmodel.UP, readiness.Status, "readiness without dependencies is down")
+
+	uc.Register("postgres", up("pool 1/10"))
+	uc.Register("redis", up(""))
+	readiness = uc.Ready(ctx)
+	require.Equal(t, healthmodel.UP, readiness.Status, "wrong status")
+	require.Len(t, readiness.Dependencies, 2, "wrong dependencies")
+	require.Equal(t, "pool 1/10", readiness.Dependencies["postgres"].Info, "info not returned")
+
+	// failed dependency
+	uc.Register("ticker", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		return "TRANSIENT_FAILURE", errors.New("ticker is not connected")
+	}))
+	readiness = uc.Ready(ctx)
+	require.Equal(t, healthmodel.DOWN, readiness.Status, "failed dependency not counted")
+	ticker := readiness.Dependencies["ticker"]
+	require.Equal(t, healthmodel.DOWN, ticker.Status, "wrong dependency status")
+	require.Equal(t, "TRANSIENT_FAILURE", ticker.Info, "info of down dependency not returned")
+	require.Equal(t, "ticker is not connected", ticker.Error, "error not returned")
+	require.Equal(t, healthmodel.UP, readiness.Dependencies["redis"].Status, "up dependency is down")
+}
+
+func TestReadyTimeout(t *testing.T) {
+	uc := healthusecase.New(time.Millisecond * 50)
+	// checker ignores ctx
+	uc.Register("stream", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) {
+		time.Sleep(time.Second)
+		return "", nil
+	}))
+	start := time.Now()
+	readiness := uc.Ready(context.Background())
+	require.Less(t, time.Since(start), time.Second/2, "slow checker blocked readiness")
+	require.Equal(t, healthmodel.DOWN, readiness.Status, "slow dependency is up")
+	require.Equal(t, context.DeadlineExceeded.Error(), readiness.Dependencies["stream"].Error, "wrong error")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 )
 
@@ -23,6 +24,8 @@ func New(nstorage NotificationStorage) *UseCase {
 }
 
 func (uc *UseCase) Delete(ctx context.Context, userId int64) error {
+	ctx, span := tracing.Start(ctx, "notificationusecase.Delete")
+	defer span.End()
 	err := uc.nstorage.DeleteUserNotifications(ctx, userId)
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("delete user notifications", "Delete", _PROVIDER))
@@ -31,6 +34,8 @@ func (uc *UseCase) Delete(ctx context.Context, userId int64) error {
 }
 
 func (uc *UseCase) Notifications(ctx context.Context, userId int64) ([]*notification.NotificationDTO, error) {
+	ctx, span := tracing.Start(ctx, "notificationusecase.Notifications")
+	defer span.End()
 	notifications, err := uc.nstorage.UserNotifications(ctx, userId)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get user notifications", "Notifications", _PROVIDER))
@@ -0,0 +1,42 @@
+package soundusecase
+
+import (
+	"context"
+
+	"github.com/Tap-Team/timerapi/internal/model/soundmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const _PROVIDER = "internal/domain/usecase/soundusecase"
+
+type SoundStorage interface {
+	Sounds(ctx context.Context) ([]*soundmodel.Sound, error)
+	SetSubscriberSound(ctx context.Context, timerId uuid.UUID, userId int64, sound *timermodel.SubscriberSound) error
+}
+
+type UseCase struct {
+	storage SoundStorage
+}
+
+func New(storage SoundStorage) *UseCase {
+	return &UseCase{storage: storage}
+}
+
+func (uc *UseCase) Sounds(ctx context.Context) ([]*soundmodel.Sound, error) {
+	sounds, err := uc.storage.Sounds(ctx)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get sounds", "Sounds", _PROVIDER))
+	}
+	return sounds, nil
+}
+
+// sound is private for subscriber (creator inclusive), nil sound returns subscriber to timer sound
+func (uc *UseCase) SetSubscriberSound(ctx context.Context, timerId uuid.UUID, userId int64, sound *timermodel.SubscriberSound) error {
+	err := uc.storage.SetSubscriberSound(ctx, timerId, userId, sound)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("set subscriber sound", "SetSubscriberSound", _PROVIDER))
+	}
+	return nil
+}
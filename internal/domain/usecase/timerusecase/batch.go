@@ -0,0 +1,306 @@
+package timerusecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/saga"
+	"github.com/google/uuid"
+)
+
+// error code of failed batch item
+func batchCode(err error) string {
+	var e exception.CodeTypedError
+	if errors.As(err, &e) {
+		return exception.MakeCode(e)
+	}
+	return "common_internal"
+}
+
+// indexes of items passed checks, they are sent to storage
+// if batch is atomic and any item failed, other items are aborted and nil is returned
+func pending(errs []error, atomic bool) []int {
+	indexes := make([]int, 0, len(errs))
+	for _, err := range errs {
+		if err != nil && atomic {
+			abort(errs)
+			return nil
+		}
+	}
+	for i, err := range errs {
+		if err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func abort(errs []error) {
+	for i := range errs {
+		if errs[i] == nil {
+			errs[i] = timererror.ExceptionBatchAborted()
+		}
+	}
+}
+
+// set storage errors of pending items
+func merge(errs []error, indexes []int, storageErrs []error) {
+	for j, i := range indexes {
+		errs[i] = storageErrs[j]
+	}
+}
+
+func (uc *UseCase) BatchCreate(ctx context.Context, creator int64, batch *timermodel.BatchCreate) (*timermodel.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.BatchCreate")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	ctx = logging.With(ctx, "user_id", creator)
+	saga := saga.NewContext(ctx, "timerusecase.BatchCreate")
+	defer saga.Rollback()
+
+	errs := make([]error, len(batch.Timers))
+	for i, timer := range batch.Timers {
+		errs[i] = timer.Validate()
+		if errs[i] == nil {
+			errs[i] = uc.colorUseCase.ValidateColor(ctx, timer.Color)
+		}
+	}
+	indexes := pending(errs, batch.Atomic)
+	if len(indexes) > 0 {
+		timers := make([]*timermodel.CreateTimer, 0, len(indexes))
+		for _, i := range indexes {
+			timers = append(timers, batch.Timers[i])
+		}
+		// create timers into storage in one transaction, timers over quota get quota error
+		storageErrs, err := uc.timerStorage.InsertTimers(ctx, creator, timers, uc.quota.MaxTimers, batch.Atomic)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("insert timers into storage", "BatchCreate", _PROVIDER))
+		}
+		merge(errs, indexes, storageErrs)
+	}
+
+	created := make([]uuid.UUID, 0, len(indexes))
+	endTimes := make(map[uuid.UUID]int64, len(indexes))
+	for i, timer := range batch.Timers {
+		if errs[i] == nil {
+			created = append(created, timer.ID)
+			endTimes[timer.ID] = timer.EndTime.Unix()
+		}
+	}
+	if len(created) > 0 {
+		saga.Register(func() error {
+			errs, err := uc.timerStorage.DeleteTimers(ctx, created, false)
+			if err != nil {
+				return err
+			}
+			return errors.Join(errs...)
+		})
+		// subscribe creator to own timers in subscriberStorage
+		for _, id := range created {
+			err := uc.subscriberStorage.Subscribe(ctx, id, creator)
+			if err != nil {
+				return nil, exception.Wrap(err, exception.NewCause("subscribe creator to own timer", "BatchCreate", _PROVIDER))
+			}
+			id := id
+			saga.Register(func() error {
+				return uc.subscriberStorage.DeleteTimer(ctx, id)
+			})
+		}
+		// add end time of all created timers in timer service by one call
+		err := uc.timerService.AddMany(ctx, endTimes)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("add timers end time to timerService", "BatchCreate", _PROVIDER))
+		}
+	}
+
+	result := timermodel.NewBatchResult(batch.Atomic, len(batch.Timers))
+	for i, timer := range batch.Timers {
+		result.Add(batchItem(timer.ID, 0, errs[i]))
+	}
+	saga.OK()
+	return result, nil
+}
+
+func (uc *UseCase) BatchDelete(ctx context.Context, userId int64, batch *timermodel.BatchDelete) (*timermodel.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.BatchDelete")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	errs := make([]error, len(batch.TimerIds))
+	timers := make([]*timermodel.Timer, len(batch.TimerIds))
+	for i, timerId := range batch.TimerIds {
+		timers[i], errs[i] = uc.checkAccess(ctx, userId, timerId)
+	}
+	indexes := pending(errs, batch.Atomic)
+	if len(indexes) > 0 {
+		ids := make([]uuid.UUID, 0, len(indexes))
+		for _, i := range indexes {
+			ids = append(ids, batch.TimerIds[i])
+		}
+		// delete timers from storage in one transaction
+		storageErrs, err := uc.timerStorage.DeleteTimers(ctx, ids, batch.Atomic)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("delete timers from storage", "BatchDelete", _PROVIDER))
+		}
+		merge(errs, indexes, storageErrs)
+	}
+
+	result := timermodel.NewBatchResult(batch.Atomic, len(batch.TimerIds))
+	for i, timerId := range batch.TimerIds {
+		if errs[i] == nil {
+			// delete timer from service if not paused and send delete event to event handler
+			if !timers[i].IsPaused {
+				uc.timerService.Remove(ctx, timerId)
+			}
+			uc.nsender.Send(notification.NewDelete(*timers[i]))
+		}
+		result.Add(batchItem(timerId, 0, errs[i]))
+	}
+	return result, nil
+}
+
+// check timer owner and users, creator can't be subscribed or unsubscribed from own timer
+func (uc *UseCase) batchSubscribersCheck(ctx context.Context, userId int64, batch *timermodel.BatchSubscribers, creatorErr func() exception.Exception) ([]error, error) {
+	timer, err := uc.checkAccess(ctx, userId, batch.TimerId)
+	if err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(batch.UserIds))
+	for i, id := range batch.UserIds {
+		if id == timer.Creator {
+			errs[i] = creatorErr()
+		}
+	}
+	return errs, nil
+}
+
+func (uc *UseCase) BatchSubscribe(ctx context.Context, userId int64, batch *timermodel.BatchSubscribers) (*timermodel.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.BatchSubscribe")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	ctx = logging.With(ctx, "timer_id", batch.TimerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.BatchSubscribe")
+	defer saga.Rollback()
+
+	errs, err := uc.batchSubscribersCheck(ctx, userId, batch, timererror.ExceptionUserAlreadySubscriber)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "BatchSubscribe", _PROVIDER))
+	}
+	users := make([]int64, 0, len(errs))
+	if indexes := pending(errs, batch.Atomic); len(indexes) > 0 {
+		for _, i := range indexes {
+			users = append(users, batch.UserIds[i])
+		}
+		// subscribe users in timer storage in one transaction, bans, subscribers limit and quotas are checked for every user
+		storageErrs, err := uc.timerStorage.SubscribeAllowedUsers(ctx, batch.TimerId, users, uc.quota, batch.Atomic)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("subscribe users in timer storage", "BatchSubscribe", _PROVIDER))
+		}
+		merge(errs, indexes, storageErrs)
+	}
+
+	subscribed := make([]int64, 0, len(users))
+	for i, id := range batch.UserIds {
+		if errs[i] == nil {
+			subscribed = append(subscribed, id)
+		}
+	}
+	if len(subscribed) > 0 {
+		saga.Register(func() error {
+			errs, err := uc.timerStorage.UnsubscribeUsers(ctx, batch.TimerId, subscribed, false)
+			if err != nil {
+				return err
+			}
+			return errors.Join(errs...)
+		})
+		// subscribe users in subscriber cache storage
+		err = uc.subscriberStorage.Subscribe(ctx, batch.TimerId, subscribed...)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("subscribe users in cache storage", "BatchSubscribe", _PROVIDER))
+		}
+	}
+
+	result := timermodel.NewBatchResult(batch.Atomic, len(batch.UserIds))
+	for i, id := range batch.UserIds {
+		result.Add(batchItem(batch.TimerId, id, errs[i]))
+	}
+	saga.OK()
+	return result, nil
+}
+
+func (uc *UseCase) BatchUnsubscribe(ctx context.Context, userId int64, batch *timermodel.BatchSubscribers) (*timermodel.BatchResult, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.BatchUnsubscribe")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	ctx = logging.With(ctx, "timer_id", batch.TimerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.BatchUnsubscribe")
+	defer saga.Rollback()
+
+	errs, err := uc.batchSubscribersCheck(ctx, userId, batch, timererror.ExceptionCreatorUnsubscribe)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "BatchUnsubscribe", _PROVIDER))
+	}
+	users := make([]int64, 0, len(errs))
+	if indexes := pending(errs, batch.Atomic); len(indexes) > 0 {
+		for _, i := range indexes {
+			users = append(users, batch.UserIds[i])
+		}
+		// unsubscribe users in timer storage in one transaction
+		storageErrs, err := uc.timerStorage.UnsubscribeUsers(ctx, batch.TimerId, users, batch.Atomic)
+		if err != nil {
+			return nil, exception.Wrap(err, exception.NewCause("unsubscribe users in timer storage", "BatchUnsubscribe", _PROVIDER))
+		}
+		merge(errs, indexes, storageErrs)
+	}
+
+	unsubscribed := make([]int64, 0, len(users))
+	for i, id := range batch.UserIds {
+		if errs[i] == nil {
+			unsubscribed = append(unsubscribed, id)
+		}
+	}
+	if len(unsubscribed) > 0 {
+		saga.Register(func() error {
+			errs, err := uc.timerStorage.SubscribeUsers(ctx, batch.TimerId, unsubscribed, false)
+			if err != nil {
+				return err
+			}
+			return errors.Join(errs...)
+		})
+		// unsubscribe users in subscriber cache storage
+		for _, id := range unsubscribed {
+			err = uc.subscriberStorage.Unsubscribe(ctx, batch.TimerId, id)
+			if err != nil {
+				return nil, exception.Wrap(err, exception.NewCause("unsubscribe user in cache storage", "BatchUnsubscribe", _PROVIDER))
+			}
+			id := id
+			saga.Register(func() error { return uc.subscriberStorage.Subscribe(ctx, batch.TimerId, id) })
+		}
+	}
+
+	result := timermodel.NewBatchResult(batch.Atomic, len(batch.UserIds))
+	for i, id := range batch.UserIds {
+		result.Add(batchItem(batch.TimerId, id, errs[i]))
+	}
+	saga.OK()
+	return result, nil
+}
+
+func batchItem(timerId uuid.UUID, userId int64, err error) *timermodel.BatchItemResult {
+	item := &timermodel.BatchItemResult{TimerId: timerId, UserId: userId, Ok: err == nil}
+	if err != nil {
+		item.Code = batchCode(err)
+	}
+	return item
+}
@@ -0,0 +1,139 @@
+package timerusecase_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCreate(t *testing.T) {
+	ctx := context.Background()
+	userId := rand.Int63()
+
+	cases := []struct {
+		name      string
+		atomic    bool
+		invalid   int
+		succeeded int
+	}{
+		{name: "best effort", atomic: false, invalid: 1, succeeded: 2},
+		{name: "atomic", atomic: true, invalid: 1, succeeded: 0},
+		{name: "all valid", atomic: true, invalid: -1, succeeded: 3},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := timerusecase.NewMockTimerStorage(ctrl)
+			cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+			service := timerservice.NewMockTimerServiceClient(ctrl)
+
+			batch := &timermodel.BatchCreate{Atomic: cs.atomic}
+			for i := 0; i < 3; i++ {
+				timer := randomTimer().CreateTimer()
+				if i == cs.invalid {
+					timer.ID = uuid.Nil
+				}
+				batch.Timers = append(batch.Timers, timer)
+			}
+			if cs.succeeded > 0 {
+				storage.EXPECT().InsertTimers(gomock.Any(), userId, gomock.Len(cs.succeeded), 0, cs.atomic).Return(make([]error, cs.succeeded), nil).Times(1)
+				cache.EXPECT().Subscribe(gomock.Any(), gomock.Any(), userId).Return(nil).Times(cs.succeeded)
+				service.EXPECT().AddMany(gomock.Any(), gomock.Len(cs.succeeded)).Return(nil).Times(1)
+			}
+
+			usecase := timerusecase.New(storage, cache, service, anyColor(ctrl), esender, nsender)
+			result, err := usecase.BatchCreate(ctx, userId, batch)
+			require.NoError(t, err, "batch create failed")
+			require.Equal(t, cs.succeeded, result.Succeeded, "wrong succeeded count")
+			require.Equal(t, len(batch.Timers)-cs.succeeded, result.Failed, "wrong failed count")
+			for i, item := range result.Items {
+				require.Equal(t, batch.Timers[i].ID, item.TimerId, "wrong items order")
+				if cs.atomic && !item.Ok && i != cs.invalid {
+					require.Equal(t, "timer_batch_aborted", item.Code, "wrong aborted item code")
+				}
+			}
+		})
+	}
+}
+
+func TestBatchCreateColorNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := timerusecase.NewMockTimerStorage(ctrl)
+	cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+	service := timerservice.NewMockTimerServiceClient(ctrl)
+	colors := timerusecase.NewMockColorUseCase(ctrl)
+
+	userId := rand.Int63()
+	known, unknown := randomTimer().CreateTimer(), randomTimer().CreateTimer()
+	unknown.Color = "ORANGE"
+	colors.EXPECT().ValidateColor(gomock.Any(), known.Color).Return(nil).Times(1)
+	colors.EXPECT().ValidateColor(gomock.Any(), unknown.Color).Return(timererror.ExceptionColorNotFound()).Times(1)
+	storage.EXPECT().InsertTimers(gomock.Any(), userId, []*timermodel.CreateTimer{known}, 0, false).Return([]error{nil}, nil).Times(1)
+	cache.EXPECT().Subscribe(gomock.Any(), known.ID, userId).Return(nil).Times(1)
+	service.EXPECT().AddMany(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	usecase := timerusecase.New(storage, cache, service, colors, esender, nsender)
+	result, err := usecase.BatchCreate(ctx, userId, &timermodel.BatchCreate{Timers: []*timermodel.CreateTimer{known, unknown}})
+	require.NoError(t, err, "batch create failed")
+	require.True(t, result.Items[0].Ok, "timer with palette color not created")
+	require.False(t, result.Items[1].Ok, "timer with unknown color created")
+	require.Equal(t, "timer_"+timererror.ExceptionColorNotFound().Code(), result.Items[1].Code, "wrong color code")
+}
+
+func TestBatchUnsubscribeCreator(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := timerusecase.NewMockTimerStorage(ctrl)
+	cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+
+	timer := randomTimer()
+	userId := rand.Int63()
+	storage.EXPECT().Timer(gomock.Any(), timer.ID).Return(timer, nil).Times(1)
+	storage.EXPECT().UnsubscribeUsers(gomock.Any(), timer.ID, []int64{userId}, false).Return([]error{nil}, nil).Times(1)
+	cache.EXPECT().Unsubscribe(gomock.Any(), timer.ID, userId).Return(nil).Times(1)
+
+	usecase := timerusecase.New(storage, cache, nil, nil, esender, nsender)
+	result, err := usecase.BatchUnsubscribe(ctx, timer.Creator, &timermodel.BatchSubscribers{
+		TimerId: timer.ID,
+		UserIds: []int64{timer.Creator, userId},
+	})
+	require.NoError(t, err, "batch unsubscribe failed")
+	require.False(t, result.Items[0].Ok, "creator unsubscribed")
+	require.Equal(t, "timer_"+timererror.ExceptionCreatorUnsubscribe().Code(), result.Items[0].Code, "wrong creator code")
+	require.True(t, result.Items[1].Ok, "user not unsubscribed")
+}
+
+func TestBatchSubscribeRejected(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := timerusecase.NewMockTimerStorage(ctrl)
+	cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+
+	timer := randomTimer()
+	allowed, banned := rand.Int63(), rand.Int63()
+	quota := timermodel.Quota{MaxSubscriptions: 5}
+	storage.EXPECT().Timer(gomock.Any(), timer.ID).Return(timer, nil).Times(1)
+	storage.EXPECT().SubscribeAllowedUsers(gomock.Any(), timer.ID, []int64{allowed, banned}, quota, false).
+		Return([]error{nil, timererror.ExceptionUserBanned()}, nil).Times(1)
+	cache.EXPECT().Subscribe(gomock.Any(), timer.ID, allowed).Return(nil).Times(1)
+
+	usecase := timerusecase.New(storage, cache, nil, nil, esender, nsender)
+	usecase.SetQuota(quota)
+	result, err := usecase.BatchSubscribe(ctx, timer.Creator, &timermodel.BatchSubscribers{
+		TimerId: timer.ID,
+		UserIds: []int64{allowed, banned},
+	})
+	require.NoError(t, err, "batch subscribe failed")
+	require.True(t, result.Items[0].Ok, "allowed user not subscribed")
+	require.False(t, result.Items[1].Ok, "banned user subscribed")
+	require.Equal(t, "timer_"+timererror.ExceptionUserBanned().Code(), result.Items[1].Code, "wrong banned code")
+}
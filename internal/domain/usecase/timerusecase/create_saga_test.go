@@ -55,22 +55,22 @@ func TestCreateFailedTimerStorageInsert(t *testing.T) {
 	switch timer.Type {
 	case timerfields.COUNTDOWN:
 		insertFailedTimerStorage.EXPECT().
-			InsertCountdownTimer(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-			func(context.Context, int64, *timermodel.CreateTimer) error {
+			InsertCountdownTimer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, int64, *timermodel.CreateTimer, int) error {
 				sleepRandom(stime)
 				return expectedErr
 			}).Times(1)
 	case timerfields.DATE:
 		insertFailedTimerStorage.EXPECT().
-			InsertDateTimer(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-			func(context.Context, int64, *timermodel.CreateTimer) error {
+			InsertDateTimer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, int64, *timermodel.CreateTimer, int) error {
 				sleepRandom(stime)
 				return expectedErr
 			}).Times(1)
 	}
 
 	// test
-	usecase = timerusecase.New(insertFailedTimerStorage, subscriberStorage, timerService, esender, nsender)
+	usecase = timerusecase.New(insertFailedTimerStorage, subscriberStorage, timerService, anyColor(ctrl), esender, nsender)
 
 	err = usecase.Create(ctx, userId, timer.CreateTimer())
 	require.ErrorIs(t, err, expectedErr, "wrong error")
@@ -101,7 +101,7 @@ func TestCreateFailedSubscribeInCache(t *testing.T) {
 		},
 	).Times(1)
 
-	usecase = timerusecase.New(timerStorage, subscribeFailedCacheStorage, timerService, esender, nsender)
+	usecase = timerusecase.New(timerStorage, subscribeFailedCacheStorage, timerService, anyColor(ctrl), esender, nsender)
 
 	err = usecase.Create(ctx, userId, timer.CreateTimer())
 	require.ErrorIs(t, err, expectedErr, "wrong error from create")
@@ -131,7 +131,7 @@ func TestAddToServiceFailed(t *testing.T) {
 		},
 	).Times(1)
 
-	usecase = timerusecase.New(timerStorage, subscriberStorage, failedAddTimerService, esender, nsender)
+	usecase = timerusecase.New(timerStorage, subscriberStorage, failedAddTimerService, anyColor(ctrl), esender, nsender)
 
 	err = usecase.Create(ctx, userId, timer.CreateTimer())
 	require.ErrorIs(t, err, expectedErr, "wrong error from create")
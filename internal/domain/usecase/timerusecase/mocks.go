@@ -11,6 +11,8 @@ import (
 	notification "github.com/Tap-Team/timerapi/internal/model/notification"
 	timerevent "github.com/Tap-Team/timerapi/internal/model/timerevent"
 	timermodel "github.com/Tap-Team/timerapi/internal/model/timermodel"
+	timerfields "github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	amidtime "github.com/Tap-Team/timerapi/pkg/amidtime"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 )
@@ -38,6 +40,20 @@ func (m *MockTimerStorage) EXPECT() *MockTimerStorageMockRecorder {
 	return m.recorder
 }
 
+// Ban mocks base method.
+func (m *MockTimerStorage) Ban(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ban", ctx, timerId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ban indicates an expected call of Ban.
+func (mr *MockTimerStorageMockRecorder) Ban(ctx, timerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockTimerStorage)(nil).Ban), ctx, timerId, userId)
+}
+
 // DeleteTimer mocks base method.
 func (m *MockTimerStorage) DeleteTimer(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -52,32 +68,134 @@ func (mr *MockTimerStorageMockRecorder) DeleteTimer(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimer", reflect.TypeOf((*MockTimerStorage)(nil).DeleteTimer), ctx, id)
 }
 
+// DeleteTimers mocks base method.
+func (m *MockTimerStorage) DeleteTimers(ctx context.Context, timerIds []uuid.UUID, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimers", ctx, timerIds, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTimers indicates an expected call of DeleteTimers.
+func (mr *MockTimerStorageMockRecorder) DeleteTimers(ctx, timerIds, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimers", reflect.TypeOf((*MockTimerStorage)(nil).DeleteTimers), ctx, timerIds, atomic)
+}
+
+// FilteredTimers mocks base method.
+func (m *MockTimerStorage) FilteredTimers(ctx context.Context, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilteredTimers", ctx, userId, filter, offset, limit)
+	ret0, _ := ret[0].([]*timermodel.Timer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilteredTimers indicates an expected call of FilteredTimers.
+func (mr *MockTimerStorageMockRecorder) FilteredTimers(ctx, userId, filter, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilteredTimers", reflect.TypeOf((*MockTimerStorage)(nil).FilteredTimers), ctx, userId, filter, offset, limit)
+}
+
 // InsertCountdownTimer mocks base method.
-func (m *MockTimerStorage) InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+func (m *MockTimerStorage) InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertCountdownTimer", ctx, creator, timer)
+	ret := m.ctrl.Call(m, "InsertCountdownTimer", ctx, creator, timer, maxTimers)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InsertCountdownTimer indicates an expected call of InsertCountdownTimer.
-func (mr *MockTimerStorageMockRecorder) InsertCountdownTimer(ctx, creator, timer interface{}) *gomock.Call {
+func (mr *MockTimerStorageMockRecorder) InsertCountdownTimer(ctx, creator, timer, maxTimers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCountdownTimer", reflect.TypeOf((*MockTimerStorage)(nil).InsertCountdownTimer), ctx, creator, timer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCountdownTimer", reflect.TypeOf((*MockTimerStorage)(nil).InsertCountdownTimer), ctx, creator, timer, maxTimers)
 }
 
 // InsertDateTimer mocks base method.
-func (m *MockTimerStorage) InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+func (m *MockTimerStorage) InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertDateTimer", ctx, creator, timer)
+	ret := m.ctrl.Call(m, "InsertDateTimer", ctx, creator, timer, maxTimers)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InsertDateTimer indicates an expected call of InsertDateTimer.
-func (mr *MockTimerStorageMockRecorder) InsertDateTimer(ctx, creator, timer interface{}) *gomock.Call {
+func (mr *MockTimerStorageMockRecorder) InsertDateTimer(ctx, creator, timer, maxTimers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDateTimer", reflect.TypeOf((*MockTimerStorage)(nil).InsertDateTimer), ctx, creator, timer, maxTimers)
+}
+
+// InsertTimers mocks base method.
+func (m *MockTimerStorage) InsertTimers(ctx context.Context, creator int64, timers []*timermodel.CreateTimer, maxTimers int, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTimers", ctx, creator, timers, maxTimers, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTimers indicates an expected call of InsertTimers.
+func (mr *MockTimerStorageMockRecorder) InsertTimers(ctx, creator, timers, maxTimers, atomic interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertDateTimer", reflect.TypeOf((*MockTimerStorage)(nil).InsertDateTimer), ctx, creator, timer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTimers", reflect.TypeOf((*MockTimerStorage)(nil).InsertTimers), ctx, creator, timers, maxTimers, atomic)
+}
+
+// PatchTimer mocks base method.
+func (m *MockTimerStorage) PatchTimer(ctx context.Context, timerId uuid.UUID, patch *timermodel.TimerPatch, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTimer", ctx, timerId, patch, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTimer indicates an expected call of PatchTimer.
+func (mr *MockTimerStorageMockRecorder) PatchTimer(ctx, timerId, patch, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTimer", reflect.TypeOf((*MockTimerStorage)(nil).PatchTimer), ctx, timerId, patch, version)
+}
+
+// RemoveSubscriber mocks base method.
+func (m *MockTimerStorage) RemoveSubscriber(ctx context.Context, timerId uuid.UUID, userId int64) (amidtime.DateTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubscriber", ctx, timerId, userId)
+	ret0, _ := ret[0].(amidtime.DateTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSubscriber indicates an expected call of RemoveSubscriber.
+func (mr *MockTimerStorageMockRecorder) RemoveSubscriber(ctx, timerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockTimerStorage)(nil).RemoveSubscriber), ctx, timerId, userId)
+}
+
+// RestoreSubscriber mocks base method.
+func (m *MockTimerStorage) RestoreSubscriber(ctx context.Context, timerId uuid.UUID, userId int64, subscribedAt amidtime.DateTime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSubscriber", ctx, timerId, userId, subscribedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSubscriber indicates an expected call of RestoreSubscriber.
+func (mr *MockTimerStorageMockRecorder) RestoreSubscriber(ctx, timerId, userId, subscribedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSubscriber", reflect.TypeOf((*MockTimerStorage)(nil).RestoreSubscriber), ctx, timerId, userId, subscribedAt)
+}
+
+// SetMaxSubscribers mocks base method.
+func (m *MockTimerStorage) SetMaxSubscribers(ctx context.Context, timerId uuid.UUID, maxSubscribers int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxSubscribers", ctx, timerId, maxSubscribers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxSubscribers indicates an expected call of SetMaxSubscribers.
+func (mr *MockTimerStorageMockRecorder) SetMaxSubscribers(ctx, timerId, maxSubscribers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxSubscribers", reflect.TypeOf((*MockTimerStorage)(nil).SetMaxSubscribers), ctx, timerId, maxSubscribers)
 }
 
 // Subscribe mocks base method.
@@ -94,6 +212,65 @@ func (mr *MockTimerStorageMockRecorder) Subscribe(ctx, timerId, userId interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockTimerStorage)(nil).Subscribe), ctx, timerId, userId)
 }
 
+// SubscribeAllowed mocks base method.
+func (m *MockTimerStorage) SubscribeAllowed(ctx context.Context, timerId uuid.UUID, userId int64, quota timermodel.Quota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeAllowed", ctx, timerId, userId, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeAllowed indicates an expected call of SubscribeAllowed.
+func (mr *MockTimerStorageMockRecorder) SubscribeAllowed(ctx, timerId, userId, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAllowed", reflect.TypeOf((*MockTimerStorage)(nil).SubscribeAllowed), ctx, timerId, userId, quota)
+}
+
+// SubscribeAllowedUsers mocks base method.
+func (m *MockTimerStorage) SubscribeAllowedUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, quota timermodel.Quota, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeAllowedUsers", ctx, timerId, userIds, quota, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeAllowedUsers indicates an expected call of SubscribeAllowedUsers.
+func (mr *MockTimerStorageMockRecorder) SubscribeAllowedUsers(ctx, timerId, userIds, quota, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAllowedUsers", reflect.TypeOf((*MockTimerStorage)(nil).SubscribeAllowedUsers), ctx, timerId, userIds, quota, atomic)
+}
+
+// SubscribeUsers mocks base method.
+func (m *MockTimerStorage) SubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeUsers", ctx, timerId, userIds, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeUsers indicates an expected call of SubscribeUsers.
+func (mr *MockTimerStorageMockRecorder) SubscribeUsers(ctx, timerId, userIds, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeUsers", reflect.TypeOf((*MockTimerStorage)(nil).SubscribeUsers), ctx, timerId, userIds, atomic)
+}
+
+// SubscribersLimit mocks base method.
+func (m *MockTimerStorage) SubscribersLimit(ctx context.Context, timerId uuid.UUID) (*timermodel.SubscribersLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribersLimit", ctx, timerId)
+	ret0, _ := ret[0].(*timermodel.SubscribersLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribersLimit indicates an expected call of SubscribersLimit.
+func (mr *MockTimerStorageMockRecorder) SubscribersLimit(ctx, timerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribersLimit", reflect.TypeOf((*MockTimerStorage)(nil).SubscribersLimit), ctx, timerId)
+}
+
 // Timer mocks base method.
 func (m *MockTimerStorage) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +286,50 @@ func (mr *MockTimerStorageMockRecorder) Timer(ctx, timerId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timer", reflect.TypeOf((*MockTimerStorage)(nil).Timer), ctx, timerId)
 }
 
+// TimerSubscribersPage mocks base method.
+func (m *MockTimerStorage) TimerSubscribersPage(ctx context.Context, timerId uuid.UUID, offset, limit int) ([]*timermodel.Subscriber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimerSubscribersPage", ctx, timerId, offset, limit)
+	ret0, _ := ret[0].([]*timermodel.Subscriber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimerSubscribersPage indicates an expected call of TimerSubscribersPage.
+func (mr *MockTimerStorageMockRecorder) TimerSubscribersPage(ctx, timerId, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimerSubscribersPage", reflect.TypeOf((*MockTimerStorage)(nil).TimerSubscribersPage), ctx, timerId, offset, limit)
+}
+
+// TimerVersion mocks base method.
+func (m *MockTimerStorage) TimerVersion(ctx context.Context, timerId uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimerVersion", ctx, timerId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimerVersion indicates an expected call of TimerVersion.
+func (mr *MockTimerStorageMockRecorder) TimerVersion(ctx, timerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimerVersion", reflect.TypeOf((*MockTimerStorage)(nil).TimerVersion), ctx, timerId)
+}
+
+// Unban mocks base method.
+func (m *MockTimerStorage) Unban(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unban", ctx, timerId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unban indicates an expected call of Unban.
+func (mr *MockTimerStorageMockRecorder) Unban(ctx, timerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unban", reflect.TypeOf((*MockTimerStorage)(nil).Unban), ctx, timerId, userId)
+}
+
 // Unsubscribe mocks base method.
 func (m *MockTimerStorage) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
 	m.ctrl.T.Helper()
@@ -123,18 +344,33 @@ func (mr *MockTimerStorageMockRecorder) Unsubscribe(ctx, timerId, userId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockTimerStorage)(nil).Unsubscribe), ctx, timerId, userId)
 }
 
+// UnsubscribeUsers mocks base method.
+func (m *MockTimerStorage) UnsubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeUsers", ctx, timerId, userIds, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsubscribeUsers indicates an expected call of UnsubscribeUsers.
+func (mr *MockTimerStorageMockRecorder) UnsubscribeUsers(ctx, timerId, userIds, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeUsers", reflect.TypeOf((*MockTimerStorage)(nil).UnsubscribeUsers), ctx, timerId, userIds, atomic)
+}
+
 // UpdateTimer mocks base method.
-func (m *MockTimerStorage) UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings) error {
+func (m *MockTimerStorage) UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTimer", ctx, timerId, timerSettings)
+	ret := m.ctrl.Call(m, "UpdateTimer", ctx, timerId, timerSettings, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTimer indicates an expected call of UpdateTimer.
-func (mr *MockTimerStorageMockRecorder) UpdateTimer(ctx, timerId, timerSettings interface{}) *gomock.Call {
+func (mr *MockTimerStorageMockRecorder) UpdateTimer(ctx, timerId, timerSettings, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimer", reflect.TypeOf((*MockTimerStorage)(nil).UpdateTimer), ctx, timerId, timerSettings)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimer", reflect.TypeOf((*MockTimerStorage)(nil).UpdateTimer), ctx, timerId, timerSettings, version)
 }
 
 // UserCreatedTimers mocks base method.
@@ -167,6 +403,21 @@ func (mr *MockTimerStorageMockRecorder) UserSubscriptions(ctx, userId, offset, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSubscriptions", reflect.TypeOf((*MockTimerStorage)(nil).UserSubscriptions), ctx, userId, offset, limit)
 }
 
+// UserTimer mocks base method.
+func (m *MockTimerStorage) UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserTimer", ctx, timerId, userId)
+	ret0, _ := ret[0].(*timermodel.Timer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserTimer indicates an expected call of UserTimer.
+func (mr *MockTimerStorageMockRecorder) UserTimer(ctx, timerId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserTimer", reflect.TypeOf((*MockTimerStorage)(nil).UserTimer), ctx, timerId, userId)
+}
+
 // UserTimers mocks base method.
 func (m *MockTimerStorage) UserTimers(ctx context.Context, userId int64, limit, offset int) ([]*timermodel.Timer, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +518,43 @@ func (mr *MockSubscriberCacheStorageMockRecorder) Unsubscribe(ctx, timerId, user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockSubscriberCacheStorage)(nil).Unsubscribe), ctx, timerId, userId)
 }
 
+// MockColorUseCase is a mock of ColorUseCase interface.
+type MockColorUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockColorUseCaseMockRecorder
+}
+
+// MockColorUseCaseMockRecorder is the mock recorder for MockColorUseCase.
+type MockColorUseCaseMockRecorder struct {
+	mock *MockColorUseCase
+}
+
+// NewMockColorUseCase creates a new mock instance.
+func NewMockColorUseCase(ctrl *gomock.Controller) *MockColorUseCase {
+	mock := &MockColorUseCase{ctrl: ctrl}
+	mock.recorder = &MockColorUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockColorUseCase) EXPECT() *MockColorUseCaseMockRecorder {
+	return m.recorder
+}
+
+// ValidateColor mocks base method.
+func (m *MockColorUseCase) ValidateColor(ctx context.Context, color timerfields.Color) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateColor", ctx, color)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateColor indicates an expected call of ValidateColor.
+func (mr *MockColorUseCaseMockRecorder) ValidateColor(ctx, color interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateColor", reflect.TypeOf((*MockColorUseCase)(nil).ValidateColor), ctx, color)
+}
+
 // MockEventSender is a mock of EventSender interface.
 type MockEventSender struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,60 @@
+package timerusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/saga"
+	"github.com/google/uuid"
+)
+
+// apply merge patch, only fields of patch are checked and updated, empty patch changes nothing
+// patch is applied only to expected version of timer, 0 version is any version
+func (uc *UseCase) Patch(ctx context.Context, timerId uuid.UUID, userId int64, patch *timermodel.TimerPatch, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Patch")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.Patch")
+	defer saga.Rollback()
+	timer, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "Patch", _PROVIDER))
+	}
+	if patch.Empty() {
+		return nil
+	}
+	if patch.Color != nil {
+		err = uc.colorUseCase.ValidateColor(ctx, *patch.Color)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate color", "Patch", _PROVIDER))
+		}
+	}
+	if patch.EndTime != nil {
+		err = checkSettingsEndTime(timer, &timermodel.TimerSettings{EndTime: *patch.EndTime})
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("check timer end time", "Patch", _PROVIDER))
+		}
+	}
+	if patch.EndTime != nil && timer.EndTime != *patch.EndTime && !timer.IsPaused {
+		err = uc.timerService.Update(ctx, timerId, patch.EndTime.Unix())
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("update end time in timerservice", "Patch", _PROVIDER))
+		}
+		saga.Register(func() error { return uc.timerService.Update(ctx, timerId, timer.EndTime.Unix()) })
+	}
+	// storage update is the last step of saga, so failed patch doesn't change version
+	err = uc.timerStorage.PatchTimer(ctx, timerId, patch, version)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("patch timer in storage", "Patch", _PROVIDER))
+	}
+	uc.esender.Send(timerevent.NewPatch(timerId, *patch))
+	saga.OK()
+	return nil
+}
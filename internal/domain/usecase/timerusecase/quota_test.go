@@ -0,0 +1,74 @@
+package timerusecase_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTimersQuota(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := timerusecase.NewMockTimerStorage(ctrl)
+
+	userId := rand.Int63()
+	quota := timermodel.Quota{MaxTimers: 2}
+	timer := randomTimer().CreateTimer()
+	timer.Type = timerfields.DATE
+	storage.EXPECT().InsertDateTimer(gomock.Any(), userId, timer, quota.MaxTimers).Return(timererror.ExceptionTimersQuota()).Times(1)
+	storage.EXPECT().InsertTimers(gomock.Any(), userId, gomock.Len(2), quota.MaxTimers, false).
+		Return([]error{nil, timererror.ExceptionTimersQuota()}, nil).Times(1)
+	cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+	cache.EXPECT().Subscribe(gomock.Any(), gomock.Any(), userId).Return(nil).Times(1)
+	service := timerservice.NewMockTimerServiceClient(ctrl)
+	service.EXPECT().AddMany(gomock.Any(), gomock.Len(1)).Return(nil).Times(1)
+
+	usecase := timerusecase.New(storage, cache, service, anyColor(ctrl), esender, nsender)
+	usecase.SetQuota(quota)
+	err := usecase.Create(ctx, userId, timer)
+	require.ErrorIs(t, err, timererror.ExceptionTimersQuota(), "wrong create error")
+
+	batch := &timermodel.BatchCreate{Timers: []*timermodel.CreateTimer{randomTimer().CreateTimer(), randomTimer().CreateTimer()}}
+	result, err := usecase.BatchCreate(ctx, userId, batch)
+	require.NoError(t, err, "batch create failed")
+	require.True(t, result.Items[0].Ok, "timer under quota not created")
+	require.False(t, result.Items[1].Ok, "timer over quota created")
+	require.Equal(t, "timer_"+timererror.ExceptionTimersQuota().Code(), result.Items[1].Code, "wrong quota code")
+}
+
+func TestSubscribeQuota(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name  string
+		quota timermodel.Quota
+		err   error
+	}{
+		{name: "subscribers quota", quota: timermodel.Quota{MaxSubscribers: 3}, err: timererror.ExceptionSubscribersLimit()},
+		{name: "subscriptions quota", quota: timermodel.Quota{MaxSubscriptions: 5}, err: timererror.ExceptionSubscriptionsQuota()},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := timerusecase.NewMockTimerStorage(ctrl)
+
+			timer := randomTimer()
+			userId := rand.Int63()
+			storage.EXPECT().Timer(gomock.Any(), timer.ID).Return(timer, nil).Times(1)
+			storage.EXPECT().SubscribeAllowed(gomock.Any(), timer.ID, userId, cs.quota).Return(cs.err).Times(1)
+
+			usecase := timerusecase.New(storage, nil, nil, nil, esender, nsender)
+			usecase.SetQuota(cs.quota)
+			_, err := usecase.Subscribe(ctx, timer.ID, userId)
+			require.ErrorIs(t, err, cs.err, "wrong subscribe error")
+		})
+	}
+}
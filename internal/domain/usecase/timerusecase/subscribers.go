@@ -0,0 +1,138 @@
+package timerusecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/saga"
+	"github.com/google/uuid"
+)
+
+// subscribers page with subscription time, only creator can see it
+func (uc *UseCase) TimerSubscribersPage(ctx context.Context, timerId uuid.UUID, userId int64, offset, limit int) ([]*timermodel.Subscriber, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.TimerSubscribersPage")
+	defer span.End()
+	_, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "TimerSubscribersPage", _PROVIDER))
+	}
+	subscribers, err := uc.timerStorage.TimerSubscribersPage(ctx, timerId, offset, limit)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get subscribers from storage", "TimerSubscribersPage", _PROVIDER))
+	}
+	return subscribers, nil
+}
+
+// remove subscriber by creator, if ban is true user also can't subscribe again
+// ban of user which isn't subscribed is allowed
+func (uc *UseCase) RemoveSubscriber(ctx context.Context, timerId uuid.UUID, userId int64, subscriberId int64, ban bool) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.RemoveSubscriber")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	timer, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "RemoveSubscriber", _PROVIDER))
+	}
+	if timer.Creator == subscriberId {
+		return exception.Wrap(timererror.ExceptionCreatorUnsubscribe(), exception.NewCause("remove creator", "RemoveSubscriber", _PROVIDER))
+	}
+	subscribers, err := uc.subscriberStorage.TimerSubscribers(ctx, timerId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("get timer subscribers", "RemoveSubscriber", _PROVIDER))
+	}
+	if _, ok := subscribers[subscriberId]; !ok {
+		if !ban {
+			return exception.Wrap(timererror.ExceptionUserNotSubscriber(), exception.NewCause("find subscriber", "RemoveSubscriber", _PROVIDER))
+		}
+		err = uc.timerStorage.Ban(ctx, timerId, subscriberId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("ban user", "RemoveSubscriber", _PROVIDER))
+		}
+		return nil
+	}
+
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId, "subscriber_id", subscriberId)
+	saga := saga.NewContext(ctx, "timerusecase.RemoveSubscriber")
+	defer saga.Rollback()
+
+	err = uc.subscriberStorage.Unsubscribe(ctx, timerId, subscriberId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("unsubscribe in cache storage", "RemoveSubscriber", _PROVIDER))
+	}
+	saga.Register(func() error { return uc.subscriberStorage.Subscribe(ctx, timerId, subscriberId) })
+
+	subscribedAt, err := uc.timerStorage.RemoveSubscriber(ctx, timerId, subscriberId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("remove subscriber in timer storage", "RemoveSubscriber", _PROVIDER))
+	}
+	// restored subscriber keeps subscription time, zero time means subscriber wasn't in storage
+	if !subscribedAt.T().IsZero() {
+		saga.Register(func() error { return uc.timerStorage.RestoreSubscriber(ctx, timerId, subscriberId, subscribedAt) })
+	}
+
+	// ban is the last step, so failed remove doesn't leave user banned
+	if ban {
+		err = uc.timerStorage.Ban(ctx, timerId, subscriberId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("ban user", "RemoveSubscriber", _PROVIDER))
+		}
+	}
+
+	uc.nsender.Send(notification.NewRemoved(*timer, subscriberId))
+	saga.OK()
+	return nil
+}
+
+func (uc *UseCase) Unban(ctx context.Context, timerId uuid.UUID, userId int64, bannedId int64) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Unban")
+	defer span.End()
+	_, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "Unban", _PROVIDER))
+	}
+	err = uc.timerStorage.Unban(ctx, timerId, bannedId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("unban user", "Unban", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) SubscribersLimit(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.SubscribersLimit, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.SubscribersLimit")
+	defer span.End()
+	_, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "SubscribersLimit", _PROVIDER))
+	}
+	limit, err := uc.timerStorage.SubscribersLimit(ctx, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get subscribers limit", "SubscribersLimit", _PROVIDER))
+	}
+	return limit, nil
+}
+
+// set max subscribers, current subscribers over new limit aren't removed
+func (uc *UseCase) SetSubscribersLimit(ctx context.Context, timerId uuid.UUID, userId int64, maxSubscribers int) (*timermodel.SubscribersLimit, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.SetSubscribersLimit")
+	defer span.End()
+	_, err := uc.checkAccess(ctx, userId, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "SetSubscribersLimit", _PROVIDER))
+	}
+	err = uc.timerStorage.SetMaxSubscribers(ctx, timerId, maxSubscribers)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("set max subscribers", "SetSubscribersLimit", _PROVIDER))
+	}
+	limit, err := uc.timerStorage.SubscribersLimit(ctx, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get subscribers limit", "SetSubscribersLimit", _PROVIDER))
+	}
+	return limit, nil
+}
@@ -0,0 +1,100 @@
+package timerusecase_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeNotAllowed(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{name: "banned", err: timererror.ExceptionUserBanned()},
+		{name: "limit reached", err: timererror.ExceptionSubscribersLimit()},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := timerusecase.NewMockTimerStorage(ctrl)
+			cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+
+			timer := randomTimer()
+			userId := rand.Int63()
+			storage.EXPECT().Timer(gomock.Any(), timer.ID).Return(timer, nil).Times(1)
+			storage.EXPECT().SubscribeAllowed(gomock.Any(), timer.ID, userId, timermodel.Quota{}).Return(cs.err).Times(1)
+
+			usecase := timerusecase.New(storage, cache, nil, nil, esender, nsender)
+			_, err := usecase.Subscribe(ctx, timer.ID, userId)
+			require.ErrorIs(t, err, cs.err, "wrong subscribe error")
+		})
+	}
+}
+
+func TestRemoveSubscriber(t *testing.T) {
+	ctx := context.Background()
+
+	banErr := errors.New("ban failed")
+	subscribedAt := amidtime.DateTime(time.Now().Add(-time.Hour))
+	cases := []struct {
+		name       string
+		ban        bool
+		subscribed bool
+		banErr     error
+		err        error
+	}{
+		{name: "remove", subscribed: true},
+		{name: "ban failed, subscriber restored", ban: true, subscribed: true, banErr: banErr, err: banErr},
+		{name: "remove and ban", ban: true, subscribed: true},
+		{name: "ban not subscriber", ban: true},
+		{name: "remove not subscriber", err: timererror.ExceptionUserNotSubscriber()},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := timerusecase.NewMockTimerStorage(ctrl)
+			cache := timerusecase.NewMockSubscriberCacheStorage(ctrl)
+
+			timer := randomTimer()
+			subscriberId := rand.Int63()
+			subscribers := timermodel.Subscribers{timer.Creator: struct{}{}}
+			if cs.subscribed {
+				subscribers[subscriberId] = struct{}{}
+			}
+			storage.EXPECT().Timer(gomock.Any(), timer.ID).Return(timer, nil).Times(1)
+			cache.EXPECT().TimerSubscribers(gomock.Any(), timer.ID).Return(subscribers, nil).Times(1)
+			if cs.ban {
+				storage.EXPECT().Ban(gomock.Any(), timer.ID, subscriberId).Return(cs.banErr).Times(1)
+			}
+			if cs.subscribed {
+				cache.EXPECT().Unsubscribe(gomock.Any(), timer.ID, subscriberId).Return(nil).Times(1)
+				storage.EXPECT().RemoveSubscriber(gomock.Any(), timer.ID, subscriberId).Return(subscribedAt, nil).Times(1)
+			}
+			// restored subscriber keeps subscription time
+			if cs.banErr != nil {
+				storage.EXPECT().RestoreSubscriber(gomock.Any(), timer.ID, subscriberId, subscribedAt).Return(nil).Times(1)
+				cache.EXPECT().Subscribe(gomock.Any(), timer.ID, subscriberId).Return(nil).Times(1)
+			}
+
+			usecase := timerusecase.New(storage, cache, nil, nil, esender, nsender)
+			err := usecase.RemoveSubscriber(ctx, timer.ID, timer.Creator, subscriberId, cs.ban)
+			if cs.err != nil {
+				require.ErrorIs(t, err, cs.err, "wrong remove subscriber error")
+				return
+			}
+			require.NoError(t, err, "remove subscriber failed")
+		})
+	}
+}
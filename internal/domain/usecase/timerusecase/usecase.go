@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
 	"github.com/Tap-Team/timerapi/internal/model/timerevent"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/internal/timerservice"
+	"github.com/Tap-Team/timerapi/internal/tracing"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/pkg/saga"
@@ -19,18 +21,38 @@ import (
 const _PROVIDER = "internal/domain/usecase/timerusecase"
 
 type TimerStorage interface {
-	InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error
-	InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error
-	UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings) error
+	InsertDateTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error
+	InsertCountdownTimer(ctx context.Context, creator int64, timer *timermodel.CreateTimer, maxTimers int) error
+	UpdateTimer(ctx context.Context, timerId uuid.UUID, timerSettings *timermodel.TimerSettings, version int64) error
+	PatchTimer(ctx context.Context, timerId uuid.UUID, patch *timermodel.TimerPatch, version int64) error
 	DeleteTimer(ctx context.Context, id uuid.UUID) error
 	Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error)
+	UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
 
 	UserTimers(ctx context.Context, userId int64, limit, offset int) ([]*timermodel.Timer, error)
 	UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
 	UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	FilteredTimers(ctx context.Context, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error)
 
 	Subscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
+	SubscribeAllowed(ctx context.Context, timerId uuid.UUID, userId int64, quota timermodel.Quota) error
 	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
+	RemoveSubscriber(ctx context.Context, timerId uuid.UUID, userId int64) (amidtime.DateTime, error)
+	RestoreSubscriber(ctx context.Context, timerId uuid.UUID, userId int64, subscribedAt amidtime.DateTime) error
+
+	InsertTimers(ctx context.Context, creator int64, timers []*timermodel.CreateTimer, maxTimers int, atomic bool) ([]error, error)
+	DeleteTimers(ctx context.Context, timerIds []uuid.UUID, atomic bool) ([]error, error)
+	SubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error)
+	SubscribeAllowedUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, quota timermodel.Quota, atomic bool) ([]error, error)
+	UnsubscribeUsers(ctx context.Context, timerId uuid.UUID, userIds []int64, atomic bool) ([]error, error)
+
+	TimerSubscribersPage(ctx context.Context, timerId uuid.UUID, offset, limit int) ([]*timermodel.Subscriber, error)
+	SubscribersLimit(ctx context.Context, timerId uuid.UUID) (*timermodel.SubscribersLimit, error)
+	SetMaxSubscribers(ctx context.Context, timerId uuid.UUID, maxSubscribers int) error
+	Ban(ctx context.Context, timerId uuid.UUID, userId int64) error
+	Unban(ctx context.Context, timerId uuid.UUID, userId int64) error
+
+	TimerVersion(ctx context.Context, timerId uuid.UUID) (int64, error)
 }
 
 type SubscriberCacheStorage interface {
@@ -40,6 +62,10 @@ type SubscriberCacheStorage interface {
 	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
 }
 
+type ColorUseCase interface {
+	ValidateColor(ctx context.Context, color timerfields.Color) error
+}
+
 type EventSender interface {
 	Send(event timerevent.TimerEvent)
 }
@@ -52,21 +78,31 @@ type UseCase struct {
 	timerStorage      TimerStorage
 	subscriberStorage SubscriberCacheStorage
 	timerService      timerservice.TimerServiceClient
+	colorUseCase      ColorUseCase
 	esender           EventSender
 	nsender           NotificationSender
+	quota             timermodel.Quota
 }
 
 func New(
 	timerStorage TimerStorage,
 	timerCache SubscriberCacheStorage,
 	timerService timerservice.TimerServiceClient,
+	colorUseCase ColorUseCase,
 	esender EventSender,
 	nsender NotificationSender,
 ) *UseCase {
-	return &UseCase{timerStorage: timerStorage, subscriberStorage: timerCache, timerService: timerService, esender: esender, nsender: nsender}
+	return &UseCase{timerStorage: timerStorage, subscriberStorage: timerCache, timerService: timerService, colorUseCase: colorUseCase, esender: esender, nsender: nsender}
+}
+
+// set per user quotas, zero quota is unlimited
+func (uc *UseCase) SetQuota(quota timermodel.Quota) {
+	uc.quota = quota
 }
 
 func (uc *UseCase) UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.UserSubscriptions")
+	defer span.End()
 	timers, err := uc.timerStorage.UserSubscriptions(ctx, userId, offset, limit)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get timers from storage", "UserSubscriptions", _PROVIDER))
@@ -75,6 +111,8 @@ func (uc *UseCase) UserSubscriptions(ctx context.Context, userId int64, offset,
 }
 
 func (uc *UseCase) UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.UserCreatedTimers")
+	defer span.End()
 	timers, err := uc.timerStorage.UserCreatedTimers(ctx, userId, offset, limit)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get timers from storage", "UserCreatedTimers", _PROVIDER))
@@ -83,6 +121,8 @@ func (uc *UseCase) UserCreatedTimers(ctx context.Context, userId int64, offset,
 }
 
 func (uc *UseCase) UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.UserTimers")
+	defer span.End()
 	timers, err := uc.timerStorage.UserTimers(ctx, userId, offset, limit)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get user timers from storage", "UserTimers", _PROVIDER))
@@ -90,7 +130,20 @@ func (uc *UseCase) UserTimers(ctx context.Context, userId int64, offset, limit i
 	return timers, nil
 }
 
+// user timers of filter list in folder and with tag of filter
+func (uc *UseCase) FilteredTimers(ctx context.Context, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.FilteredTimers")
+	defer span.End()
+	timers, err := uc.timerStorage.FilteredTimers(ctx, userId, filter, offset, limit)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get filtered timers from storage", "FilteredTimers", _PROVIDER))
+	}
+	return timers, nil
+}
+
 func (uc *UseCase) TimerSubscribers(ctx context.Context, timerId uuid.UUID) ([]int64, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.TimerSubscribers")
+	defer span.End()
 	subscribers, err := uc.subscriberStorage.TimerSubscribers(ctx, timerId)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get timer subscribers", "TimerSubscribers", _PROVIDER))
@@ -99,6 +152,8 @@ func (uc *UseCase) TimerSubscribers(ctx context.Context, timerId uuid.UUID) ([]i
 }
 
 func (uc *UseCase) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.Timer")
+	defer span.End()
 	timer, err := uc.timerStorage.Timer(ctx, timerId)
 	if err != nil {
 		return nil, exception.Wrap(err, exception.NewCause("get timer from storage", "Timer", _PROVIDER))
@@ -106,28 +161,47 @@ func (uc *UseCase) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Ti
 	return timer, nil
 }
 
+// timer with relation of user to timer
+func (uc *UseCase) UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.UserTimer")
+	defer span.End()
+	timer, err := uc.timerStorage.UserTimer(ctx, timerId, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user timer from storage", "UserTimer", _PROVIDER))
+	}
+	return timer, nil
+}
+
 func (uc *UseCase) Create(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Create")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timer.ID, "user_id", creator)
+	saga := saga.NewContext(ctx, "timerusecase.Create")
 	defer saga.Rollback()
 
-	// create timer into storage
+	err = uc.colorUseCase.ValidateColor(ctx, timer.Color)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("validate color", "Create", _PROVIDER))
+	}
+
+	// create timer into storage, timers quota is checked in storage transaction
 	switch timer.Type {
 	case timerfields.DATE:
-		err := uc.timerStorage.InsertDateTimer(ctx, creator, timer)
+		err := uc.timerStorage.InsertDateTimer(ctx, creator, timer, uc.quota.MaxTimers)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("create date timer into storage", "Create", _PROVIDER))
 		}
 	case timerfields.COUNTDOWN:
-		err := uc.timerStorage.InsertCountdownTimer(ctx, creator, timer)
+		err := uc.timerStorage.InsertCountdownTimer(ctx, creator, timer, uc.quota.MaxTimers)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("create countdown timer into storage", "Create", _PROVIDER))
 		}
 	}
-	saga.Register(func() {
-		uc.timerStorage.DeleteTimer(ctx, timer.ID)
+	saga.Register(func() error {
+		return uc.timerStorage.DeleteTimer(ctx, timer.ID)
 	})
 
 	// subscribe creator to own timer in subscriberStorage
@@ -135,16 +209,16 @@ func (uc *UseCase) Create(ctx context.Context, creator int64, timer *timermodel.
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("subscribe creator to own timer", "Create", _PROVIDER))
 	}
-	saga.Register(func() {
-		uc.subscriberStorage.DeleteTimer(ctx, timer.ID)
+	saga.Register(func() error {
+		return uc.subscriberStorage.DeleteTimer(ctx, timer.ID)
 	})
 	// add timer end time in timer service
 	err = uc.timerService.Add(ctx, timer.ID, timer.EndTime.Unix())
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("add timer end time to timerService", "Create", _PROVIDER))
 	}
-	saga.Register(func() {
-		uc.timerService.Remove(ctx, timer.ID)
+	saga.Register(func() error {
+		return uc.timerService.Remove(ctx, timer.ID)
 	})
 
 	// if err == nil set saga state is ok
@@ -152,12 +226,39 @@ func (uc *UseCase) Create(ctx context.Context, creator int64, timer *timermodel.
 	return nil
 }
 
+// create own copy of any timer, copy is created by normal create saga
+func (uc *UseCase) Clone(ctx context.Context, timerId uuid.UUID, userId int64, clone *timermodel.CloneTimer) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.Clone")
+	defer span.End()
+	original, err := uc.timerStorage.Timer(ctx, timerId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get timer from storage", "Clone", _PROVIDER))
+	}
+	timer := original.Clone(uuid.New(), clone, time.Now())
+	err = timer.Validate()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("validate timer copy", "Clone", _PROVIDER))
+	}
+	err = uc.Create(ctx, userId, timer)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("create timer copy", "Clone", _PROVIDER))
+	}
+	created, err := uc.timerStorage.Timer(ctx, timer.ID)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get timer copy from storage", "Clone", _PROVIDER))
+	}
+	return created, nil
+}
+
 func (uc *UseCase) Delete(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Delete")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
 	// create new saga
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.Delete")
 	// defer saga was rollback if not all ok
 	defer saga.Rollback()
 	// check access user to timer
@@ -182,31 +283,38 @@ func (uc *UseCase) Delete(ctx context.Context, timerId uuid.UUID, userId int64)
 	return nil
 }
 
-func (uc *UseCase) Update(ctx context.Context, timerId uuid.UUID, userId int64, settings *timermodel.TimerSettings) error {
+// update timer with expected version, 0 version is any version
+// storage update is the last step of saga, so failed update doesn't change version
+func (uc *UseCase) Update(ctx context.Context, timerId uuid.UUID, userId int64, settings *timermodel.TimerSettings, version int64) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Update")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.Update")
 	defer saga.Rollback()
 	timer, err := uc.checkAccess(ctx, userId, timerId)
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("check access", "Update", _PROVIDER))
 	}
-	err = checkSettingsEndTime(timer, settings)
+	err = uc.colorUseCase.ValidateColor(ctx, settings.Color)
 	if err != nil {
-		return exception.Wrap(err, exception.NewCause("check timer end time", "Update", _PROVIDER))
+		return exception.Wrap(err, exception.NewCause("validate color", "Update", _PROVIDER))
 	}
-	err = uc.timerStorage.UpdateTimer(ctx, timerId, settings)
+	err = checkSettingsEndTime(timer, settings)
 	if err != nil {
-		return exception.Wrap(err, exception.NewCause("update timer in storage", "Update", _PROVIDER))
+		return exception.Wrap(err, exception.NewCause("check timer end time", "Update", _PROVIDER))
 	}
-	saga.Register(func() {
-		uc.timerStorage.UpdateTimer(ctx, timer.ID, timermodel.NewTimerSettings(timer.Name, timer.Description, timer.Color, timer.WithMusic, timer.EndTime))
-	})
 	if timer.EndTime != settings.EndTime && !timer.IsPaused {
 		err = uc.timerService.Update(ctx, timerId, settings.EndTime.Unix())
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("update end time in timerservice", "Update", _PROVIDER))
 		}
+		saga.Register(func() error { return uc.timerService.Update(ctx, timerId, timer.EndTime.Unix()) })
+	}
+	err = uc.timerStorage.UpdateTimer(ctx, timerId, settings, version)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("update timer in storage", "Update", _PROVIDER))
 	}
 	uc.esender.Send(timerevent.NewUpdate(timerId, *settings))
 	saga.OK()
@@ -296,6 +404,8 @@ func (uc *UseCase) checkAccess(ctx context.Context, userId int64, timerId uuid.U
 }
 
 func (uc *UseCase) Subscribe(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.Subscribe")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 	var err error
@@ -308,31 +418,34 @@ func (uc *UseCase) Subscribe(ctx context.Context, timerId uuid.UUID, userId int6
 		return nil, exception.Wrap(timererror.ExceptionUserAlreadySubscriber(), exception.NewCause("unsubscribe timer", "Unsubscribe", _PROVIDER))
 	}
 	// create new saga
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.Subscribe")
 	// defer saga was rollback if not all ok
 	defer saga.Rollback()
 
-	// subscribe in subscriber cache storage
-	err = uc.subscriberStorage.Subscribe(ctx, timerId, userId)
+	// subscribe in timerStorage, ban, subscribers limit and quotas are checked in the same transaction
+	err = uc.timerStorage.SubscribeAllowed(ctx, timerId, userId, uc.quota)
 	if err != nil {
-		return nil, exception.Wrap(err, exception.NewCause("subscribe in cache storage", "Subscribe", _PROVIDER))
+		return nil, exception.Wrap(err, exception.NewCause("subscribe in timer storage", "Subscribe", _PROVIDER))
 	}
 	// register rollback
-	saga.Register(func() { uc.subscriberStorage.Unsubscribe(ctx, timerId, userId) })
+	saga.Register(func() error { return uc.timerStorage.Unsubscribe(ctx, timerId, userId) })
 
-	// subscribe in timerStorage
-	err = uc.timerStorage.Subscribe(ctx, timerId, userId)
+	// subscribe in subscriber cache storage
+	err = uc.subscriberStorage.Subscribe(ctx, timerId, userId)
 	if err != nil {
-		return nil, exception.Wrap(err, exception.NewCause("subscribe in timer storage", "Subscribe", _PROVIDER))
+		return nil, exception.Wrap(err, exception.NewCause("subscribe in cache storage", "Subscribe", _PROVIDER))
 	}
 	// register rollback
-	saga.Register(func() { uc.subscriberStorage.Unsubscribe(ctx, timerId, userId) })
+	saga.Register(func() error { return uc.subscriberStorage.Unsubscribe(ctx, timerId, userId) })
 
 	saga.OK()
 	return timer, nil
 }
 
 func (uc *UseCase) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	ctx, span := tracing.Start(ctx, "timerusecase.Unsubscribe")
+	defer span.End()
 	var err error
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
@@ -344,7 +457,8 @@ func (uc *UseCase) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId in
 		return exception.Wrap(timererror.ExceptionCreatorUnsubscribe(), exception.NewCause("unsubscribe timer", "Unsubscribe", _PROVIDER))
 	}
 	// create new saga
-	var saga saga.Saga
+	ctx = logging.With(ctx, "timer_id", timerId, "user_id", userId)
+	saga := saga.NewContext(ctx, "timerusecase.Unsubscribe")
 	// defer saga was rollback if not all ok
 	defer saga.Rollback()
 
@@ -354,7 +468,7 @@ func (uc *UseCase) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId in
 		return exception.Wrap(err, exception.NewCause("unsubscribe in cache storage", "Unsubscribe", _PROVIDER))
 	}
 	// register rollback
-	saga.Register(func() { uc.subscriberStorage.Subscribe(ctx, timerId, userId) })
+	saga.Register(func() error { return uc.subscriberStorage.Subscribe(ctx, timerId, userId) })
 
 	// unsubscribe in timer storage
 	err = uc.timerStorage.Unsubscribe(ctx, timerId, userId)
@@ -362,7 +476,7 @@ func (uc *UseCase) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId in
 		return exception.Wrap(err, exception.NewCause("unsubscribe in timer storage", "Unsubscribe", _PROVIDER))
 	}
 	// register rollback
-	saga.Register(func() { uc.subscriberStorage.Subscribe(ctx, timerId, userId) })
+	saga.Register(func() error { return uc.subscriberStorage.Subscribe(ctx, timerId, userId) })
 
 	saga.OK()
 	return nil
@@ -19,6 +19,7 @@ import (
 	"github.com/Tap-Team/timerapi/pkg/postgres"
 	"github.com/Tap-Team/timerapi/pkg/rediscontainer"
 	"github.com/Tap-Team/timerapi/proto/timerservicepb"
+	"github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -64,6 +65,13 @@ type NSender struct{}
 
 func (s NSender) Send(notification.Notification) {}
 
+// palette which has every color
+func anyColor(ctrl *gomock.Controller) *timerusecase.MockColorUseCase {
+	colors := timerusecase.NewMockColorUseCase(ctrl)
+	colors.EXPECT().ValidateColor(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return colors
+}
+
 var (
 	timerStorage      timerusecase.TimerStorage
 	subscriberStorage timerusecase.SubscriberCacheStorage
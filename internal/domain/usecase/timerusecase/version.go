@@ -0,0 +1,19 @@
+package timerusecase
+
+import (
+	"context"
+
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+func (uc *UseCase) TimerVersion(ctx context.Context, timerId uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "timerusecase.TimerVersion")
+	defer span.End()
+	version, err := uc.timerStorage.TimerVersion(ctx, timerId)
+	if err != nil {
+		return 0, exception.Wrap(err, exception.NewCause("get version from storage", "TimerVersion", _PROVIDER))
+	}
+	return version, nil
+}
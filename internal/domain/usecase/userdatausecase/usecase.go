@@ -0,0 +1,257 @@
+package userdatausecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/userdataerror"
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/userdatamodel"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const _PROVIDER = "internal/domain/usecase/userdatausecase"
+
+// timers are loaded by pages
+const pageSize = 100
+
+type ErasureStorage interface {
+	StartErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error)
+	CompleteErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error)
+	Erasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error)
+	PendingErasures(ctx context.Context) ([]int64, error)
+}
+
+type TimerStorage interface {
+	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
+	EraseUserTimers(ctx context.Context, userId int64) error
+}
+
+type SubscriberCacheStorage interface {
+	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
+}
+
+type TimerUseCase interface {
+	UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	Delete(ctx context.Context, timerId uuid.UUID, userId int64) error
+}
+
+type NotificationUseCase interface {
+	Notifications(ctx context.Context, userId int64) ([]*notification.NotificationDTO, error)
+	Delete(ctx context.Context, userId int64) error
+}
+
+type WebhookUseCase interface {
+	Webhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error)
+	DeleteAll(ctx context.Context, userId int64) error
+}
+
+type CalendarUseCase interface {
+	UserFeed(ctx context.Context, userId int64) (*calendarmodel.Feed, error)
+	DeleteFeed(ctx context.Context, userId int64) error
+}
+
+type UseCase struct {
+	erasureStorage      ErasureStorage
+	timerStorage        TimerStorage
+	subscriberStorage   SubscriberCacheStorage
+	timerUseCase        TimerUseCase
+	notificationUseCase NotificationUseCase
+	webhookUseCase      WebhookUseCase
+	calendarUseCase     CalendarUseCase
+}
+
+func New(
+	erasureStorage ErasureStorage,
+	timerStorage TimerStorage,
+	subscriberStorage SubscriberCacheStorage,
+	timerUseCase TimerUseCase,
+	notificationUseCase NotificationUseCase,
+	webhookUseCase WebhookUseCase,
+	calendarUseCase CalendarUseCase,
+) *UseCase {
+	return &UseCase{
+		erasureStorage:      erasureStorage,
+		timerStorage:        timerStorage,
+		subscriberStorage:   subscriberStorage,
+		timerUseCase:        timerUseCase,
+		notificationUseCase: notificationUseCase,
+		webhookUseCase:      webhookUseCase,
+		calendarUseCase:     calendarUseCase,
+	}
+}
+
+func allPages(ctx context.Context, userId int64, page func(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)) ([]*timermodel.Timer, error) {
+	timers := make([]*timermodel.Timer, 0)
+	for offset := 0; ; offset += pageSize {
+		timerPage, err := page(ctx, userId, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		timers = append(timers, timerPage...)
+		if len(timerPage) < pageSize {
+			return timers, nil
+		}
+	}
+}
+
+// archive of all data tied to user
+func (uc *UseCase) Export(ctx context.Context, userId int64) (*userdatamodel.Archive, error) {
+	var err error
+	archive := &userdatamodel.Archive{UserId: userId, ExportedAt: amidtime.Now()}
+	archive.Timers, err = allPages(ctx, userId, uc.timerUseCase.UserCreatedTimers)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user created timers", "Export", _PROVIDER))
+	}
+	archive.Subscriptions, err = allPages(ctx, userId, uc.timerUseCase.UserSubscriptions)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user subscriptions", "Export", _PROVIDER))
+	}
+	archive.Notifications, err = uc.notificationUseCase.Notifications(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user notifications", "Export", _PROVIDER))
+	}
+	archive.Webhooks, err = uc.webhookUseCase.Webhooks(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user webhooks", "Export", _PROVIDER))
+	}
+	archive.CalendarFeed, err = uc.calendarUseCase.UserFeed(ctx, userId)
+	if err != nil && !errors.Is(err, calendarerror.ExceptionFeedNotFound()) {
+		return nil, exception.Wrap(err, exception.NewCause("get user calendar feed", "Export", _PROVIDER))
+	}
+	return archive, nil
+}
+
+func (uc *UseCase) Erasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure, err := uc.erasureStorage.Erasure(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get erasure", "Erasure", _PROVIDER))
+	}
+	return erasure, nil
+}
+
+// erase all data tied to user
+// every step can be repeated, so failed erasure is continued by next call or by Resume
+func (uc *UseCase) Erase(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute*5)
+	defer cancel()
+	_, err := uc.erasureStorage.StartErasure(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("start erasure", "Erase", _PROVIDER))
+	}
+	// delete created timers, subscribers get delete notification
+	err = uc.deleteTimers(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("delete user timers", "Erase", _PROVIDER))
+	}
+	err = uc.unsubscribe(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("unsubscribe user", "Erase", _PROVIDER))
+	}
+	err = uc.timerStorage.EraseUserTimers(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("erase user timers", "Erase", _PROVIDER))
+	}
+	err = uc.notificationUseCase.Delete(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("delete user notifications", "Erase", _PROVIDER))
+	}
+	err = uc.webhookUseCase.DeleteAll(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("delete user webhooks", "Erase", _PROVIDER))
+	}
+	err = uc.calendarUseCase.DeleteFeed(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("delete user calendar feed", "Erase", _PROVIDER))
+	}
+	erasure, err := uc.erasureStorage.CompleteErasure(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("complete erasure", "Erase", _PROVIDER))
+	}
+	return erasure, nil
+}
+
+// continue all unfinished erasures, returns completed erasures and errors of failed
+func (uc *UseCase) Resume(ctx context.Context) ([]*userdatamodel.Erasure, error) {
+	userIds, err := uc.erasureStorage.PendingErasures(ctx)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get pending erasures", "Resume", _PROVIDER))
+	}
+	erasures := make([]*userdatamodel.Erasure, 0, len(userIds))
+	errs := make([]error, 0)
+	for _, userId := range userIds {
+		erasure, err := uc.Erase(ctx, userId)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		erasures = append(erasures, erasure)
+	}
+	if len(errs) != 0 {
+		return erasures, exception.Wrap(errors.Join(errs...), exception.NewCause("erase users", "Resume", _PROVIDER))
+	}
+	return erasures, nil
+}
+
+// deleted timers are excluded from created timers, so first page is loaded until it is empty
+// page without not attempted timers means timers aren't deleted, erasure is stopped instead of endless loop
+func (uc *UseCase) deleteTimers(ctx context.Context, userId int64) error {
+	attempted := make(map[uuid.UUID]bool)
+	for {
+		timers, err := uc.timerUseCase.UserCreatedTimers(ctx, userId, 0, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(timers) == 0 {
+			return nil
+		}
+		progress := false
+		for _, timer := range timers {
+			if attempted[timer.ID] {
+				continue
+			}
+			attempted[timer.ID] = true
+			progress = true
+			err := uc.timerUseCase.Delete(ctx, timer.ID, userId)
+			if err != nil && !errors.Is(err, timererror.ExceptionTimerNotFound()) {
+				return err
+			}
+		}
+		if !progress {
+			return userdataerror.ExceptionErasureStalled()
+		}
+	}
+}
+
+// remove user from subscribers of other timers in cache and in storage
+func (uc *UseCase) unsubscribe(ctx context.Context, userId int64) error {
+	for {
+		timers, err := uc.timerUseCase.UserSubscriptions(ctx, userId, 0, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(timers) == 0 {
+			return nil
+		}
+		for _, timer := range timers {
+			// timer subscribers could be already removed from cache by previous erasure
+			err := uc.subscriberStorage.Unsubscribe(ctx, timer.ID, userId)
+			if err != nil && !errors.Is(err, timererror.ExceptionTimerSubscribersNotFound()) {
+				return err
+			}
+			err = uc.timerStorage.Unsubscribe(ctx, timer.ID, userId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package userdatausecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/userdatausecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/calendarerror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/errorutils/userdataerror"
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/userdatamodel"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var errFailed = errors.New("failed")
+
+// in memory data of all stores, timer subscribers are kept separately in cache and storage
+type data struct {
+	timers        map[uuid.UUID]*timermodel.Timer
+	storageSubs   map[uuid.UUID]map[int64]bool
+	cacheSubs     map[uuid.UUID]map[int64]bool
+	notifications map[int64]int
+	webhooks      map[int64]int
+	feeds         map[int64]string
+	erasures      map[int64]*userdatamodel.Erasure
+	deleted       []uuid.UUID
+	// delete of this timer fails
+	failDelete uuid.UUID
+	// delete of this timer returns not found, but timer is kept
+	keepDelete uuid.UUID
+}
+
+func newData() *data {
+	return &data{
+		timers:        make(map[uuid.UUID]*timermodel.Timer),
+		storageSubs:   make(map[uuid.UUID]map[int64]bool),
+		cacheSubs:     make(map[uuid.UUID]map[int64]bool),
+		notifications: make(map[int64]int),
+		webhooks:      make(map[int64]int),
+		feeds:         make(map[int64]string),
+		erasures:      make(map[int64]*userdatamodel.Erasure),
+	}
+}
+
+func (d *data) addTimer(creator int64, subscribers ...int64) *timermodel.Timer {
+	timer := &timermodel.Timer{ID: uuid.New(), Creator: creator}
+	d.timers[timer.ID] = timer
+	d.storageSubs[timer.ID] = map[int64]bool{creator: true}
+	d.cacheSubs[timer.ID] = map[int64]bool{creator: true}
+	for _, userId := range subscribers {
+		d.storageSubs[timer.ID][userId] = true
+		d.cacheSubs[timer.ID][userId] = true
+	}
+	return timer
+}
+
+func page(timers []*timermodel.Timer, offset, limit int) []*timermodel.Timer {
+	if offset >= len(timers) {
+		return []*timermodel.Timer{}
+	}
+	end := offset + limit
+	if end > len(timers) {
+		end = len(timers)
+	}
+	return timers[offset:end]
+}
+
+func (d *data) UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	timers := make([]*timermodel.Timer, 0)
+	for _, timer := range d.timers {
+		if timer.Creator == userId {
+			timers = append(timers, timer)
+		}
+	}
+	return page(timers, offset, limit), nil
+}
+
+func (d *data) UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	timers := make([]*timermodel.Timer, 0)
+	for id, subs := range d.storageSubs {
+		timer, ok := d.timers[id]
+		if ok && subs[userId] && timer.Creator != userId {
+			timers = append(timers, timer)
+		}
+	}
+	return page(timers, offset, limit), nil
+}
+
+// delete by timer use case, cache subscribers are removed by delete notification
+func (d *data) Delete(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	if timerId == d.failDelete {
+		return errFailed
+	}
+	if timerId == d.keepDelete {
+		return timererror.ExceptionTimerNotFound()
+	}
+	if _, ok := d.timers[timerId]; !ok {
+		return timererror.ExceptionTimerNotFound()
+	}
+	delete(d.timers, timerId)
+	delete(d.cacheSubs, timerId)
+	d.deleted = append(d.deleted, timerId)
+	return nil
+}
+
+func (d *data) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	delete(d.storageSubs[timerId], userId)
+	return nil
+}
+
+func (d *data) EraseUserTimers(ctx context.Context, userId int64) error {
+	for _, subs := range d.storageSubs {
+		delete(subs, userId)
+	}
+	return nil
+}
+
+type cache struct{ *data }
+
+func (c cache) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	subs, ok := c.cacheSubs[timerId]
+	if !ok {
+		return timererror.ExceptionTimerSubscribersNotFound()
+	}
+	delete(subs, userId)
+	return nil
+}
+
+type notifications struct{ *data }
+
+func (n notifications) Notifications(ctx context.Context, userId int64) ([]*notification.NotificationDTO, error) {
+	return make([]*notification.NotificationDTO, n.notifications[userId]), nil
+}
+
+func (n notifications) Delete(ctx context.Context, userId int64) error {
+	delete(n.notifications, userId)
+	return nil
+}
+
+func (d *data) Webhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error) {
+	return make([]*webhookmodel.Webhook, d.webhooks[userId]), nil
+}
+
+func (d *data) DeleteAll(ctx context.Context, userId int64) error {
+	delete(d.webhooks, userId)
+	return nil
+}
+
+func (d *data) UserFeed(ctx context.Context, userId int64) (*calendarmodel.Feed, error) {
+	token, ok := d.feeds[userId]
+	if !ok {
+		return nil, calendarerror.ExceptionFeedNotFound()
+	}
+	return &calendarmodel.Feed{Token: token}, nil
+}
+
+func (d *data) DeleteFeed(ctx context.Context, userId int64) error {
+	delete(d.feeds, userId)
+	return nil
+}
+
+func (d *data) StartErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure, ok := d.erasures[userId]
+	if !ok || erasure.Completed {
+		erasure = &userdatamodel.Erasure{UserId: userId, RequestedAt: amidtime.Now()}
+		d.erasures[userId] = erasure
+	}
+	return erasure, nil
+}
+
+func (d *data) CompleteErasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure := d.erasures[userId]
+	erasure.Completed = true
+	erasure.CompletedAt = amidtime.Now()
+	return erasure, nil
+}
+
+func (d *data) Erasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error) {
+	erasure, ok := d.erasures[userId]
+	if !ok {
+		return nil, userdataerror.ExceptionErasureNotFound()
+	}
+	return erasure, nil
+}
+
+func (d *data) PendingErasures(ctx context.Context) ([]int64, error) {
+	userIds := make([]int64, 0)
+	for userId, erasure := range d.erasures {
+		if !erasure.Completed {
+			userIds = append(userIds, userId)
+		}
+	}
+	return userIds, nil
+}
+
+func newUseCase(d *data) *userdatausecase.UseCase {
+	return userdatausecase.New(d, d, cache{d}, d, notifications{d}, d, d)
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	d := newData()
+	var userId, otherId int64 = 1, 2
+	d.addTimer(userId)
+	d.addTimer(userId, otherId)
+	d.addTimer(otherId, userId)
+	d.notifications[userId] = 3
+	d.webhooks[userId] = 1
+
+	archive, err := newUseCase(d).Export(ctx, userId)
+	require.NoError(t, err, "export failed")
+	require.Equal(t, userId, archive.UserId, "wrong archive user")
+	require.Len(t, archive.Timers, 2, "wrong created timers")
+	require.Len(t, archive.Subscriptions, 1, "wrong subscriptions")
+	require.Len(t, archive.Notifications, 3, "wrong notifications")
+	require.Len(t, archive.Webhooks, 1, "wrong webhooks")
+	require.Nil(t, archive.CalendarFeed, "feed without token")
+}
+
+func TestErase(t *testing.T) {
+	ctx := context.Background()
+	d := newData()
+	var userId, otherId int64 = 1, 2
+	own := d.addTimer(userId, otherId)
+	failed := d.addTimer(userId)
+	other := d.addTimer(otherId, userId)
+	otherOwn := d.addTimer(otherId)
+	d.notifications[userId] = 1
+	d.webhooks[userId] = 1
+	d.feeds[userId] = "token"
+	d.failDelete = failed.ID
+	usecase := newUseCase(d)
+
+	// first erasure fails on delete of timer, erasure stays unfinished
+	_, err := usecase.Erase(ctx, userId)
+	require.ErrorIs(t, err, errFailed, "wrong erase error")
+	erasure, err := usecase.Erasure(ctx, userId)
+	require.NoError(t, err, "get erasure failed")
+	require.False(t, erasure.Completed, "failed erasure completed")
+
+	// resume continues erasure
+	d.failDelete = uuid.Nil
+	erasures, err := usecase.Resume(ctx)
+	require.NoError(t, err, "resume failed")
+	require.Len(t, erasures, 1, "wrong count of resumed erasures")
+	require.True(t, erasures[0].Completed, "erasure not completed")
+
+	require.ElementsMatch(t, []uuid.UUID{own.ID, failed.ID}, d.deleted, "wrong deleted timers")
+	require.False(t, d.storageSubs[other.ID][userId], "subscription not removed from storage")
+	require.False(t, d.cacheSubs[other.ID][userId], "subscription not removed from cache")
+	require.Contains(t, d.timers, otherOwn.ID, "timer of other user deleted")
+	require.Empty(t, d.notifications, "notifications not deleted")
+	require.Empty(t, d.webhooks, "webhooks not deleted")
+	require.Empty(t, d.feeds, "feed not deleted")
+
+	// repeated erasure is no-op
+	erasure, err = usecase.Erase(ctx, userId)
+	require.NoError(t, err, "repeated erase failed")
+	require.True(t, erasure.Completed, "repeated erasure not completed")
+	require.Len(t, d.deleted, 2, "timers deleted twice")
+}
+
+func TestEraseStalled(t *testing.T) {
+	ctx := context.Background()
+	d := newData()
+	var userId int64 = 1
+	kept := d.addTimer(userId)
+	deleted := d.addTimer(userId)
+	d.keepDelete = kept.ID
+	usecase := newUseCase(d)
+
+	// timer listed after delete doesn't make erasure loop
+	_, err := usecase.Erase(ctx, userId)
+	require.ErrorIs(t, err, userdataerror.ExceptionErasureStalled(), "wrong erase error")
+	require.Equal(t, []uuid.UUID{deleted.ID}, d.deleted, "wrong deleted timers")
+	erasure, err := usecase.Erasure(ctx, userId)
+	require.NoError(t, err, "get erasure failed")
+	require.False(t, erasure.Completed, "stalled erasure completed")
+
+	d.keepDelete = uuid.Nil
+	erasure, err = usecase.Erase(ctx, userId)
+	require.NoError(t, err, "resumed erase failed")
+	require.True(t, erasure.Completed, "resumed erasure not completed")
+}
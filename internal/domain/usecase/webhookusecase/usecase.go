@@ -0,0 +1,172 @@
+package webhookusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/url"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const _PROVIDER = "internal/domain/usecase/webhookusecase"
+
+// max count of webhooks of one user
+const maxUserWebhooks = 10
+
+type WebhookStorage interface {
+	InsertWebhook(ctx context.Context, webhook *webhookmodel.Webhook) error
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	DeleteUserWebhooks(ctx context.Context, userId int64) error
+	Webhook(ctx context.Context, id uuid.UUID) (*webhookmodel.Webhook, error)
+	UserWebhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error)
+	EnableWebhook(ctx context.Context, id uuid.UUID) error
+	WebhookDeliveries(ctx context.Context, webhookId uuid.UUID, offset, limit int) ([]*webhookmodel.Delivery, error)
+}
+
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type UseCase struct {
+	storage  WebhookStorage
+	resolver Resolver
+}
+
+func New(storage WebhookStorage, resolver Resolver) *UseCase {
+	return &UseCase{storage: storage, resolver: resolver}
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// create webhook, secret is returned only once in this method
+func (uc *UseCase) Create(ctx context.Context, userId int64, create *webhookmodel.CreateWebhook) (*webhookmodel.Webhook, error) {
+	webhooks, err := uc.storage.UserWebhooks(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user webhooks", "Create", _PROVIDER))
+	}
+	if len(webhooks) >= maxUserWebhooks {
+		return nil, exception.Wrap(webhookerror.ExceptionTooManyWebhooks(), exception.NewCause("check webhooks count", "Create", _PROVIDER))
+	}
+	err = uc.checkHost(ctx, create.URL)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check host", "Create", _PROVIDER))
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("generate secret", "Create", _PROVIDER))
+	}
+	webhook := &webhookmodel.Webhook{
+		ID:        uuid.New(),
+		UserId:    userId,
+		URL:       create.URL,
+		Secret:    secret,
+		Events:    create.Events,
+		IsEnabled: true,
+		CreatedAt: amidtime.Now(),
+	}
+	err = uc.storage.InsertWebhook(ctx, webhook)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("insert webhook", "Create", _PROVIDER))
+	}
+	return webhook, nil
+}
+
+// every address of webhook host should be public, url is already validated
+func (uc *UseCase) checkHost(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return webhookerror.ExceptionWrongURL()
+	}
+	addrs, err := uc.resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return webhookerror.ExceptionWrongURL()
+	}
+	for _, addr := range addrs {
+		if !webhookmodel.PublicIP(addr.IP) {
+			return webhookerror.ExceptionWrongURL()
+		}
+	}
+	return nil
+}
+
+// user webhooks without secrets
+func (uc *UseCase) Webhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error) {
+	webhooks, err := uc.storage.UserWebhooks(ctx, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get user webhooks", "Webhooks", _PROVIDER))
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, webhookId uuid.UUID, userId int64) error {
+	_, err := uc.checkAccess(ctx, webhookId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "Delete", _PROVIDER))
+	}
+	err = uc.storage.DeleteWebhook(ctx, webhookId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete webhook", "Delete", _PROVIDER))
+	}
+	return nil
+}
+
+// delete all user webhooks
+func (uc *UseCase) DeleteAll(ctx context.Context, userId int64) error {
+	err := uc.storage.DeleteUserWebhooks(ctx, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("delete user webhooks", "DeleteAll", _PROVIDER))
+	}
+	return nil
+}
+
+// enable webhook disabled after failed deliveries
+func (uc *UseCase) Enable(ctx context.Context, webhookId uuid.UUID, userId int64) error {
+	_, err := uc.checkAccess(ctx, webhookId, userId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("check access", "Enable", _PROVIDER))
+	}
+	err = uc.storage.EnableWebhook(ctx, webhookId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("enable webhook", "Enable", _PROVIDER))
+	}
+	return nil
+}
+
+func (uc *UseCase) Deliveries(ctx context.Context, webhookId uuid.UUID, userId int64, offset, limit int) ([]*webhookmodel.Delivery, error) {
+	_, err := uc.checkAccess(ctx, webhookId, userId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("check access", "Deliveries", _PROVIDER))
+	}
+	deliveries, err := uc.storage.WebhookDeliveries(ctx, webhookId, offset, limit)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get webhook deliveries", "Deliveries", _PROVIDER))
+	}
+	return deliveries, nil
+}
+
+func (uc *UseCase) checkAccess(ctx context.Context, webhookId uuid.UUID, userId int64) (*webhookmodel.Webhook, error) {
+	webhook, err := uc.storage.Webhook(ctx, webhookId)
+	if err != nil {
+		return nil, exception.Wrap(err, exception.NewCause("get webhook by id", "checkAccess", _PROVIDER))
+	}
+	if webhook.UserId != userId {
+		return nil, exception.Wrap(webhookerror.ExceptionUserForbidden(), exception.NewCause("compare owner and userId", "checkAccess", _PROVIDER))
+	}
+	return webhook, nil
+}
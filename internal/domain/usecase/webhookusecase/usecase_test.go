@@ -0,0 +1,185 @@
+package webhookusecase_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/webhookusecase"
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookStorage struct {
+	webhooks []*webhookmodel.Webhook
+}
+
+func (s *webhookStorage) InsertWebhook(ctx context.Context, webhook *webhookmodel.Webhook) error {
+	s.webhooks = append(s.webhooks, webhook)
+	return nil
+}
+
+func (s *webhookStorage) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	for i, webhook := range s.webhooks {
+		if webhook.ID == id {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			return nil
+		}
+	}
+	return webhookerror.ExceptionWebhookNotFound()
+}
+
+func (s *webhookStorage) DeleteUserWebhooks(ctx context.Context, userId int64) error {
+	webhooks := s.webhooks[:0]
+	for _, webhook := range s.webhooks {
+		if webhook.UserId != userId {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	s.webhooks = webhooks
+	return nil
+}
+
+func (s *webhookStorage) Webhook(ctx context.Context, id uuid.UUID) (*webhookmodel.Webhook, error) {
+	for _, webhook := range s.webhooks {
+		if webhook.ID == id {
+			copy := *webhook
+			return &copy, nil
+		}
+	}
+	return nil, webhookerror.ExceptionWebhookNotFound()
+}
+
+func (s *webhookStorage) UserWebhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error) {
+	webhooks := make([]*webhookmodel.Webhook, 0)
+	for _, webhook := range s.webhooks {
+		if webhook.UserId == userId {
+			copy := *webhook
+			webhooks = append(webhooks, &copy)
+		}
+	}
+	return webhooks, nil
+}
+
+func (s *webhookStorage) EnableWebhook(ctx context.Context, id uuid.UUID) error {
+	for _, webhook := range s.webhooks {
+		if webhook.ID == id {
+			webhook.IsEnabled = true
+			webhook.FailureCount = 0
+			return nil
+		}
+	}
+	return webhookerror.ExceptionWebhookNotFound()
+}
+
+func (s *webhookStorage) WebhookDeliveries(ctx context.Context, webhookId uuid.UUID, offset, limit int) ([]*webhookmodel.Delivery, error) {
+	return []*webhookmodel.Delivery{}, nil
+}
+
+// resolver of fixed hosts, unknown host isn't resolved
+type resolver map[string][]string
+
+func (r resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+var testResolver = resolver{
+	"public.test":   {"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"},
+	"private.test":  {"10.0.0.1"},
+	"loopback.test": {"127.0.0.1"},
+	"mixed.test":    {"93.184.216.34", "192.168.1.1"},
+	"shared.test":   {"100.64.0.1"},
+	"v6local.test":  {"::1"},
+	"ula.test":      {"fd00::1"},
+	"empty.test":    {},
+}
+
+func createWebhook(url string) *webhookmodel.CreateWebhook {
+	return &webhookmodel.CreateWebhook{URL: url, Events: []webhookmodel.EventType{webhookmodel.Expire}}
+}
+
+func TestCreateCheckHost(t *testing.T) {
+	ctx := context.Background()
+	storage := &webhookStorage{}
+	uc := webhookusecase.New(storage, testResolver)
+	var userId int64 = 1
+
+	webhook, err := uc.Create(ctx, userId, createWebhook("https://public.test/hook"))
+	require.NoError(t, err, "create webhook of public host failed")
+	require.NotEmpty(t, webhook.Secret, "secret not returned on create")
+	require.True(t, webhook.IsEnabled, "created webhook is disabled")
+
+	for _, host := range []string{
+		"private.test",
+		"loopback.test",
+		"mixed.test",
+		"shared.test",
+		"v6local.test",
+		"ula.test",
+		"empty.test",
+		"unknown.test",
+	} {
+		_, err := uc.Create(ctx, userId, createWebhook("https://"+host+"/hook"))
+		require.ErrorIs(t, err, webhookerror.ExceptionWrongURL(), "webhook of %s created", host)
+	}
+	require.Len(t, storage.webhooks, 1, "webhook with wrong host inserted")
+}
+
+func TestCreateMaxWebhooks(t *testing.T) {
+	ctx := context.Background()
+	storage := &webhookStorage{}
+	uc := webhookusecase.New(storage, testResolver)
+	var userId int64 = 1
+
+	for i := 0; i < 10; i++ {
+		_, err := uc.Create(ctx, userId, createWebhook("https://public.test/hook"))
+		require.NoError(t, err, "create webhook under limit failed")
+	}
+	_, err := uc.Create(ctx, userId, createWebhook("https://public.test/hook"))
+	require.ErrorIs(t, err, webhookerror.ExceptionTooManyWebhooks(), "webhook over limit created")
+
+	// limit is per user
+	_, err = uc.Create(ctx, userId+1, createWebhook("https://public.test/hook"))
+	require.NoError(t, err, "create webhook of other user failed")
+
+	// deleted webhook frees place
+	err = uc.Delete(ctx, storage.webhooks[0].ID, userId)
+	require.NoError(t, err, "delete webhook failed")
+	_, err = uc.Create(ctx, userId, createWebhook("https://public.test/hook"))
+	require.NoError(t, err, "create webhook after delete failed")
+}
+
+func TestWebhookAccess(t *testing.T) {
+	ctx := context.Background()
+	storage := &webhookStorage{}
+	uc := webhookusecase.New(storage, testResolver)
+	var userId int64 = 1
+
+	webhook, err := uc.Create(ctx, userId, createWebhook("https://public.test/hook"))
+	require.NoError(t, err, "create webhook failed")
+
+	err = uc.Enable(ctx, webhook.ID, userId+1)
+	require.ErrorIs(t, err, webhookerror.ExceptionUserForbidden(), "webhook enabled by not owner")
+	err = uc.Delete(ctx, webhook.ID, userId+1)
+	require.ErrorIs(t, err, webhookerror.ExceptionUserForbidden(), "webhook deleted by not owner")
+	_, err = uc.Deliveries(ctx, webhook.ID, userId+1, 0, 10)
+	require.ErrorIs(t, err, webhookerror.ExceptionUserForbidden(), "deliveries got by not owner")
+	err = uc.Delete(ctx, uuid.New(), userId)
+	require.ErrorIs(t, err, webhookerror.ExceptionWebhookNotFound(), "not existing webhook deleted")
+
+	webhooks, err := uc.Webhooks(ctx, userId)
+	require.NoError(t, err, "get webhooks failed")
+	require.Len(t, webhooks, 1, "wrong webhooks count")
+	require.Empty(t, webhooks[0].Secret, "secret returned in webhook list")
+}
@@ -10,8 +10,8 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// error is logged by request logger with request id
 func ErrorHandler(e error, c echo.Context) {
-	c.Logger().Error(e)
 	httpCode := 500
 	response := ErrorResponse{
 		Code:    "common_internal",
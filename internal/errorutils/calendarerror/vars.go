@@ -0,0 +1,21 @@
+package calendarerror
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const calendarErrType = "calendar"
+
+var (
+	ExceptionFeedNotFound = func() exception.Exception {
+		return exception.New(http.StatusNotFound, calendarErrType, "feed_not_found")
+	}
+	ExceptionTimerPaused = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, calendarErrType, "timer_paused")
+	}
+	ExceptionWrongCalendar = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, calendarErrType, "wrong_calendar")
+	}
+)
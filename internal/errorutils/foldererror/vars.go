@@ -0,0 +1,23 @@
+package foldererror
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const folderErrType = "folder"
+
+var (
+	ExceptionFolderNotFound = func() exception.Exception { return exception.New(http.StatusNotFound, folderErrType, "not_found") }
+	ExceptionTagNotFound    = func() exception.Exception { return exception.New(http.StatusNotFound, folderErrType, "tag_not_found") }
+	ExceptionUserForbidden  = func() exception.Exception {
+		return exception.New(http.StatusForbidden, folderErrType, "user_forbidden")
+	}
+	ExceptionTooManyFolders = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, folderErrType, "too_many_folders")
+	}
+	ExceptionTooManyTags = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, folderErrType, "too_many_tags")
+	}
+)
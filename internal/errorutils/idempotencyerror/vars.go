@@ -0,0 +1,21 @@
+package idempotencyerror
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const idempotencyErrType = "idempotency"
+
+var (
+	ExceptionWrongKey = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, idempotencyErrType, "wrong_key")
+	}
+	ExceptionRequestInProgress = func() exception.Exception {
+		return exception.New(http.StatusConflict, idempotencyErrType, "request_in_progress")
+	}
+	ExceptionKeyReused = func() exception.Exception {
+		return exception.New(http.StatusUnprocessableEntity, idempotencyErrType, "key_reused")
+	}
+)
@@ -23,12 +23,17 @@ var (
 	ExceptionUserForbidden = func() exception.Exception { return exception.New(http.StatusForbidden, timerErrType, "user_forbidden") }
 
 	ExceptionColorNotFound = func() exception.Exception { return exception.New(http.StatusNotFound, timerErrType, "color_not_found") }
+	ExceptionWrongColor    = func() exception.Exception { return exception.New(http.StatusBadRequest, timerErrType, "wrong_color") }
+	ExceptionSoundNotFound = func() exception.Exception { return exception.New(http.StatusNotFound, timerErrType, "sound_not_found") }
+	ExceptionWrongVolume   = func() exception.Exception { return exception.New(http.StatusBadRequest, timerErrType, "wrong_volume") }
 	ExceptionTypeNotFound  = func() exception.Exception { return exception.New(http.StatusNotFound, timerErrType, "type_not_found") }
 
 	ExceptionStatusNotFound = func() exception.Exception {
 		return exception.New(http.StatusNotFound, timerErrType, "status_not_found")
 	}
 
+	ExceptionWrongDelta = func() exception.Exception { return exception.New(http.StatusBadRequest, timerErrType, "wrong_delta") }
+
 	ExceptionTimerIsPaused  = func() exception.Exception { return exception.New(http.StatusBadRequest, timerErrType, "is_paused") }
 	ExceptionTimerIsPlaying = func() exception.Exception { return exception.New(http.StatusBadRequest, timerErrType, "is_playing") }
 
@@ -39,4 +44,52 @@ var (
 	ExceptionCreatorUnsubscribe = func() exception.Exception {
 		return exception.New(http.StatusBadRequest, timerErrType, "creator_unsubscribe")
 	}
+
+	ExceptionUserNotSubscriber = func() exception.Exception {
+		return exception.New(http.StatusNotFound, timerErrType, "user_not_subscriber")
+	}
+	ExceptionUserBanned = func() exception.Exception {
+		return exception.New(http.StatusForbidden, timerErrType, "user_banned")
+	}
+	ExceptionSubscribersLimit = func() exception.Exception {
+		return exception.New(http.StatusConflict, timerErrType, "subscribers_limit")
+	}
+	ExceptionWrongSubscribersLimit = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, timerErrType, "wrong_subscribers_limit")
+	}
+
+	ExceptionTimersQuota = func() exception.Exception {
+		return exception.New(http.StatusConflict, timerErrType, "timers_quota")
+	}
+	ExceptionSubscriptionsQuota = func() exception.Exception {
+		return exception.New(http.StatusConflict, timerErrType, "subscriptions_quota")
+	}
+	ExceptionRateLimit = func() exception.Exception {
+		return exception.New(http.StatusTooManyRequests, timerErrType, "rate_limit")
+	}
+
+	ExceptionVersionRequired = func() exception.Exception {
+		return exception.New(http.StatusPreconditionRequired, timerErrType, "version_required")
+	}
+	ExceptionWrongVersion = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, timerErrType, "wrong_version")
+	}
+	ExceptionVersionMismatch = func() exception.Exception {
+		return exception.New(http.StatusPreconditionFailed, timerErrType, "version_mismatch")
+	}
+
+	ExceptionWrongTimeZone = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, timerErrType, "wrong_time_zone")
+	}
+
+	ExceptionWrongPatch = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, timerErrType, "wrong_patch")
+	}
+
+	ExceptionWrongBatchSize = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, timerErrType, "wrong_batch_size")
+	}
+	ExceptionBatchAborted = func() exception.Exception {
+		return exception.New(http.StatusConflict, timerErrType, "batch_aborted")
+	}
 )
@@ -0,0 +1,19 @@
+package userdataerror
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const userDataErrType = "user_data"
+
+var (
+	ExceptionErasureNotFound = func() exception.Exception {
+		return exception.New(http.StatusNotFound, userDataErrType, "erasure_not_found")
+	}
+	// created timers are still listed after delete, erasure should be resumed later
+	ExceptionErasureStalled = func() exception.Exception {
+		return exception.New(http.StatusConflict, userDataErrType, "erasure_stalled")
+	}
+)
@@ -0,0 +1,21 @@
+package webhookerror
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+)
+
+const webhookErrType = "webhook"
+
+var (
+	ExceptionWebhookNotFound = func() exception.Exception { return exception.New(http.StatusNotFound, webhookErrType, "not_found") }
+	ExceptionWrongURL        = func() exception.Exception { return exception.New(http.StatusBadRequest, webhookErrType, "wrong_url") }
+	ExceptionWrongEvent      = func() exception.Exception { return exception.New(http.StatusBadRequest, webhookErrType, "wrong_event") }
+	ExceptionUserForbidden   = func() exception.Exception {
+		return exception.New(http.StatusForbidden, webhookErrType, "user_forbidden")
+	}
+	ExceptionTooManyWebhooks = func() exception.Exception {
+		return exception.New(http.StatusBadRequest, webhookErrType, "too_many_webhooks")
+	}
+)
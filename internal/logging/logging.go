@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+type loggerKey struct{}
+
+type requestIdKey struct{}
+
+// set default json logger to stdout, std log output is redirected to it
+// empty level is info
+func Setup(level string) error {
+	var lvl slog.Level
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return err
+		}
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: lvl})))
+	return nil
+}
+
+func logger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+// logger with attributes added to ctx and trace id of span from ctx
+func FromContext(ctx context.Context) *slog.Logger {
+	l := logger(ctx)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = l.With("trace_id", sc.TraceID().String())
+	}
+	return l
+}
+
+// add attributes to logger of ctx, e.g. timer_id and user_id of usecase
+func With(ctx context.Context, args ...any) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger(ctx).With(args...))
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIdKey{}, id)
+	return With(ctx, "request_id", id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey{}).(string)
+	return id
+}
+
+type cause struct {
+	Action string `json:"action"`
+	Method string `json:"method"`
+	Pkg    string `json:"pkg"`
+}
+
+// error attribute, exception is logged with code, http code and chain of causes
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	var e *exception.AmidException
+	if !errors.As(err, &e) {
+		return slog.String("error", err.Error())
+	}
+	message := exception.MakeCode(e)
+	if inner := e.Unwrap(); inner != nil {
+		message = inner.Error()
+	}
+	causes := make([]cause, 0)
+	for _, c := range e.Causes() {
+		causes = append(causes, cause{Action: c.Action(), Method: c.Method(), Pkg: c.Pkg()})
+	}
+	return slog.Group("error",
+		slog.String("message", message),
+		slog.String("code", exception.MakeCode(e)),
+		slog.Int("http_code", e.HttpCode()),
+		slog.Any("causes", causes),
+	)
+}
@@ -0,0 +1,65 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func capture(t *testing.T) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return buf
+}
+
+func TestContext(t *testing.T) {
+	buf := capture(t)
+	ctx := logging.WithRequestID(context.Background(), "req")
+	ctx = logging.With(ctx, "timer_id", "timer", "user_id", int64(1))
+	logging.FromContext(ctx).Info("message")
+
+	record := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), "unmarshal record failed")
+	require.Equal(t, "req", record["request_id"], "wrong request id")
+	require.Equal(t, "timer", record["timer_id"], "wrong timer id")
+	require.Equal(t, float64(1), record["user_id"], "wrong user id")
+	require.Equal(t, "req", logging.RequestID(ctx), "wrong request id of ctx")
+}
+
+func TestErr(t *testing.T) {
+	buf := capture(t)
+	err := exception.Error(errors.New("connection refused"), http.StatusNotFound, "timer", "not_found")
+	err = exception.Wrap(err, exception.NewCause("get timer", "Timer", "storage"))
+	err = exception.Wrap(err, exception.NewCause("get timer from storage", "Timer", "usecase"))
+	slog.Default().Error("failed", logging.Err(err))
+
+	var record struct {
+		Error struct {
+			Message  string `json:"message"`
+			Code     string `json:"code"`
+			HttpCode int    `json:"http_code"`
+			Causes   []struct {
+				Action string `json:"action"`
+				Method string `json:"method"`
+				Pkg    string `json:"pkg"`
+			} `json:"causes"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), "unmarshal record failed")
+	require.Equal(t, "connection refused", record.Error.Message, "wrong message")
+	require.Equal(t, "timer_not_found", record.Error.Code, "wrong code")
+	require.Equal(t, http.StatusNotFound, record.Error.HttpCode, "wrong http code")
+	require.Len(t, record.Error.Causes, 2, "wrong causes")
+	require.Equal(t, "storage", record.Error.Causes[0].Pkg, "wrong order of causes")
+	require.Equal(t, "get timer from storage", record.Error.Causes[1].Action, "wrong cause action")
+}
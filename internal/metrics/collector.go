@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// depth of queue is read on every scrape
+func RegisterQueue(queue string, depth func() int) {
+	prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "queue_depth",
+		Help:        "Number of items waiting in queue.",
+		ConstLabels: prometheus.Labels{"queue": queue},
+	}, func() float64 { return float64(depth()) }))
+}
+
+var subscribedTimersBuckets = []float64{0, 1, 5, 10, 25, 50, 100, 250, 500}
+
+// histogram of subscribed timers per websocket connection, built from open connections on every scrape
+type subscriptionsCollector struct {
+	desc          *prometheus.Desc
+	subscriptions func() []int
+}
+
+// subscriptions returns number of subscribed timers of every open connection
+func RegisterSubscriptions(subscriptions func() []int) {
+	prometheus.MustRegister(&subscriptionsCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "ws", "subscribed_timers"),
+			"Number of subscribed timers per open websocket connection.",
+			nil, nil,
+		),
+		subscriptions: subscriptions,
+	})
+}
+
+func (c *subscriptionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *subscriptionsCollector) Collect(ch chan<- prometheus.Metric) {
+	var sum float64
+	buckets := make(map[float64]uint64, len(subscribedTimersBuckets))
+	subscriptions := c.subscriptions()
+	for _, n := range subscriptions {
+		sum += float64(n)
+		for _, bound := range subscribedTimersBuckets {
+			if float64(n) <= bound {
+				buckets[bound]++
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstHistogram(c.desc, uint64(len(subscriptions)), sum, buckets)
+}
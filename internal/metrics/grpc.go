@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// rpc name without service, "/timerservice.TimerService/Add" is "Add"
+func rpcName(fullMethod string) string {
+	return path.Base(fullMethod)
+}
+
+func observeTicker(method string, start time.Time, err error) {
+	tickerDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		tickerErrors.WithLabelValues(method, status.Code(err).String()).Inc()
+	}
+}
+
+// observe latency and errors of ticker unary calls
+func TickerUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		observeTicker(rpcName(method), start, err)
+		return err
+	}
+}
+
+// observe errors of ticker stream opening, latency is time to open stream
+func TickerStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		observeTicker(rpcName(method), start, err)
+		return stream, err
+	}
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "timerapi"
+
+// label values are limited sets: route templates, event types, method names, never ids
+var (
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of http requests by route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	wsConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "ws",
+		Name:      "connections",
+		Help:      "Number of open websocket connections.",
+	})
+
+	timerExpirations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "timer",
+		Name:      "expirations_total",
+		Help:      "Number of processed timer expirations.",
+	})
+
+	fanoutSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "fanout_size",
+		Help:      "Number of recipients of one notification.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	}, []string{"type"})
+
+	fanoutDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "fanout_duration_seconds",
+		Help:      "Duration of notification delivery to online streams and storage.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+
+	botMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "bot",
+		Name:      "messages_total",
+		Help:      "Number of messages sent by bot by result.",
+	}, []string{"result"})
+
+	sagaRollbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "saga",
+		Name:      "rollbacks_total",
+		Help:      "Number of saga rollbacks by usecase.",
+	}, []string{"usecase"})
+
+	tickerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "ticker",
+		Name:      "rpc_duration_seconds",
+		Help:      "Duration of ticker service rpc calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	tickerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ticker",
+		Name:      "rpc_errors_total",
+		Help:      "Number of failed ticker service rpc calls by status code.",
+	}, []string{"method", "code"})
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+func ObserveHTTP(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unknown"
+	}
+	httpDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func WebsocketOpened() {
+	wsConnections.Inc()
+}
+
+func WebsocketClosed() {
+	wsConnections.Dec()
+}
+
+func TimerExpired() {
+	timerExpirations.Inc()
+}
+
+// observe size and duration of notification delivery, ntype is notification type
+func ObserveFanout(ntype string, recipients int, duration time.Duration) {
+	fanoutSize.WithLabelValues(ntype).Observe(float64(recipients))
+	fanoutDuration.WithLabelValues(ntype).Observe(duration.Seconds())
+}
+
+func BotMessageSent(err error) {
+	if err != nil {
+		botMessages.WithLabelValues(resultFailure).Inc()
+		return
+	}
+	botMessages.WithLabelValues(resultSuccess).Inc()
+}
+
+func SagaRollback(usecase string) {
+	sagaRollbacks.WithLabelValues(usecase).Inc()
+}
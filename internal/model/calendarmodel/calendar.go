@@ -0,0 +1,234 @@
+package calendarmodel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/ical"
+	"github.com/google/uuid"
+)
+
+const (
+	ProdID = "-//Tap-Team//timerapi//RU"
+	// calendar apps are asked to refresh feed with this interval
+	FeedRefreshInterval = time.Minute * 15
+
+	uidDomain = "timerapi"
+)
+
+// url of calendar feed, token is secret part of url
+type Feed struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+var colors = map[timerfields.Color]string{
+	timerfields.RED:    "red",
+	timerfields.GREEN:  "green",
+	timerfields.BLUE:   "blue",
+	timerfields.PURPLE: "purple",
+	timerfields.YELLOW: "yellow",
+}
+
+// location of timer, offset of time zone is taken at end of timer so fixed zone is right across DST
+// timer without zone uses utc field as offset in hours
+func Location(timer *timermodel.Timer) *time.Location {
+	offset := int(timer.UTC) * int(time.Hour/time.Second)
+	if timer.TimeZone != "" {
+		offset = timer.TimeZone.Offset(timer.EndTime.T())
+	}
+	if offset == 0 {
+		return time.UTC
+	}
+	sign := "+"
+	abs := offset
+	if offset < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", sign, abs/3600, abs%3600/60), offset)
+}
+
+func summary(timer *timermodel.Timer) string {
+	if len(strings.TrimSpace(string(timer.Name))) == 0 {
+		return "Таймер"
+	}
+	return string(timer.Name)
+}
+
+// paused countdown timer has no end, it can't be placed in calendar
+func Exportable(timer *timermodel.Timer) bool {
+	return !(timer.Type == timerfields.COUNTDOWN && timer.IsPaused)
+}
+
+// calendar event of timer
+// date timer is event at end time, countdown timer is event of current run from start to end
+func Event(timer *timermodel.Timer, stamp time.Time) ical.Event {
+	loc := Location(timer)
+	end := timer.EndTime.T().In(loc)
+	event := ical.Event{
+		UID:         fmt.Sprintf("%s@%s", timer.ID, uidDomain),
+		Stamp:       stamp,
+		Summary:     summary(timer),
+		Description: string(timer.Description),
+		Color:       colors[timer.Color],
+	}
+	switch timer.Type {
+	case timerfields.COUNTDOWN:
+		event.Start = end.Add(-time.Duration(timer.Duration) * time.Second)
+		event.End = end
+		event.Alarms = []ical.Alarm{
+			{Trigger: 0, Description: event.Summary},
+		}
+	default:
+		event.Start = end
+		event.Alarms = []ical.Alarm{
+			{Trigger: -time.Hour, Description: event.Summary},
+			{Trigger: 0, Description: event.Summary},
+		}
+	}
+	return event
+}
+
+// calendar of timers, paused countdown timers are skipped
+func Calendar(name string, timers []*timermodel.Timer, stamp time.Time) *ical.Calendar {
+	calendar := &ical.Calendar{
+		ProdID: ProdID,
+		Name:   name,
+		Events: make([]ical.Event, 0, len(timers)),
+	}
+	for _, timer := range timers {
+		if !Exportable(timer) {
+			continue
+		}
+		calendar.Events = append(calendar.Events, Event(timer, stamp))
+	}
+	return calendar
+}
+
+type ImportStatus string
+
+const (
+	Created  ImportStatus = "created"
+	Skipped  ImportStatus = "skipped"
+	Rejected ImportStatus = "rejected"
+)
+
+// import report of one calendar event
+type ImportResult struct {
+	UID     string       `json:"uid"`
+	Summary string       `json:"summary"`
+	Status  ImportStatus `json:"status"`
+	// error code or reason of skip, empty for created timers
+	Reason  string     `json:"reason,omitempty"`
+	TimerId *uuid.UUID `json:"timerId,omitempty"`
+	// end time of created timer
+	EndTime *amidtime.DateTime `json:"endTime,omitempty"`
+	// changes made to fit event into timer, e.g. truncated name or next occurrence of recurring event
+	Notes []string `json:"notes,omitempty"`
+}
+
+// reasons and notes of import
+const (
+	ReasonPast          = "past_event"
+	ReasonNoStart       = "no_start"
+	ReasonWrongTime     = "wrong_time"
+	ReasonRecurrence    = "unsupported_recurrence"
+	ReasonLimitExceeded = "limit_exceeded"
+
+	NoteNameTruncated        = "name_truncated"
+	NoteDescriptionTruncated = "description_truncated"
+	NoteNextOccurrence       = "recurring_next_occurrence"
+	NoteApproximateRule      = "recurrence_approximated"
+	NoteAllDay               = "all_day_start_of_day"
+)
+
+func truncate(s string, max int) (string, bool) {
+	r := []rune(s)
+	if len(r) <= max {
+		return s, false
+	}
+	return string(r[:max]), true
+}
+
+// timer offset of location in hours, offsets are rounded towards zero
+func utc(t time.Time) int16 {
+	_, offset := t.Zone()
+	return int16(offset / 3600)
+}
+
+// IANA zone of location, empty if location isn't zone of tz database
+func timeZone(t time.Time) timerfields.TimeZone {
+	zone := timerfields.TimeZone(t.Location().String())
+	if zone == "Local" || zone.Validate() != nil {
+		return ""
+	}
+	return zone
+}
+
+// date timer from calendar event, event start is end of timer
+// recurring event is replaced by next occurrence after now, events in past are skipped
+func ImportTimer(event *ical.ParsedEvent, now time.Time) (*timermodel.CreateTimer, *ImportResult) {
+	result := &ImportResult{UID: event.UID, Summary: event.Summary, Notes: make([]string, 0)}
+	reject := func(status ImportStatus, reason string) (*timermodel.CreateTimer, *ImportResult) {
+		result.Status = status
+		result.Reason = reason
+		return nil, result
+	}
+	switch {
+	case errors.Is(event.Err, ical.ErrNoStart):
+		return reject(Rejected, ReasonNoStart)
+	case event.Err != nil:
+		return reject(Rejected, ReasonWrongTime)
+	}
+	start := event.Start
+	if event.RRule != "" {
+		rule, err := ical.ParseRRule(event.RRule)
+		if err != nil {
+			return reject(Rejected, ReasonRecurrence)
+		}
+		if rule.Approximate {
+			result.Notes = append(result.Notes, NoteApproximateRule)
+		}
+		next, ok := rule.Next(start, now)
+		if !ok {
+			return reject(Skipped, ReasonPast)
+		}
+		if !next.Equal(start) {
+			result.Notes = append(result.Notes, NoteNextOccurrence)
+		}
+		start = next
+	}
+	if !start.After(now) {
+		return reject(Skipped, ReasonPast)
+	}
+	if event.AllDay {
+		result.Notes = append(result.Notes, NoteAllDay)
+	}
+	name, truncated := truncate(strings.TrimSpace(event.Summary), timerfields.NameMaxSize)
+	if truncated {
+		result.Notes = append(result.Notes, NoteNameTruncated)
+	}
+	description, truncated := truncate(strings.TrimSpace(event.Description), timerfields.DescriptionMaxSize)
+	if truncated {
+		result.Notes = append(result.Notes, NoteDescriptionTruncated)
+	}
+	timer := timermodel.NewCreateTimer(
+		uuid.New(),
+		utc(start),
+		amidtime.DateTime(now),
+		amidtime.DateTime(start),
+		timerfields.DATE,
+		timerfields.Name(name),
+		timerfields.Description(description),
+		timerfields.DEFAULT,
+		false,
+	)
+	timer.TimeZone = timeZone(start)
+	return timer, result
+}
@@ -0,0 +1,14 @@
+package colormodel
+
+import "github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+
+// color of palette, palette is stored in colors table
+type Color struct {
+	Name     timerfields.Color    `json:"name"`
+	Hex      timerfields.HexColor `json:"hex"`
+	Gradient timerfields.HexColor `json:"gradient,omitempty"`
+}
+
+func NewColor(name timerfields.Color, hex, gradient timerfields.HexColor) *Color {
+	return &Color{Name: name, Hex: hex, Gradient: gradient}
+}
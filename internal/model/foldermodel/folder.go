@@ -0,0 +1,55 @@
+package foldermodel
+
+import (
+	"github.com/Tap-Team/timerapi/pkg/amidstr"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/validate"
+	"github.com/google/uuid"
+)
+
+const (
+	NameField   = "Название Папки"
+	NameMinSize = 1
+	NameMaxSize = 60
+)
+
+type Name string
+
+func (n Name) Validate() error {
+	return validate.StringValidate(string(n), NameField, NameMinSize, NameMaxSize)
+}
+
+func (n *Name) UnmarshalJSON(data []byte) error {
+	return amidstr.UnmarshalTrimString((*string)(n), data)
+}
+
+// folder is owned by user, every user organizes his timers and subscriptions in own folders
+type Folder struct {
+	ID     uuid.UUID `json:"id"`
+	UserId int64     `json:"userId"`
+	Name   Name      `json:"name"`
+	// manual order of folder
+	Position int `json:"position"`
+	// count of not deleted timers in folder
+	TimersCount int               `json:"timersCount"`
+	CreatedAt   amidtime.DateTime `json:"createdAt"`
+}
+
+// body of create and rename folder requests
+type FolderSettings struct {
+	Name Name `json:"name"`
+}
+
+func (f *FolderSettings) Validate() error {
+	return f.Name.Validate()
+}
+
+// ids in new manual order, first id goes first
+type Order struct {
+	Ids []uuid.UUID `json:"ids"`
+}
+
+// body of move timer request, null folder moves timer out of folders
+type Move struct {
+	FolderId *uuid.UUID `json:"folderId"`
+}
@@ -0,0 +1,67 @@
+package foldermodel
+
+import (
+	"github.com/Tap-Team/timerapi/internal/errorutils/foldererror"
+	"github.com/Tap-Team/timerapi/pkg/amidstr"
+	"github.com/Tap-Team/timerapi/pkg/validate"
+)
+
+const (
+	TagField   = "Тег"
+	TagMinSize = 1
+	TagMaxSize = 32
+)
+
+// max count of tags of one timer
+const MaxTimerTags = 20
+
+// free form tag, tags are private for user
+type Tag string
+
+func (t Tag) Validate() error {
+	return validate.StringValidate(string(t), TagField, TagMinSize, TagMaxSize)
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	return amidstr.UnmarshalTrimString((*string)(t), data)
+}
+
+// tag with count of tagged not deleted timers
+type TagInfo struct {
+	Name        Tag `json:"name"`
+	TimersCount int `json:"timersCount"`
+}
+
+// tags of timer, duplicates are removed by Validate
+type Tags struct {
+	Tags []Tag `json:"tags"`
+}
+
+func (t *Tags) Validate() error {
+	unique := make([]Tag, 0, len(t.Tags))
+	seen := make(map[Tag]struct{}, len(t.Tags))
+	for _, tag := range t.Tags {
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		unique = append(unique, tag)
+	}
+	if len(unique) > MaxTimerTags {
+		return foldererror.ExceptionTooManyTags()
+	}
+	t.Tags = unique
+	return nil
+}
+
+// body of rename tag request
+type RenameTag struct {
+	Name Tag `json:"name"`
+}
+
+func (r *RenameTag) Validate() error {
+	return r.Name.Validate()
+}
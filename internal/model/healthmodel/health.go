@@ -0,0 +1,28 @@
+package healthmodel
+
+type Status string
+
+const (
+	UP   Status = "up"
+	DOWN Status = "down"
+)
+
+// state of one dependency
+type Dependency struct {
+	Status Status `json:"status"`
+	// duration of check in milliseconds
+	Latency int64 `json:"latency"`
+	// state of dependency, e.g. connection state or time of last receive
+	Info  string `json:"info,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// readiness is up only if every dependency is up
+type Readiness struct {
+	Status       Status                 `json:"status"`
+	Dependencies map[string]*Dependency `json:"dependencies"`
+}
+
+type Liveness struct {
+	Status Status `json:"status"`
+}
@@ -0,0 +1,24 @@
+package idempotencymodel
+
+import "encoding/json"
+
+// max length of Idempotency-Key header
+const MaxKeySize = 255
+
+// request saved by idempotency key, response is empty until request is completed
+type Record struct {
+	// hash of method, url and body of request
+	Hash        string `json:"hash"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+func (r Record) MarshalBinary() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Record) UnmarshalBinary(b []byte) error {
+	return json.Unmarshal(b, r)
+}
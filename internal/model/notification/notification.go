@@ -10,6 +10,8 @@ type NotificationType string
 const (
 	Expired NotificationType = "notification_expired"
 	Delete  NotificationType = "notification_delete"
+	// subscriber was removed from timer by creator
+	Removed NotificationType = "notification_removed"
 )
 
 type Notification interface {
@@ -66,3 +68,8 @@ func NewWithSubscribers(notification Notification, subscribers []int64) Notifica
 		Subs: subscribers,
 	}
 }
+
+// notification for removed subscriber only, not for all timer subscribers
+func NewRemoved(timer timermodel.Timer, userId int64) NotificationSubscribers {
+	return NewWithSubscribers(&NotificationDTO{NTimer: timer, Ntype: Removed}, []int64{userId})
+}
@@ -0,0 +1,21 @@
+package remindermodel
+
+import (
+	"encoding/json"
+
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+)
+
+// snoozed reminder about expired timer, timer is saved on snooze because expired date timer is deleted before reminder
+type Reminder struct {
+	UserId int64            `json:"userId"`
+	Timer  timermodel.Timer `json:"timer"`
+}
+
+func (r Reminder) MarshalBinary() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Reminder) UnmarshalBinary(b []byte) error {
+	return json.Unmarshal(b, r)
+}
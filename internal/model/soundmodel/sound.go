@@ -0,0 +1,13 @@
+package soundmodel
+
+import "github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+
+// sound of sounds catalog
+type Sound struct {
+	ID    timerfields.SoundId `json:"id"`
+	Title string              `json:"title"`
+	// duration in seconds
+	Duration int64 `json:"duration"`
+	// path of asset on static host of app
+	URL string `json:"url"`
+}
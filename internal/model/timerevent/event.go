@@ -1,6 +1,8 @@
 package timerevent
 
 import (
+	"time"
+
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/google/uuid"
@@ -15,6 +17,8 @@ const (
 	Subscribe   EventType = "event_subscribe"
 	Unsubscribe EventType = "event_unsubscribe"
 	Reset       EventType = "event_reset"
+	ClockSync   EventType = "event_clock_sync"
+	Adjust      EventType = "event_adjust"
 )
 
 type TimerEvent interface {
@@ -69,6 +73,18 @@ func NewReset(timerId uuid.UUID, endTime, pauseTime amidtime.DateTime) TimerEven
 	return &ResetEvent{Event: Event{Etype: Reset, Id: timerId}, EndTime: endTime, PauseTime: pauseTime}
 }
 
+// countdown time is shifted by delta seconds, end time and duration are values after shift
+type AdjustEvent struct {
+	Event
+	Delta    int64             `json:"delta"`
+	EndTime  amidtime.DateTime `json:"endTime"`
+	Duration int64             `json:"duration"`
+}
+
+func NewAdjust(timerId uuid.UUID, delta int64, endTime amidtime.DateTime, duration int64) TimerEvent {
+	return &AdjustEvent{Event: Event{Etype: Adjust, Id: timerId}, Delta: delta, EndTime: endTime, Duration: duration}
+}
+
 type UpdateEvent struct {
 	Event
 	timermodel.TimerSettings
@@ -84,6 +100,22 @@ func NewUpdate(timerId uuid.UUID, settings timermodel.TimerSettings) TimerEvent
 	}
 }
 
+// update event of merge patch, only changed fields are sent
+type PatchEvent struct {
+	Event
+	timermodel.TimerPatch
+}
+
+func NewPatch(timerId uuid.UUID, patch timermodel.TimerPatch) TimerEvent {
+	return &PatchEvent{
+		Event{
+			Etype: Update,
+			Id:    timerId,
+		},
+		patch,
+	}
+}
+
 // event which send client to server
 // add or remove timer from hot update
 type SubscribeEvent struct {
@@ -91,6 +123,38 @@ type SubscribeEvent struct {
 	TimerIds []uuid.UUID `json:"timerIds"`
 }
 
+// any event which client sends to server, subscribe events and clock sync
+type ClientEvent struct {
+	Type     EventType   `json:"type"`
+	TimerIds []uuid.UUID `json:"timerIds,omitempty"`
+	// client time of clock sync request, unix milliseconds
+	ClientSendTime int64 `json:"clientSendTime,omitempty"`
+	// server time when event was read
+	ReceiveTime time.Time `json:"-"`
+}
+
+// NTP-style clock sync exchange, all times are unix milliseconds
+// with clientReceiveTime as time when client got response
+// offset = ((serverReceiveTime - clientSendTime) + (serverSendTime - clientReceiveTime)) / 2
+// delay = (clientReceiveTime - clientSendTime) - (serverSendTime - serverReceiveTime)
+// drift is change of offset between syncs
+type ClockSyncEvent struct {
+	Type              EventType `json:"type"`
+	ClientSendTime    int64     `json:"clientSendTime"`
+	ServerReceiveTime int64     `json:"serverReceiveTime"`
+	ServerSendTime    int64     `json:"serverSendTime"`
+}
+
+func NewClockSync(clientSendTime int64, serverReceiveTime time.Time) *ClockSyncEvent {
+	return &ClockSyncEvent{Type: ClockSync, ClientSendTime: clientSendTime, ServerReceiveTime: serverReceiveTime.UnixMilli()}
+}
+
+// set server send time, should be called right before response is written
+func (e *ClockSyncEvent) Sent() *ClockSyncEvent {
+	e.ServerSendTime = time.Now().UnixMilli()
+	return e
+}
+
 func NewSubscribe(timerIds ...uuid.UUID) *SubscribeEvent {
 	return &SubscribeEvent{Type: Subscribe, TimerIds: timerIds}
 }
@@ -0,0 +1,78 @@
+package timermodel
+
+import (
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/google/uuid"
+)
+
+// max count of items in one batch request
+const MaxBatchSize = 100
+
+// atomic batch is executed all-or-nothing, otherwise every item is executed independently (best effort)
+type BatchCreate struct {
+	Atomic bool           `json:"atomic"`
+	Timers []*CreateTimer `json:"timers"`
+}
+
+func (b *BatchCreate) Validate() error {
+	if len(b.Timers) == 0 || len(b.Timers) > MaxBatchSize {
+		return timererror.ExceptionWrongBatchSize()
+	}
+	return nil
+}
+
+type BatchDelete struct {
+	Atomic   bool        `json:"atomic"`
+	TimerIds []uuid.UUID `json:"timerIds"`
+}
+
+func (b *BatchDelete) Validate() error {
+	if len(b.TimerIds) == 0 || len(b.TimerIds) > MaxBatchSize {
+		return timererror.ExceptionWrongBatchSize()
+	}
+	return nil
+}
+
+// subscribe or unsubscribe users of one timer
+type BatchSubscribers struct {
+	Atomic  bool      `json:"atomic"`
+	TimerId uuid.UUID `json:"timerId"`
+	UserIds []int64   `json:"userIds"`
+}
+
+func (b *BatchSubscribers) Validate() error {
+	if len(b.UserIds) == 0 || len(b.UserIds) > MaxBatchSize {
+		return timererror.ExceptionWrongBatchSize()
+	}
+	return nil
+}
+
+// result of one batch item, items are in order of request
+type BatchItemResult struct {
+	TimerId uuid.UUID `json:"timerId"`
+	UserId  int64     `json:"userId,omitempty"`
+	Ok      bool      `json:"ok"`
+	// error code of failed item, "timer_batch_aborted" for items rolled back by atomic batch
+	Code string `json:"code,omitempty"`
+}
+
+type BatchResult struct {
+	Atomic bool `json:"atomic"`
+	// count of committed items
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Items     []*BatchItemResult `json:"items"`
+}
+
+func NewBatchResult(atomic bool, size int) *BatchResult {
+	return &BatchResult{Atomic: atomic, Items: make([]*BatchItemResult, 0, size)}
+}
+
+func (r *BatchResult) Add(item *BatchItemResult) {
+	if item.Ok {
+		r.Succeeded++
+	} else {
+		r.Failed++
+	}
+	r.Items = append(r.Items, item)
+}
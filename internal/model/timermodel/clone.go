@@ -0,0 +1,41 @@
+package timermodel
+
+import (
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+)
+
+// options of timer copy
+type CloneTimer struct {
+	// countdown of copy starts now with duration of original timer, otherwise copy ends with original timer
+	// date timer always ends with original timer
+	Reset bool `json:"reset"`
+	// copy description of original timer, otherwise copy has empty description
+	// name, duration, color, music and sound are copied always
+	KeepSettings bool `json:"keepSettings"`
+}
+
+// create timer of copy with new id, copy of paused countdown timer starts with time left of original
+func (t *Timer) Clone(id uuid.UUID, clone *CloneTimer, now time.Time) *CreateTimer {
+	timer := t.CreateTimer()
+	timer.ID = id
+	if t.Type == timerfields.COUNTDOWN {
+		duration := time.Duration(t.Duration) * time.Second
+		end := t.EndTime.T()
+		switch {
+		case clone.Reset:
+			end = now.Add(duration)
+		case t.IsPaused:
+			end = now.Add(t.EndTime.T().Sub(t.PauseTime.T()))
+		}
+		timer.EndTime = amidtime.DateTime(end)
+		timer.StartTime = amidtime.DateTime(end.Add(-duration))
+	}
+	if !clone.KeepSettings {
+		timer.Description = ""
+	}
+	return timer
+}
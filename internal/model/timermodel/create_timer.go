@@ -22,8 +22,16 @@ type CreateTimer struct {
 	Type        timerfields.Type        `json:"type"`
 	Name        timerfields.Name        `json:"name"`
 	Description timerfields.Description `json:"description"`
-	Color       timerfields.Color       `json:"color"`
-	WithMusic   bool                    `json:"withMusic"`
+	// palette color, DEFAULT if empty and custom color is set
+	Color       timerfields.Color        `json:"color"`
+	CustomColor *timerfields.CustomColor `json:"customColor,omitempty"`
+	// default sound is set if only withMusic is set
+	WithMusic bool                `json:"withMusic"`
+	SoundId   timerfields.SoundId `json:"soundId,omitempty"`
+	// max volume if empty
+	Volume timerfields.Volume `json:"volume,omitempty"`
+	// IANA time zone, if empty zone is taken from utc
+	TimeZone timerfields.TimeZone `json:"timeZone"`
 }
 
 func NewCreateTimer(
@@ -50,10 +58,15 @@ func NewCreateTimer(
 }
 
 func (t *CreateTimer) ValidatableVariables() []validate.Validatable {
-	return []validate.Validatable{t.Description, t.Name, t.Color, t.Type}
+	return []validate.Validatable{t.Description, t.Name, t.Color, t.CustomColor, t.Type, t.TimeZone, t.SoundId, t.Volume}
 }
 
 func (t *CreateTimer) Validate() error {
+	if t.Color == "" && t.CustomColor != nil {
+		t.Color = timerfields.DEFAULT
+	}
+	t.SoundId, t.Volume = t.Sound()
+	t.WithMusic = t.SoundId != 0
 	err := validate.ValidateFields(t)
 	if err != nil {
 		return err
@@ -70,6 +83,27 @@ func (t *CreateTimer) Validate() error {
 	return nil
 }
 
+// zone and utc offset in hours which are stored, utc is derived from zone at now
+// timer without zone gets zone of utc for old clients
+func (t *CreateTimer) Zone(now time.Time) (timerfields.TimeZone, int16) {
+	if t.TimeZone == "" {
+		return timerfields.TimeZoneFromUTC(t.UTC), t.UTC
+	}
+	return t.TimeZone, int16(t.TimeZone.Offset(now) / 3600)
+}
+
+// sound and volume which are stored, old clients know only withMusic and get default sound
+func (t *CreateTimer) Sound() (timerfields.SoundId, timerfields.Volume) {
+	soundId, volume := t.SoundId, t.Volume
+	if soundId == 0 && t.WithMusic {
+		soundId = timerfields.DefaultSound
+	}
+	if volume == 0 {
+		volume = timerfields.MaxVolume
+	}
+	return soundId, volume
+}
+
 func (t CreateTimer) DefaultDuration() int64 {
 	return t.EndTime.Unix() - t.StartTime.Unix()
 }
@@ -0,0 +1,11 @@
+package timermodel
+
+// per user quotas, 0 is unlimited
+type Quota struct {
+	// max active timers of one creator
+	MaxTimers int
+	// max subscriptions of one user, own timers aren't counted
+	MaxSubscriptions int
+	// max subscribers of one timer, creator can set lower limit on timer
+	MaxSubscribers int
+}
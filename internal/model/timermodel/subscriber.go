@@ -0,0 +1,69 @@
+package timermodel
+
+import (
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/validate"
+)
+
+// max value of timer subscribers limit
+const MaxSubscribersLimit = 100000
+
+type Subscriber struct {
+	UserId       int64             `json:"userId"`
+	SubscribedAt amidtime.DateTime `json:"subscribedAt"`
+}
+
+// limit of timer subscribers, creator isn't counted
+type SubscribersLimit struct {
+	// 0 is unlimited
+	MaxSubscribers int `json:"maxSubscribers"`
+	// current count of subscribers, ignored in requests
+	Count int `json:"count"`
+}
+
+func (l *SubscribersLimit) Validate() error {
+	if l.MaxSubscribers < 0 || l.MaxSubscribers > MaxSubscribersLimit {
+		return timererror.ExceptionWrongSubscribersLimit()
+	}
+	return nil
+}
+
+// true if one more user can subscribe
+func (l *SubscribersLimit) Allows() bool {
+	return l.MaxSubscribers == 0 || l.Count < l.MaxSubscribers
+}
+
+// sound picked by subscriber instead of timer sound
+type SubscriberSound struct {
+	SoundId timerfields.SoundId `json:"soundId"`
+	// max volume if empty
+	Volume timerfields.Volume `json:"volume"`
+}
+
+// subscriber sound from nullable columns, nil if subscriber plays timer sound
+func NewSubscriberSound(soundId *timerfields.SoundId, volume *timerfields.Volume) *SubscriberSound {
+	if soundId == nil {
+		return nil
+	}
+	sound := &SubscriberSound{SoundId: *soundId, Volume: timerfields.MaxVolume}
+	if volume != nil {
+		sound.Volume = *volume
+	}
+	return sound
+}
+
+func (s *SubscriberSound) Validate() error {
+	if s.Volume == 0 {
+		s.Volume = timerfields.MaxVolume
+	}
+	if s.SoundId == 0 {
+		return timererror.ExceptionSoundNotFound()
+	}
+	return validate.ValidateFields(s)
+}
+
+func (s *SubscriberSound) ValidatableVariables() []validate.Validatable {
+	return []validate.Validatable{s.SoundId, s.Volume}
+}
@@ -19,9 +19,32 @@ type Timer struct {
 	Name        timerfields.Name        `json:"name"`
 	Description timerfields.Description `json:"description"`
 	Color       timerfields.Color       `json:"color"`
-	WithMusic   bool                    `json:"withMusic"`
-	Duration    int64                   `json:"duration"`
-	IsPaused    bool                    `json:"isPaused,omitempty"`
+	// custom color shown instead of palette color by clients which know custom colors
+	CustomColor *timerfields.CustomColor `json:"customColor,omitempty"`
+	// true if timer has sound, kept for clients which don't know sounds
+	WithMusic bool `json:"withMusic"`
+	// sound of alarm from sounds catalog, empty if timer is silent
+	SoundId timerfields.SoundId `json:"soundId,omitempty"`
+	Volume  timerfields.Volume  `json:"volume"`
+	// sound picked by user who requested timer, played instead of timer sound
+	SubscriberSound *SubscriberSound `json:"subscriberSound,omitempty"`
+	Duration        int64            `json:"duration"`
+	IsPaused        bool             `json:"isPaused,omitempty"`
+	// count of subscribers except creator
+	SubscribersCount int `json:"subscribersCount"`
+	// relation of user who requested timer, empty if timer isn't requested by user
+	Relation     timerfields.Relation `json:"relation,omitempty"`
+	SubscribedAt amidtime.DateTime    `json:"subscribedAt"`
+	// incremented by every change of timer, returned as ETag
+	Version int64 `json:"version"`
+	// IANA time zone of timer
+	TimeZone timerfields.TimeZone `json:"timeZone"`
+	// current offset of time zone in seconds, DST is included
+	Offset int `json:"offset"`
+	// folder, pin and tags of user who requested timer, private for this user
+	FolderId *uuid.UUID `json:"folderId,omitempty"`
+	IsPinned bool       `json:"isPinned,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
 }
 
 func NewTimer(
@@ -54,12 +77,17 @@ func NewTimer(
 }
 
 func (t *Timer) ValidatableVariables() []validate.Validatable {
-	return []validate.Validatable{t.Description, t.Name, t.Color, t.Type}
+	return []validate.Validatable{t.Description, t.Name, t.Color, t.CustomColor, t.Type}
 }
 
 func (t *Timer) CreateTimer() *CreateTimer {
 	startTime := time.Unix(t.EndTime.T().Unix()-t.Duration, 0)
-	return NewCreateTimer(t.ID, t.UTC, amidtime.DateTime(startTime), t.EndTime, t.Type, t.Name, t.Description, t.Color, t.WithMusic)
+	timer := NewCreateTimer(t.ID, t.UTC, amidtime.DateTime(startTime), t.EndTime, t.Type, t.Name, t.Description, t.Color, t.WithMusic)
+	timer.TimeZone = t.TimeZone
+	timer.CustomColor = t.CustomColor
+	timer.SoundId = t.SoundId
+	timer.Volume = t.Volume
+	return timer
 }
 
 func (t *Timer) Is(target *Timer) (string, bool) {
@@ -90,6 +118,9 @@ func (t *Timer) Is(target *Timer) (string, bool) {
 	if t.WithMusic != target.WithMusic {
 		return "withmusic", false
 	}
+	if t.SoundId != target.SoundId {
+		return "sound", false
+	}
 	if t.Duration != target.Duration {
 		return "duration", false
 	}
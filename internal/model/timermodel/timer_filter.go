@@ -0,0 +1,24 @@
+package timermodel
+
+import "github.com/google/uuid"
+
+// list of user timers
+type TimerList string
+
+const (
+	// created timers and subscriptions
+	AllTimers     TimerList = "all"
+	CreatedTimers TimerList = "created"
+	Subscriptions TimerList = "subscriptions"
+)
+
+// filter of user timer lists, empty folder and tag are skipped
+type TimerFilter struct {
+	List     TimerList
+	FolderId *uuid.UUID
+	Tag      string
+}
+
+func (f *TimerFilter) Empty() bool {
+	return f.FolderId == nil && f.Tag == ""
+}
@@ -0,0 +1,118 @@
+package timermodel
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/Tap-Team/timerapi/pkg/validate"
+)
+
+// RFC 7386 merge patch of timer settings, nil fields are not changed
+type TimerPatch struct {
+	Name        *timerfields.Name        `json:"name,omitempty"`
+	Description *timerfields.Description `json:"description,omitempty"`
+	Color       *timerfields.Color       `json:"color,omitempty"`
+	// custom color with empty hex clears custom color
+	CustomColor *timerfields.CustomColor `json:"customColor,omitempty"`
+	WithMusic   *bool                    `json:"withMusic,omitempty"`
+	// sound 0 makes timer silent, withMusic is set by sound
+	SoundId *timerfields.SoundId `json:"soundId,omitempty"`
+	Volume  *timerfields.Volume  `json:"volume,omitempty"`
+	EndTime *amidtime.DateTime   `json:"endTime,omitempty"`
+}
+
+// parse merge patch, null name, description, custom color or sound clears it, null of other fields and unknown fields are not allowed
+func ParseTimerPatch(data []byte) (*TimerPatch, error) {
+	fields := make(map[string]json.RawMessage)
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, errors.Join(err, timererror.ExceptionWrongPatch())
+	}
+	patch := new(TimerPatch)
+	for field, value := range fields {
+		null := string(value) == "null"
+		var dst any
+		switch field {
+		case "name":
+			patch.Name = new(timerfields.Name)
+			dst = patch.Name
+		case "description":
+			patch.Description = new(timerfields.Description)
+			dst = patch.Description
+		case "color":
+			patch.Color = new(timerfields.Color)
+			dst = patch.Color
+		case "customColor":
+			patch.CustomColor = new(timerfields.CustomColor)
+			dst = patch.CustomColor
+		case "withMusic":
+			patch.WithMusic = new(bool)
+			dst = patch.WithMusic
+		case "soundId":
+			patch.SoundId = new(timerfields.SoundId)
+			dst = patch.SoundId
+		case "volume":
+			patch.Volume = new(timerfields.Volume)
+			dst = patch.Volume
+		case "endTime":
+			patch.EndTime = new(amidtime.DateTime)
+			dst = patch.EndTime
+		default:
+			return nil, timererror.ExceptionWrongPatch()
+		}
+		if null {
+			if field == "name" || field == "description" || field == "customColor" || field == "soundId" {
+				continue
+			}
+			return nil, timererror.ExceptionWrongPatch()
+		}
+		err = json.Unmarshal(value, dst)
+		if err != nil {
+			return nil, errors.Join(err, timererror.ExceptionWrongPatch())
+		}
+	}
+	return patch, nil
+}
+
+func (p *TimerPatch) Empty() bool {
+	return p.Name == nil && p.Description == nil && p.Color == nil && p.CustomColor == nil && p.WithMusic == nil && p.SoundId == nil && p.Volume == nil && p.EndTime == nil
+}
+
+func (p *TimerPatch) ValidatableVariables() []validate.Validatable {
+	variables := make([]validate.Validatable, 0, 3)
+	if p.Color != nil {
+		variables = append(variables, *p.Color)
+	}
+	// empty hex clears custom color
+	if p.CustomColor != nil && p.CustomColor.Hex != "" {
+		variables = append(variables, p.CustomColor)
+	}
+	if p.Name != nil {
+		variables = append(variables, *p.Name)
+	}
+	if p.Description != nil {
+		variables = append(variables, *p.Description)
+	}
+	if p.SoundId != nil {
+		variables = append(variables, *p.SoundId)
+	}
+	if p.Volume != nil {
+		variables = append(variables, *p.Volume)
+	}
+	return variables
+}
+
+func (p *TimerPatch) Validate() error {
+	// withMusic is set by sound, conflicting withMusic isn't allowed
+	if p.SoundId != nil {
+		withMusic := *p.SoundId != 0
+		if p.WithMusic != nil && *p.WithMusic != withMusic {
+			return timererror.ExceptionWrongPatch()
+		}
+		p.WithMusic = &withMusic
+	}
+	return validate.ValidateFields(p)
+}
@@ -9,9 +9,16 @@ import (
 type TimerSettings struct {
 	Name        timerfields.Name        `json:"name"`
 	Description timerfields.Description `json:"description"`
-	Color       timerfields.Color       `json:"color"`
-	WithMusic   bool                    `json:"withMusic"`
-	EndTime     amidtime.DateTime       `json:"endTime"`
+	// palette color, DEFAULT if empty and custom color is set
+	Color timerfields.Color `json:"color"`
+	// custom color isn't changed if it is null, it is cleared by merge patch
+	CustomColor *timerfields.CustomColor `json:"customColor,omitempty"`
+	// sound of timer with music isn't changed if soundId is empty, default sound is set if timer had no sound
+	WithMusic bool                `json:"withMusic"`
+	SoundId   timerfields.SoundId `json:"soundId,omitempty"`
+	// volume isn't changed if it is empty
+	Volume  timerfields.Volume `json:"volume,omitempty"`
+	EndTime amidtime.DateTime  `json:"endTime"`
 }
 
 func NewTimerSettings(
@@ -31,9 +38,19 @@ func NewTimerSettings(
 }
 
 func (t *TimerSettings) ValidatableVariables() []validate.Validatable {
-	return []validate.Validatable{t.Color, t.Name, t.Description}
+	variables := []validate.Validatable{t.Color, t.CustomColor, t.Name, t.Description, t.SoundId}
+	if t.Volume != 0 {
+		variables = append(variables, t.Volume)
+	}
+	return variables
 }
 
 func (t *TimerSettings) Validate() error {
+	if t.Color == "" && t.CustomColor != nil {
+		t.Color = timerfields.DEFAULT
+	}
+	if t.SoundId != 0 {
+		t.WithMusic = true
+	}
 	return validate.ValidateFields(t)
 }
@@ -1,9 +1,17 @@
 package timerfields
 
-import "github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+import (
+	"database/sql/driver"
+	"regexp"
 
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/pkg/amidstr"
+)
+
+// name of palette color, palette is stored in colors table
 type Color string
 
+// colors of first palette, DEFAULT is shown by clients which don't know custom colors
 const (
 	DEFAULT Color = "DEFAULT"
 	RED     Color = "RED"
@@ -13,11 +21,72 @@ const (
 	YELLOW  Color = "YELLOW"
 )
 
+// max size of color name column
+const ColorMaxSize = 30
+
+// static check of color name, color is looked up in palette by color use case
 func (c Color) Validate() error {
-	for _, clr := range []Color{DEFAULT, RED, GREEN, BLUE, PURPLE, YELLOW} {
-		if c == clr {
-			return nil
-		}
+	if len(c) == 0 || len(c) > ColorMaxSize {
+		return timererror.ExceptionColorNotFound()
+	}
+	return nil
+}
+
+var hexColorRegexp = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// rgb color in hex, e.g. #FF8800
+type HexColor string
+
+func (h HexColor) Validate() error {
+	if !hexColorRegexp.MatchString(string(h)) {
+		return timererror.ExceptionWrongColor()
+	}
+	return nil
+}
+
+func (h *HexColor) Scan(src any) error {
+	return amidstr.ScanNullString((*string)(h), src)
+}
+
+func (h HexColor) Value() (driver.Value, error) {
+	if len(h) == 0 {
+		return nil, nil
+	}
+	return string(h), nil
+}
+
+// custom color of timer, gradient is end color of linear gradient which starts with hex
+type CustomColor struct {
+	Hex      HexColor `json:"hex"`
+	Gradient HexColor `json:"gradient,omitempty"`
+}
+
+// nil custom color is valid
+func (c *CustomColor) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := c.Hex.Validate(); err != nil {
+		return err
+	}
+	if c.Gradient == "" {
+		return nil
+	}
+	return c.Gradient.Validate()
+}
+
+// custom color from nullable columns, nil if hex is empty
+func NewCustomColor(hex, gradient HexColor) *CustomColor {
+	if hex == "" {
+		return nil
+	}
+	return &CustomColor{Hex: hex, Gradient: gradient}
+}
+
+// nullable columns of custom color, empty values of nil custom color
+func (c *CustomColor) Columns() (HexColor, HexColor) {
+	if c == nil {
+		return "", ""
 	}
-	return timererror.ExceptionColorNotFound()
+	return c.Hex, c.Gradient
 }
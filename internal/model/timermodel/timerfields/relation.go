@@ -0,0 +1,10 @@
+package timerfields
+
+// relation of user to timer
+type Relation string
+
+const (
+	OWNER      Relation = "owner"
+	SUBSCRIBER Relation = "subscriber"
+	NONE       Relation = "none"
+)
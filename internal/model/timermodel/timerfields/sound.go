@@ -0,0 +1,43 @@
+package timerfields
+
+import (
+	"database/sql/driver"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+)
+
+// id of sound from sounds catalog, 0 is no sound
+type SoundId int16
+
+// sound which was the only sound of timers with music
+const DefaultSound SoundId = 1
+
+// existence of sound is checked by storage
+func (s SoundId) Validate() error {
+	if s < 0 {
+		return timererror.ExceptionSoundNotFound()
+	}
+	return nil
+}
+
+func (s SoundId) Value() (driver.Value, error) {
+	if s == 0 {
+		return nil, nil
+	}
+	return int64(s), nil
+}
+
+// volume of sound in percents
+type Volume int16
+
+const (
+	MinVolume Volume = 1
+	MaxVolume Volume = 100
+)
+
+func (v Volume) Validate() error {
+	if v < MinVolume || v > MaxVolume {
+		return timererror.ExceptionWrongVolume()
+	}
+	return nil
+}
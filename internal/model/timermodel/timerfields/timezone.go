@@ -0,0 +1,80 @@
+package timerfields
+
+import (
+	"fmt"
+	"time"
+	// tz database is embedded, alpine image doesn't have tzdata
+	_ "time/tzdata"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+)
+
+const (
+	TimeZoneMaxSize = 64
+	// legacy offsets in hours which have Etc/GMT zone
+	minEtcOffset = -12
+	maxEtcOffset = 14
+)
+
+// IANA time zone name, e.g. Europe/Berlin, empty zone is UTC
+type TimeZone string
+
+const UTCZone TimeZone = "UTC"
+
+// zone should exist in tz database
+func (z TimeZone) Validate() error {
+	if z == "" {
+		return nil
+	}
+	if len(z) > TimeZoneMaxSize {
+		return timererror.ExceptionWrongTimeZone()
+	}
+	if _, err := time.LoadLocation(string(z)); err != nil {
+		return timererror.ExceptionWrongTimeZone()
+	}
+	return nil
+}
+
+// location of zone, UTC if zone is empty or unknown
+func (z TimeZone) Location() *time.Location {
+	if z == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(string(z))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// offset of zone in seconds at time t, DST is included
+func (z TimeZone) Offset(t time.Time) int {
+	_, offset := t.In(z.Location()).Zone()
+	return offset
+}
+
+// utc offset of zone in hours at time t, stored offset of legacy timer is kept for UTC zone
+// offset of zone with DST changes, so stored offset is stale after DST transition
+func (z TimeZone) UTC(t time.Time, stored int16) int16 {
+	if z == "" || z == UTCZone {
+		return stored
+	}
+	return int16(z.Offset(t) / 3600)
+}
+
+// legacy utc offset in hours should be in range of Etc/GMT zones
+func ValidateUTC(utc int16) error {
+	if utc < minEtcOffset || utc > maxEtcOffset {
+		return timererror.ExceptionWrongTimeZone()
+	}
+	return nil
+}
+
+// zone of legacy utc offset in hours, offsets without Etc/GMT zone are UTC
+// sign of Etc/GMT zones is inverted, UTC+3 is Etc/GMT-3
+func TimeZoneFromUTC(utc int16) TimeZone {
+	if utc == 0 || utc < minEtcOffset || utc > maxEtcOffset {
+		return UTCZone
+	}
+	return TimeZone(fmt.Sprintf("Etc/GMT%+d", -utc))
+}
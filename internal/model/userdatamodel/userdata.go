@@ -0,0 +1,34 @@
+package userdatamodel
+
+import (
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+)
+
+// archive of all data tied to user
+type Archive struct {
+	UserId     int64             `json:"userId"`
+	ExportedAt amidtime.DateTime `json:"exportedAt"`
+	// timers created by user
+	Timers []*timermodel.Timer `json:"timers"`
+	// timers of other users which user subscribed to
+	Subscriptions []*timermodel.Timer `json:"subscriptions"`
+	// unread notifications
+	Notifications []*notification.NotificationDTO `json:"notifications"`
+	// webhooks without secrets
+	Webhooks []*webhookmodel.Webhook `json:"webhooks"`
+	// calendar feed, nil if user never requested feed
+	CalendarFeed *calendarmodel.Feed `json:"calendarFeed,omitempty"`
+}
+
+// erasure of user data, erasure is executed by steps and can be continued after failure
+type Erasure struct {
+	UserId      int64             `json:"userId"`
+	RequestedAt amidtime.DateTime `json:"requestedAt"`
+	Completed   bool              `json:"completed"`
+	// zero while erasure isn't completed
+	CompletedAt amidtime.DateTime `json:"completedAt"`
+}
@@ -0,0 +1,139 @@
+package webhookmodel
+
+import (
+	"net"
+	"net/url"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/webhookerror"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
+	"github.com/Tap-Team/timerapi/pkg/amidtime"
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	Expire EventType = "expire"
+	Delete EventType = "delete"
+	Update EventType = "update"
+	Stop   EventType = "stop"
+	Start  EventType = "start"
+	Reset  EventType = "reset"
+	Adjust EventType = "adjust"
+)
+
+var eventTypes = []EventType{Expire, Delete, Update, Stop, Start, Reset, Adjust}
+
+func (e EventType) Validate() error {
+	for _, tp := range eventTypes {
+		if tp == e {
+			return nil
+		}
+	}
+	return webhookerror.ExceptionWrongEvent()
+}
+
+// webhook event type of timer event, false if event should not be sent to webhooks
+func TimerEventType(event timerevent.TimerEvent) (EventType, bool) {
+	switch event.Type() {
+	case timerevent.Update:
+		return Update, true
+	case timerevent.Stop:
+		return Stop, true
+	case timerevent.Start:
+		return Start, true
+	case timerevent.Reset:
+		return Reset, true
+	case timerevent.Adjust:
+		return Adjust, true
+	default:
+		return "", false
+	}
+}
+
+// webhook event type of notification, false if notification should not be sent to webhooks
+func NotificationEventType(n notification.Notification) (EventType, bool) {
+	switch n.Type() {
+	case notification.Expired:
+		return Expire, true
+	case notification.Delete:
+		return Delete, true
+	default:
+		return "", false
+	}
+}
+
+type Webhook struct {
+	ID           uuid.UUID         `json:"id"`
+	UserId       int64             `json:"userId"`
+	URL          string            `json:"url"`
+	Secret       string            `json:"secret,omitempty"`
+	Events       []EventType       `json:"events"`
+	IsEnabled    bool              `json:"isEnabled"`
+	FailureCount int               `json:"failureCount"`
+	CreatedAt    amidtime.DateTime `json:"createdAt"`
+}
+
+func (w *Webhook) Subscribed(event EventType) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateWebhook struct {
+	URL    string      `json:"url"`
+	Events []EventType `json:"events"`
+}
+
+// shared address space of carrier-grade NAT, not covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// webhooks are sent only to public addresses, so users can't reach services inside of our network
+func PublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+func (w *CreateWebhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return webhookerror.ExceptionWrongURL()
+	}
+	// host names are resolved and checked on create and on every delivery
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !PublicIP(ip) {
+		return webhookerror.ExceptionWrongURL()
+	}
+	if len(w.Events) == 0 {
+		return webhookerror.ExceptionWrongEvent()
+	}
+	for _, e := range w.Events {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// one attempt of payload delivery
+type Delivery struct {
+	ID         uuid.UUID         `json:"id"`
+	WebhookId  uuid.UUID         `json:"webhookId"`
+	Event      EventType         `json:"event"`
+	Attempt    int               `json:"attempt"`
+	StatusCode int               `json:"statusCode"`
+	Error      string            `json:"error,omitempty"`
+	IsSuccess  bool              `json:"isSuccess"`
+	CreatedAt  amidtime.DateTime `json:"createdAt"`
+}
+
+// body of webhook request
+type Payload struct {
+	ID        uuid.UUID `json:"id"`
+	Event     EventType `json:"event"`
+	TimerId   uuid.UUID `json:"timerId"`
+	CreatedAt int64     `json:"createdAt"`
+	Data      any       `json:"data"`
+}
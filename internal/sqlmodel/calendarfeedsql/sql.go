@@ -0,0 +1,35 @@
+package calendarfeedsql
+
+/*
+create table if not exists calendar_feeds (
+    user_id bigint not null,
+    token varchar(64) not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint calendar_feeds_key primary key (user_id),
+    constraint calendar_feeds_token_unique unique (token)
+);
+*/
+
+const Table = "calendar_feeds"
+
+type calendar_feed_column string
+
+func (c calendar_feed_column) String() string {
+	return string(c)
+}
+
+func (c calendar_feed_column) Table() string {
+	return Table
+}
+
+const (
+	UserId    calendar_feed_column = "user_id"
+	Token     calendar_feed_column = "token"
+	CreatedAt calendar_feed_column = "created_at"
+)
+
+const (
+	PrimaryKey  = "calendar_feeds_key"
+	TokenUnique = "calendar_feeds_token_unique"
+)
@@ -4,6 +4,9 @@ package colorsql
 create table if not exists colors (
     id smallserial primary key,
     color varchar(30) not null,
+    hex varchar(7) not null default '#818C99',
+    gradient varchar(7),
+    position smallint not null default 0,
 
     constraint colors_unique unique (color)
 );
@@ -24,6 +27,12 @@ func (c colors_column) String() string {
 const (
 	ID    colors_column = "id"
 	Color colors_column = "color"
+	// rgb of color for clients, e.g. #3F8AE0
+	Hex colors_column = "hex"
+	// end color of linear gradient, NULL if color is solid
+	Gradient colors_column = "gradient"
+	// order of color in palette
+	Position colors_column = "position"
 )
 
 const (
@@ -0,0 +1,33 @@
+package erasuresql
+
+/*
+create table if not exists user_erasures (
+    user_id bigint not null,
+    requested_at timestamp(0) not null default now(),
+    completed_at timestamp(0) default null,
+
+    constraint user_erasures_key primary key (user_id)
+);
+*/
+
+const Table = "user_erasures"
+
+type erasure_column string
+
+func (c erasure_column) String() string {
+	return string(c)
+}
+
+func (c erasure_column) Table() string {
+	return Table
+}
+
+const (
+	UserId      erasure_column = "user_id"
+	RequestedAt erasure_column = "requested_at"
+	CompletedAt erasure_column = "completed_at"
+)
+
+const (
+	PrimaryKey = "user_erasures_key"
+)
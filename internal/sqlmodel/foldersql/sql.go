@@ -0,0 +1,38 @@
+package foldersql
+
+/*
+create table if not exists folders (
+    id uuid not null,
+    user_id bigint not null,
+    name varchar(60) not null,
+    position integer not null default 0,
+    created_at timestamp(0) not null default now(),
+
+    constraint folders_key primary key (id)
+);
+*/
+
+const Table = "folders"
+
+type folder_column string
+
+func (f folder_column) String() string {
+	return string(f)
+}
+
+func (f folder_column) Table() string {
+	return Table
+}
+
+const (
+	ID       folder_column = "id"
+	UserId   folder_column = "user_id"
+	Name     folder_column = "name"
+	Position folder_column = "position"
+	// time of folder creation
+	CreatedAt folder_column = "created_at"
+)
+
+const (
+	PrimaryKey = "folders_key"
+)
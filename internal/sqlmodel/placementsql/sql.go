@@ -0,0 +1,43 @@
+package placementsql
+
+/*
+create table if not exists timer_placements (
+    timer_id uuid not null,
+    user_id bigint not null,
+    folder_id uuid,
+    position integer,
+    is_pinned boolean not null default false,
+
+    constraint fk_timer_placements__timer_subcribers foreign key (timer_id, user_id) references timer_subcribers(timer_id, user_id) on delete cascade,
+    constraint fk_timer_placements__folders foreign key (folder_id) references folders(id) on delete set null,
+
+    constraint timer_placements_key primary key (timer_id, user_id)
+);
+*/
+
+const Table = "timer_placements"
+
+type placement_column string
+
+func (p placement_column) String() string {
+	return string(p)
+}
+
+func (p placement_column) Table() string {
+	return Table
+}
+
+const (
+	TimerId  placement_column = "timer_id"
+	UserId   placement_column = "user_id"
+	FolderId placement_column = "folder_id"
+	// manual order of timer, NULL if timer isn't ordered
+	Position placement_column = "position"
+	IsPinned placement_column = "is_pinned"
+)
+
+const (
+	FK_Subscribers = "fk_timer_placements__timer_subcribers"
+	FK_Folders     = "fk_timer_placements__folders"
+	PrimaryKey     = "timer_placements_key"
+)
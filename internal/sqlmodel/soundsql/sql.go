@@ -0,0 +1,34 @@
+package soundsql
+
+/*
+create table if not exists sounds (
+    id smallserial primary key,
+    title varchar(60) not null,
+    duration integer not null,
+    url varchar(255) not null,
+    position smallint not null default 0
+);
+*/
+
+const Table = "sounds"
+
+type sound_column string
+
+func (s sound_column) String() string {
+	return string(s)
+}
+
+func (s sound_column) Table() string {
+	return Table
+}
+
+const (
+	ID    sound_column = "id"
+	Title sound_column = "title"
+	// duration of sound in seconds
+	Duration sound_column = "duration"
+	// path of asset on static host of app
+	URL sound_column = "url"
+	// order of sound in catalog
+	Position sound_column = "position"
+)
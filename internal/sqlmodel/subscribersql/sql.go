@@ -26,9 +26,15 @@ func (s subcriber_column) Table() string {
 const (
 	TimerId subcriber_column = "timer_id"
 	UserId  subcriber_column = "user_id"
+	// time of subscription
+	CreatedAt subcriber_column = "created_at"
+	// sound picked by subscriber instead of timer sound, NULL if timer sound is played
+	SoundId subcriber_column = "sound_id"
+	Volume  subcriber_column = "volume"
 )
 
 const (
 	FK_Timers  = "fk_timer_subcribers__timers"
+	FK_Sounds  = "fk_timer_subcribers__sounds"
 	PrimaryKey = "timer_subcribers_key"
 )
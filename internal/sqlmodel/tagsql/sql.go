@@ -0,0 +1,36 @@
+package tagsql
+
+/*
+create table if not exists timer_tags (
+    timer_id uuid not null,
+    user_id bigint not null,
+    tag varchar(32) not null,
+
+    constraint fk_timer_tags__timer_subcribers foreign key (timer_id, user_id) references timer_subcribers(timer_id, user_id) on delete cascade,
+
+    constraint timer_tags_key primary key (timer_id, user_id, tag)
+);
+*/
+
+const Table = "timer_tags"
+
+type tag_column string
+
+func (t tag_column) String() string {
+	return string(t)
+}
+
+func (t tag_column) Table() string {
+	return Table
+}
+
+const (
+	TimerId tag_column = "timer_id"
+	UserId  tag_column = "user_id"
+	Tag     tag_column = "tag"
+)
+
+const (
+	FK_Subscribers = "fk_timer_tags__timer_subcribers"
+	PrimaryKey     = "timer_tags_key"
+)
@@ -0,0 +1,36 @@
+package timerbansql
+
+/*
+create table if not exists timer_bans (
+    timer_id uuid not null,
+    user_id bigint not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint fk_timer_bans__timers foreign key (timer_id) references timers(id) on delete cascade,
+
+    constraint timer_bans_key primary key (timer_id, user_id)
+);
+*/
+
+const Table = "timer_bans"
+
+type timer_ban_column string
+
+func (c timer_ban_column) String() string {
+	return string(c)
+}
+
+func (c timer_ban_column) Table() string {
+	return Table
+}
+
+const (
+	TimerId   timer_ban_column = "timer_id"
+	UserId    timer_ban_column = "user_id"
+	CreatedAt timer_ban_column = "created_at"
+)
+
+const (
+	FK_Timers  = "fk_timer_bans__timers"
+	PrimaryKey = "timer_bans_key"
+)
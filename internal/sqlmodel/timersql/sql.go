@@ -50,11 +50,27 @@ const (
 	Duration    timer_column = "duration"
 	IsDeleted   timer_column = "is_deleted"
 	CreatedAt   timer_column = "created_at"
+	// max count of subscribers except creator, 0 is unlimited
+	MaxSubscribers timer_column = "max_subscribers"
+	// count of subscribers except creator, kept by timer_subcribers trigger
+	SubscribersCount timer_column = "subscribers_count"
+	// incremented by every mutation of timer, used for optimistic concurrency
+	Version timer_column = "version"
+	// IANA time zone name, utc is kept as offset in hours for old clients
+	TimeZone timer_column = "time_zone"
+	// custom hex color and gradient end, NULL if timer has only palette color
+	CustomColor    timer_column = "custom_color"
+	CustomGradient timer_column = "custom_gradient"
+	// sound of alarm, NULL if timer is silent, with_music is true if sound is set
+	SoundId timer_column = "sound_id"
+	// volume of sound in percents
+	Volume timer_column = "volume"
 )
 
 const (
 	FK_Colors  = "fk_timers__colors"
 	FK_Types   = "fk_timers__types"
 	FK_Status  = "fk_timers__timer_status"
+	FK_Sounds  = "fk_timers__sounds"
 	PrimaryKey = "timers_key"
 )
@@ -0,0 +1,46 @@
+package webhookdeliverysql
+
+/*
+create table if not exists webhook_deliveries (
+    id uuid not null,
+    webhook_id uuid not null,
+    event varchar(30) not null,
+    attempt int not null,
+    status_code int not null default 0,
+    error text,
+    is_success boolean not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint fk_webhook_deliveries__webhooks foreign key (webhook_id) references webhooks(id) on delete cascade,
+
+    constraint webhook_deliveries_key primary key (id, attempt)
+);
+*/
+
+const Table = "webhook_deliveries"
+
+type delivery_column string
+
+func (d delivery_column) String() string {
+	return string(d)
+}
+
+func (d delivery_column) Table() string {
+	return Table
+}
+
+const (
+	ID         delivery_column = "id"
+	WebhookId  delivery_column = "webhook_id"
+	Event      delivery_column = "event"
+	Attempt    delivery_column = "attempt"
+	StatusCode delivery_column = "status_code"
+	Error      delivery_column = "error"
+	IsSuccess  delivery_column = "is_success"
+	CreatedAt  delivery_column = "created_at"
+)
+
+const (
+	FK_Webhooks = "fk_webhook_deliveries__webhooks"
+	PrimaryKey  = "webhook_deliveries_key"
+)
@@ -0,0 +1,43 @@
+package webhooksql
+
+/*
+create table if not exists webhooks (
+    id uuid not null,
+    user_id bigint not null,
+    url text not null,
+    secret varchar(64) not null,
+    events varchar(30)[] not null,
+    is_enabled boolean not null default true,
+    failure_count int not null default 0,
+    created_at timestamp(0) not null default now(),
+
+    constraint webhooks_key primary key (id)
+);
+*/
+
+const Table = "webhooks"
+
+type webhook_column string
+
+func (w webhook_column) String() string {
+	return string(w)
+}
+
+func (w webhook_column) Table() string {
+	return Table
+}
+
+const (
+	ID           webhook_column = "id"
+	UserId       webhook_column = "user_id"
+	URL          webhook_column = "url"
+	Secret       webhook_column = "secret"
+	Events       webhook_column = "events"
+	IsEnabled    webhook_column = "is_enabled"
+	FailureCount webhook_column = "failure_count"
+	CreatedAt    webhook_column = "created_at"
+)
+
+const (
+	PrimaryKey = "webhooks_key"
+)
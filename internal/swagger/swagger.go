@@ -7,13 +7,13 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-//	@title			Timer API Swagger
-//	@version		1.0
-//	@termsOfService	http://swagger.io/terms/
-//	@license.name	Apache 2.0
-//	@license.url	http://www.apache.org/licenses/LICENSE-2.0.html
+// @title			Timer API Swagger
+// @version		1.0
+// @termsOfService	http://swagger.io/terms/
+// @license.name	Apache 2.0
+// @license.url	http://www.apache.org/licenses/LICENSE-2.0.html
 //
-//	@BasePath		/
+// @BasePath		/
 func New(e *echo.Echo, config config.SwaggerConfig) {
 	docs.SwaggerInfo.Host = config.Host()
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
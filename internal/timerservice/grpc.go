@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"sync/atomic"
+	"time"
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/Tap-Team/timerapi/proto/timerservicepb"
 	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -26,10 +29,23 @@ type TimerServiceClient interface {
 	TimerTick(ctx context.Context) (<-chan []uuid.UUID, error)
 }
 
+// grpc client with state of tick stream
+type StreamingClient interface {
+	TimerServiceClient
+	LastTick() (time.Time, error)
+}
+
 type timerServiceClientGrpc struct {
 	client timerservicepb.TimerServiceClient
+
+	// state of tick stream for health checks
+	streaming atomic.Bool
+	// unix nano of last receive from tick stream or of stream open
+	lastReceive atomic.Int64
 }
 
+var ErrTickStreamClosed = errors.New("tick stream is closed")
+
 func GrpcError(err error) error {
 	statusErr, ok := status.FromError(err)
 	if !ok {
@@ -111,6 +127,15 @@ func (c *timerServiceClientGrpc) TimerTick(ctx context.Context) (<-chan []uuid.U
 	return ch, nil
 }
 
+// time of last receive from tick stream, ErrTickStreamClosed if stream isn't open
+// ticks are received only when timers expire, so old receive doesn't mean stream is broken
+func (c *timerServiceClientGrpc) LastTick() (time.Time, error) {
+	if !c.streaming.Load() {
+		return time.Time{}, ErrTickStreamClosed
+	}
+	return time.Unix(0, c.lastReceive.Load()), nil
+}
+
 func (c *timerServiceClientGrpc) serviceStream(ctx context.Context) (<-chan []uuid.UUID, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	uuidChan := make(chan []uuid.UUID)
@@ -121,7 +146,10 @@ func (c *timerServiceClientGrpc) serviceStream(ctx context.Context) (<-chan []uu
 		cancel()
 		return nil, fmt.Errorf("error while listen timer tick, %w", err)
 	}
+	c.streaming.Store(true)
+	c.lastReceive.Store(time.Now().UnixNano())
 	go func() {
+		defer c.streaming.Store(false)
 		// in loop receive values
 	Loop:
 		for {
@@ -136,10 +164,11 @@ func (c *timerServiceClientGrpc) serviceStream(ctx context.Context) (<-chan []uu
 					break Loop
 				}
 				if err != nil {
-					log.Printf("\nerror while receive event from timerservice, %s", err)
+					slog.Error("error while receive event from timerservice", logging.Err(err))
 					cancel()
 					break Loop
 				}
+				c.lastReceive.Store(time.Now().UnixNano())
 				// make list of uuid to send it to chan
 				uuids := make([]uuid.UUID, 0, len(event.GetIds()))
 				for _, b := range event.GetIds() {
@@ -0,0 +1,57 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/config"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		config config.TracingConfig
+		err    bool
+	}{
+		{config: config.TracingConfig{}},
+		{config: config.TracingConfig{Exporter: tracing.ExporterNone}},
+		{config: config.TracingConfig{Exporter: tracing.ExporterStdout}},
+		{config: config.TracingConfig{Exporter: "jaeger"}, err: true},
+	}
+	for _, cs := range cases {
+		shutdown, err := tracing.Setup(ctx, cs.config)
+		if cs.err {
+			require.Error(t, err, "unknown exporter accepted, %s", cs.config.Exporter)
+			continue
+		}
+		require.NoError(t, err, "setup failed, %s", cs.config.Exporter)
+		require.NoError(t, shutdown(ctx), "shutdown failed, %s", cs.config.Exporter)
+	}
+}
+
+func TestRatio(t *testing.T) {
+	require.Equal(t, float64(0), config.TracingConfig{}.Ratio(), "wrong zero ratio")
+	require.Equal(t, float64(0), config.TracingConfig{SampleRatio: -1}.Ratio(), "wrong ratio below 0")
+	require.Equal(t, float64(1), config.TracingConfig{SampleRatio: 2}.Ratio(), "wrong ratio above 1")
+	require.Equal(t, 0.25, config.TracingConfig{SampleRatio: 0.25}.Ratio(), "wrong ratio")
+}
+
+func TestRatioFromFile(t *testing.T) {
+	cases := []struct {
+		file  string
+		ratio float64
+	}{
+		{file: "tracing:\n  exporter: none\n", ratio: config.DefaultSampleRatio},
+		{file: "tracing:\n  sample_ratio: 0\n", ratio: 0},
+		{file: "tracing:\n  sample_ratio: 0.5\n", ratio: 0.5},
+	}
+	for _, cs := range cases {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(cs.file), 0o600), "write config failed")
+		require.Equal(t, cs.ratio, config.FromFile(path).Tracing.Ratio(), "wrong ratio of %q", cs.file)
+	}
+}
@@ -0,0 +1,83 @@
+package botcallback
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SevereCloud/vksdk/v2/object"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/google/uuid"
+)
+
+const (
+	Restart     = "restart"
+	Unsubscribe = "unsubscribe"
+	Snooze      = "snooze"
+
+	// text command of message handlers, show user timers
+	timersCommand = "timers"
+
+	SnoozeDuration = time.Minute * 10
+)
+
+// payload of callback buttons, vk allow at most 255 bytes
+// UserId is id of user who received notification, only he can press the button
+type Payload struct {
+	Command string    `json:"command"`
+	TimerId uuid.UUID `json:"timerId"`
+	UserId  int64     `json:"userId"`
+}
+
+func ParsePayload(data []byte) (Payload, error) {
+	var p Payload
+	err := json.Unmarshal(data, &p)
+	return p, err
+}
+
+// answer on callback button, show snackbar with text to user
+type snackbar struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func SnackbarEventData(text string) string {
+	b, _ := json.Marshal(snackbar{Type: "show_snackbar", Text: text})
+	return string(b)
+}
+
+// inline keyboard attached to notification message for user, return nil if notification has no actions
+func Keyboard(n notification.Notification, userId int64) *object.MessagesKeyboard {
+	switch n.Type() {
+	case notification.Expired:
+		return expiredKeyboard(n, userId)
+	case notification.Delete, notification.Removed:
+		k := object.NewMessagesKeyboardInline()
+		k.AddRow()
+		k.AddTextButton("Мои таймеры", map[string]string{"command": timersCommand}, object.Primary)
+		return k
+	default:
+		return nil
+	}
+}
+
+func expiredKeyboard(n notification.Notification, userId int64) *object.MessagesKeyboard {
+	timer := n.Timer()
+	payload := func(command string) Payload {
+		return Payload{Command: command, TimerId: timer.ID, UserId: userId}
+	}
+	k := object.NewMessagesKeyboardInline()
+	k.AddRow()
+	// expired countdown timer stay paused and can be restarted by creator or left by subscriber
+	// expired date timer is deleted, so only snooze is available
+	if timer.Type == timerfields.COUNTDOWN {
+		if timer.Creator == userId {
+			k.AddCallbackButton("Перезапустить", payload(Restart), object.Positive)
+		} else {
+			k.AddCallbackButton("Отписаться", payload(Unsubscribe), object.Negative)
+		}
+		k.AddRow()
+	}
+	k.AddCallbackButton("Отложить на 10 минут", payload(Snooze), object.Secondary)
+	return k
+}
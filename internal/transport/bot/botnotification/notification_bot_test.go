@@ -10,6 +10,7 @@ import (
 	"github.com/Tap-Team/timerapi/internal/testdatamodule"
 	"github.com/Tap-Team/timerapi/internal/transport/bot/botnotification"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 )
 
 type FakeNotificationStream chan notification.NotificationSubscribers
@@ -62,4 +63,5 @@ func TestSend(t *testing.T) {
 	fakeStream <- timerSubs
 
 	bot.Run(ctx)
+	require.NoError(t, bot.Drain(context.Background()), "drain failed")
 }
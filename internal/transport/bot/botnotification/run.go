@@ -5,6 +5,7 @@ import (
 
 	"github.com/SevereCloud/vksdk/v2/api"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/pkg/graceful"
 )
 
 type MessageSender interface {
@@ -20,15 +21,18 @@ type NotificationStream interface {
 
 type NotificationBot interface {
 	Run(context.Context)
+	// wait for notifications which are being sent, should be called after Run returned
+	Drain(context.Context) error
 }
 
 type notificationBot struct {
 	sender             MessageSender
 	notificationStream NotificationStream
+	worker             *graceful.Worker
 }
 
 func New(sender MessageSender, notificationStream NotificationStream) NotificationBot {
-	return &notificationBot{sender: sender, notificationStream: notificationStream}
+	return &notificationBot{sender: sender, notificationStream: notificationStream, worker: graceful.NewWorker()}
 }
 
 func (b *notificationBot) Run(ctx context.Context) {
@@ -40,16 +44,40 @@ Loop:
 	for {
 		select {
 		case <-ctx.Done():
+			b.drainStream(stream.Stream())
 			break Loop
 		case n, ok := <-stream.Stream():
 			if !ok {
 				break Loop
 			}
-			go sendNotification(ctx, b.sender, n)
+			b.send(n)
 		}
 	}
 }
 
+func (b *notificationBot) Drain(ctx context.Context) error {
+	return b.worker.Drain(ctx)
+}
+
+// send notifications left in stream without blocking
+func (b *notificationBot) drainStream(stream <-chan notification.NotificationSubscribers) {
+	for {
+		select {
+		case n, ok := <-stream:
+			if !ok {
+				return
+			}
+			b.send(n)
+		default:
+			return
+		}
+	}
+}
+
+func (b *notificationBot) send(n notification.NotificationSubscribers) {
+	b.worker.Go(func(ctx context.Context) { sendNotification(ctx, b.sender, n) })
+}
+
 func sendNotification(ctx context.Context, sender MessageSender, n notification.NotificationSubscribers) {
 	for _, userId := range n.Subscribers() {
 		user := User(userId)
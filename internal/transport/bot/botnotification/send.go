@@ -7,14 +7,19 @@ import (
 	"math/rand"
 
 	"github.com/SevereCloud/vksdk/v2/api/params"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/metrics"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/transport/bot/botcallback"
 )
 
 type User int64
 
 func (u User) Send(ctx context.Context, sender MessageSender, n notification.Notification) {
+	ctx = logging.With(ctx, "user_id", int64(u), "timer_id", n.TimerId(), "notification_type", n.Type())
 	msg, err := message(n)
 	if err != nil {
+		logging.FromContext(ctx).Warn("bot message of notification not built", logging.Err(err))
 		return
 	}
 	b := params.NewMessagesSendBuilder()
@@ -22,7 +27,14 @@ func (u User) Send(ctx context.Context, sender MessageSender, n notification.Not
 	b.UserID(int(u))
 	b.Message(msg)
 	b.RandomID(rand.Int())
-	sender.MessagesSend(b.Params)
+	if keyboard := botcallback.Keyboard(n, int64(u)); keyboard != nil {
+		b.Keyboard(keyboard)
+	}
+	_, err = sender.MessagesSend(b.Params)
+	metrics.BotMessageSent(err)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed send bot notification", logging.Err(err))
+	}
 }
 
 func message(n notification.Notification) (string, error) {
@@ -31,6 +43,8 @@ func message(n notification.Notification) (string, error) {
 		return deleteMessage(n), nil
 	case notification.Expired:
 		return expiredMessage(n), nil
+	case notification.Removed:
+		return removedMessage(n), nil
 	default:
 		return "", errors.New("wrong notification type")
 	}
@@ -51,3 +65,11 @@ func expiredMessage(n notification.Notification) string {
 	}
 	return fmt.Sprintf(`Здравствуйте, уведомляю о том что таймер %s истёк`, name)
 }
+
+func removedMessage(n notification.Notification) string {
+	name := n.Timer().Name
+	if len(name) == 0 {
+		name = "Без названия"
+	}
+	return fmt.Sprintf(`Здравствуйте, уведомляю вас о том что создатель таймера %s удалил вас из подписчиков`, name)
+}
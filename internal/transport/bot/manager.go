@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"sync"
 
 	"github.com/SevereCloud/vksdk/v2/api"
 	"github.com/Tap-Team/timerapi/internal/transport/bot/botnotification"
@@ -9,26 +10,55 @@ import (
 )
 
 type manager struct {
-	vk *api.VK
+	vk                    *api.VK
+	timerUseCase          messagehandlers.TimerUseCase
+	countdownTimerUseCase messagehandlers.CountdownTimerUseCase
+	reminders             messagehandlers.ReminderStorage
+
+	mu    sync.Mutex
+	nbots []botnotification.NotificationBot
 }
 
 type Manager interface {
-	RunMessageHandlers()
+	RunMessageHandlers(ctx context.Context)
 	RunNotificationBot(ctx context.Context, nstream botnotification.NotificationStream)
+	// wait for notifications which are being sent by notification bots, should be called after ctx of bots is done
+	Drain(ctx context.Context) error
 }
 
-func NewManager(vk *api.VK) Manager {
-	return &manager{vk: vk}
+func NewManager(
+	vk *api.VK,
+	timerUseCase messagehandlers.TimerUseCase,
+	countdownTimerUseCase messagehandlers.CountdownTimerUseCase,
+	reminders messagehandlers.ReminderStorage,
+) Manager {
+	return &manager{vk: vk, timerUseCase: timerUseCase, countdownTimerUseCase: countdownTimerUseCase, reminders: reminders}
 }
 
 // blocking function, if you not need blocking of code run in new goroutine: go Manager.RunNotificationBot
 func (m *manager) RunNotificationBot(ctx context.Context, nstream botnotification.NotificationStream) {
 	nbot := botnotification.New(m.vk, nstream)
+	m.mu.Lock()
+	m.nbots = append(m.nbots, nbot)
+	m.mu.Unlock()
 	nbot.Run(ctx)
 }
 
+func (m *manager) Drain(ctx context.Context) error {
+	m.mu.Lock()
+	nbots := m.nbots
+	m.mu.Unlock()
+	for _, nbot := range nbots {
+		if err := nbot.Drain(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // blocking function, if you not need blocking of code run in new goroutine: go Manager.RunMessageHandlers
-func (m *manager) RunMessageHandlers() {
-	handler := messagehandlers.NewMain(m.vk)
-	handler.Handle()
+// returns when ctx is done and command which is being handled is finished
+func (m *manager) RunMessageHandlers(ctx context.Context) {
+	handler := messagehandlers.NewMain(m.vk, m.timerUseCase, m.countdownTimerUseCase, m.reminders)
+	handler.Handle(ctx)
 }
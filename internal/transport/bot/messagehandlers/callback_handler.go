@@ -0,0 +1,164 @@
+package messagehandlers
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/SevereCloud/vksdk/v2/api/params"
+	"github.com/SevereCloud/vksdk/v2/events"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/notification"
+	"github.com/Tap-Team/timerapi/internal/model/remindermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/transport/bot/botcallback"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const (
+	foreignButtonMessage = "Эта кнопка предназначена другому пользователю"
+	restartedMessage     = "Таймер перезапущен"
+	unsubscribedMessage  = "Вы отписались от таймера"
+	snoozedMessage       = "Напомню через 10 минут"
+)
+
+// how often due reminders are checked
+const remindersInterval = time.Second
+
+// max reminders which are sent at once
+const remindersBatchSize = 100
+
+// snoozed reminders are saved in storage so they survive restart of the app
+type ReminderStorage interface {
+	Schedule(ctx context.Context, reminder remindermodel.Reminder, due time.Time) error
+	TakeDue(ctx context.Context, now time.Time, limit int) ([]remindermodel.Reminder, error)
+}
+
+// blocking function, send due reminders until ctx is done
+func (r *Router) RunReminders(ctx context.Context) {
+	ticker := time.NewTicker(remindersInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.SendDueReminders(context.Background())
+		}
+	}
+}
+
+// take reminders with due time in the past and send them, reminder which failed to send is lost
+func (r *Router) SendDueReminders(ctx context.Context) {
+	for {
+		reminders, err := r.reminders.TakeDue(ctx, time.Now(), remindersBatchSize)
+		if err != nil {
+			logging.FromContext(ctx).Error("failed take due reminders", logging.Err(err))
+			return
+		}
+		for _, reminder := range reminders {
+			ctx := logging.With(ctx, "user_id", reminder.UserId, "timer_id", reminder.Timer.ID)
+			err := r.remind(ctx, reminder.UserId, reminder.Timer)
+			if err != nil {
+				logging.FromContext(ctx).Error("failed send snoozed reminder", logging.Err(err))
+			}
+		}
+		if len(reminders) < remindersBatchSize {
+			return
+		}
+	}
+}
+
+// long poll message_event handler, handle callback buttons of notification messages
+func (r *Router) MessageEvent(ctx context.Context, obj events.MessageEventObject) {
+	ctx = logging.With(ctx, "user_id", int64(obj.UserID))
+	payload, err := botcallback.ParsePayload(obj.Payload)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed unmarshal callback payload", "payload", string(obj.Payload), logging.Err(err))
+		return
+	}
+	ctx = logging.With(ctx, "timer_id", payload.TimerId, "command", payload.Command)
+	text, err := r.callback(ctx, int64(obj.UserID), payload)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed handle callback", logging.Err(err))
+		text = errorMessage(err)
+	}
+	err = r.answer(obj, text)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed answer callback", logging.Err(err))
+	}
+}
+
+func (r *Router) callback(ctx context.Context, userId int64, payload botcallback.Payload) (string, error) {
+	// button was sent to another user, for example message was forwarded
+	if payload.UserId != userId {
+		return foreignButtonMessage, nil
+	}
+	switch payload.Command {
+	case botcallback.Restart:
+		err := r.restart(ctx, payload.TimerId, userId)
+		if err != nil {
+			return "", exception.Wrap(err, exception.NewCause("restart timer", "callback", _PROVIDER))
+		}
+		return restartedMessage, nil
+	case botcallback.Unsubscribe:
+		err := r.timerUseCase.Unsubscribe(ctx, payload.TimerId, userId)
+		if err != nil {
+			return "", exception.Wrap(err, exception.NewCause("unsubscribe timer", "callback", _PROVIDER))
+		}
+		return unsubscribedMessage, nil
+	case botcallback.Snooze:
+		// fetch timer now, expired date timer will be deleted before reminder
+		timer, err := r.timerUseCase.Timer(ctx, payload.TimerId)
+		if err != nil || timer == nil {
+			timer = &timermodel.Timer{ID: payload.TimerId}
+		}
+		reminder := remindermodel.Reminder{UserId: userId, Timer: *timer}
+		err = r.reminders.Schedule(ctx, reminder, time.Now().Add(botcallback.SnoozeDuration))
+		if err != nil {
+			return "", exception.Wrap(err, exception.NewCause("schedule reminder", "callback", _PROVIDER))
+		}
+		return snoozedMessage, nil
+	default:
+		return failedMessage, nil
+	}
+}
+
+// expired countdown timer is paused on start position, reset it and start again
+func (r *Router) restart(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	timer, err := r.countdownTimerUseCase.Reset(ctx, timerId, userId, 0)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("reset timer", "restart", _PROVIDER))
+	}
+	if !timer.IsPaused {
+		return nil
+	}
+	_, err = r.countdownTimerUseCase.Start(ctx, timerId, userId, 0)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("start timer", "restart", _PROVIDER))
+	}
+	return nil
+}
+
+func (r *Router) remind(ctx context.Context, userId int64, timer timermodel.Timer) error {
+	b := params.NewMessagesSendBuilder()
+	b.WithContext(ctx)
+	b.UserID(int(userId))
+	b.Message(fmt.Sprintf("Напоминаю, таймер %s истёк", timerName(&timer)))
+	b.RandomID(rand.Int())
+	b.Keyboard(botcallback.Keyboard(notification.NewExpired(timer), userId))
+	_, err := r.sender.MessagesSend(b.Params)
+	return err
+}
+
+func (r *Router) answer(obj events.MessageEventObject, text string) error {
+	b := params.NewMessagesSendMessageEventAnswerBuilder()
+	b.EventID(obj.EventID)
+	b.UserID(obj.UserID)
+	b.PeerID(obj.PeerID)
+	b.EventData(botcallback.SnackbarEventData(text))
+	_, err := r.sender.MessagesSendMessageEventAnswer(b.Params)
+	return err
+}
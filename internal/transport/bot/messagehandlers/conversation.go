@@ -0,0 +1,58 @@
+package messagehandlers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// state of multi-step command, for example user send "pause" and bot wait number of timer
+type Conversation struct {
+	Command   string
+	TimerIds  []uuid.UUID
+	ExpiresAt time.Time
+}
+
+// in memory storage of user conversations, key is vk user id
+type ConversationStorage struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	storage map[int64]Conversation
+}
+
+func NewConversationStorage(ttl time.Duration) *ConversationStorage {
+	return &ConversationStorage{ttl: ttl, storage: make(map[int64]Conversation)}
+}
+
+func (s *ConversationStorage) Set(userId int64, command string, timerIds []uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage[userId] = Conversation{Command: command, TimerIds: timerIds, ExpiresAt: time.Now().Add(s.ttl)}
+	// drop expired conversations of other users to keep storage small
+	for id, conv := range s.storage {
+		if time.Now().After(conv.ExpiresAt) {
+			delete(s.storage, id)
+		}
+	}
+}
+
+func (s *ConversationStorage) Get(userId int64) (Conversation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conv, ok := s.storage[userId]
+	if !ok {
+		return Conversation{}, false
+	}
+	if time.Now().After(conv.ExpiresAt) {
+		delete(s.storage, userId)
+		return Conversation{}, false
+	}
+	return conv, true
+}
+
+func (s *ConversationStorage) Clear(userId int64) {
+	s.mu.Lock()
+	delete(s.storage, userId)
+	s.mu.Unlock()
+}
@@ -0,0 +1,54 @@
+package messagehandlers
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/SevereCloud/vksdk/v2/object"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+)
+
+const (
+	// vk allows at most 40 symbols in button label
+	buttonLabelMaxSize = 40
+	// vk allows at most 6 rows in inline keyboard, last row is reserved for cancel button
+	keyboardMaxTimers = 5
+)
+
+func mainKeyboard() *object.MessagesKeyboard {
+	k := object.NewMessagesKeyboard(false)
+	k.AddRow()
+	k.AddTextButton("Мои таймеры", commandPayload{Command: TimersCommand}, object.Primary)
+	k.AddTextButton("Сколько осталось", commandPayload{Command: RemainingCommand}, object.Primary)
+	k.AddRow()
+	k.AddTextButton("Пауза", commandPayload{Command: PauseCommand}, object.Secondary)
+	k.AddTextButton("Продолжить", commandPayload{Command: ResumeCommand}, object.Positive)
+	k.AddTextButton("Сбросить", commandPayload{Command: ResetCommand}, object.Secondary)
+	k.AddRow()
+	k.AddTextButton("Отписаться", commandPayload{Command: UnsubscribeCommand}, object.Negative)
+	return k
+}
+
+// inline keyboard to choose timer for command
+func timersKeyboard(command string, timers []*timermodel.Timer) *object.MessagesKeyboard {
+	k := object.NewMessagesKeyboardInline()
+	for i, timer := range timers {
+		if i == keyboardMaxTimers {
+			break
+		}
+		k.AddRow()
+		k.AddTextButton(buttonLabel(i+1, timer), commandPayload{Command: command, TimerId: timer.ID}, object.Secondary)
+	}
+	k.AddRow()
+	k.AddTextButton("Отмена", commandPayload{Command: CancelCommand}, object.Negative)
+	return k
+}
+
+func buttonLabel(index int, timer *timermodel.Timer) string {
+	label := fmt.Sprintf("%d. %s", index, timerName(timer))
+	if utf8.RuneCountInString(label) <= buttonLabelMaxSize {
+		return label
+	}
+	runes := []rune(label)
+	return string(runes[:buttonLabelMaxSize-1]) + "…"
+}
@@ -1,33 +1,89 @@
 package messagehandlers
 
 import (
-	"log"
+	"context"
+	"os"
+	"time"
 
 	"github.com/SevereCloud/vksdk/v2/api"
+	"github.com/SevereCloud/vksdk/v2/events"
 	"github.com/SevereCloud/vksdk/v2/longpoll-bot"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
 )
 
+const _PROVIDER = "internal/transport/bot/messagehandlers"
+
+// time after which unfinished multi-step command is forgotten
+const conversationTTL = time.Minute * 5
+
+type MessageSender interface {
+	MessagesSend(params api.Params) (response int, err error)
+	MessagesSendMessageEventAnswer(params api.Params) (response int, err error)
+}
+
+type TimerUseCase interface {
+	Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error)
+	UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
+}
+
+// bot commands aren't versioned, 0 version is any version
+type CountdownTimerUseCase interface {
+	Stop(ctx context.Context, timerId uuid.UUID, userId int64, version int64) error
+	Start(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error)
+	Reset(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error)
+}
+
 type mainHandler struct {
-	vk *api.VK
+	vk     *api.VK
+	router *Router
 }
 
-func NewMain(vk *api.VK) *mainHandler {
-	return &mainHandler{vk: vk}
+func NewMain(vk *api.VK, timerUseCase TimerUseCase, countdownTimerUseCase CountdownTimerUseCase, reminders ReminderStorage) *mainHandler {
+	return &mainHandler{
+		vk:     vk,
+		router: NewRouter(vk, timerUseCase, countdownTimerUseCase, NewConversationStorage(conversationTTL), reminders),
+	}
 }
 
-func (m *mainHandler) Handle() {
+// blocking function, long poll and reminders are stopped when ctx is done
+// command which is being handled is finished with its own context, so it isn't interrupted by shutdown
+func (m *mainHandler) Handle(ctx context.Context) {
 	// get information about the group
 	group, err := m.vk.GroupsGetByID(nil)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("failed get bot group", logging.Err(err))
+		os.Exit(1)
 	}
 
 	// Initializing Long Poll
 	lp, err := longpoll.NewLongPoll(m.vk, group[0].ID)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("failed init bot long poll", logging.Err(err))
+		os.Exit(1)
 	}
-	lp.MessageNew(m.startMessageHandler)
+	lp.MessageNew(func(_ context.Context, obj events.MessageNewObject) {
+		m.router.MessageNew(context.Background(), obj)
+	})
+	lp.MessageEvent(func(_ context.Context, obj events.MessageEventObject) {
+		m.router.MessageEvent(context.Background(), obj)
+	})
 
-	lp.Run()
+	// snoozed reminders are sent by the same replica that handles long poll
+	reminders := make(chan struct{})
+	go func() {
+		defer close(reminders)
+		m.router.RunReminders(ctx)
+	}()
+
+	err = lp.RunWithContext(ctx)
+	if err != nil && ctx.Err() == nil {
+		slog.Error("bot long poll stopped", logging.Err(err))
+	}
+	<-reminders
 }
@@ -0,0 +1,168 @@
+package messagehandlers
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/SevereCloud/vksdk/v2/api/params"
+	"github.com/SevereCloud/vksdk/v2/events"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/google/uuid"
+)
+
+const (
+	StartCommand       = "start"
+	TimersCommand      = "timers"
+	RemainingCommand   = "remaining"
+	PauseCommand       = "pause"
+	ResumeCommand      = "resume"
+	ResetCommand       = "reset"
+	UnsubscribeCommand = "unsubscribe"
+	CancelCommand      = "cancel"
+)
+
+// text aliases of commands, user can type it instead of pressing keyboard button
+var textCommands = map[string]string{
+	"start":            StartCommand,
+	"начать":           StartCommand,
+	"timers":           TimersCommand,
+	"мои таймеры":      TimersCommand,
+	"таймеры":          TimersCommand,
+	"remaining":        RemainingCommand,
+	"сколько осталось": RemainingCommand,
+	"осталось":         RemainingCommand,
+	"pause":            PauseCommand,
+	"пауза":            PauseCommand,
+	"resume":           ResumeCommand,
+	"продолжить":       ResumeCommand,
+	"reset":            ResetCommand,
+	"сбросить":         ResetCommand,
+	"unsubscribe":      UnsubscribeCommand,
+	"отписаться":       UnsubscribeCommand,
+	"cancel":           CancelCommand,
+	"отмена":           CancelCommand,
+}
+
+// payload of keyboard buttons
+type commandPayload struct {
+	Command string    `json:"command"`
+	TimerId uuid.UUID `json:"timerId,omitempty"`
+}
+
+// parsed incoming message
+type botMessage struct {
+	userId  int64
+	peerId  int
+	command string
+	timerId uuid.UUID
+	text    string
+}
+
+type commandHandler func(ctx context.Context, msg *botMessage) error
+
+type Router struct {
+	sender                MessageSender
+	timerUseCase          TimerUseCase
+	countdownTimerUseCase CountdownTimerUseCase
+	conversations         *ConversationStorage
+	reminders             ReminderStorage
+	handlers              map[string]commandHandler
+}
+
+func NewRouter(
+	sender MessageSender,
+	timerUseCase TimerUseCase,
+	countdownTimerUseCase CountdownTimerUseCase,
+	conversations *ConversationStorage,
+	reminders ReminderStorage,
+) *Router {
+	r := &Router{
+		sender:                sender,
+		timerUseCase:          timerUseCase,
+		countdownTimerUseCase: countdownTimerUseCase,
+		conversations:         conversations,
+		reminders:             reminders,
+	}
+	r.handlers = map[string]commandHandler{
+		StartCommand:       r.startCommand,
+		TimersCommand:      r.timersCommand,
+		RemainingCommand:   r.remainingCommand,
+		PauseCommand:       r.pauseCommand,
+		ResumeCommand:      r.resumeCommand,
+		ResetCommand:       r.resetCommand,
+		UnsubscribeCommand: r.unsubscribeCommand,
+		CancelCommand:      r.cancelCommand,
+	}
+	return r
+}
+
+// long poll message_new handler
+func (r *Router) MessageNew(ctx context.Context, obj events.MessageNewObject) {
+	msg := r.parseMessage(obj)
+	handler, ok := r.handlers[msg.command]
+	if !ok {
+		handler = r.unknownCommand
+	}
+	ctx = logging.With(ctx, "user_id", msg.userId, "command", msg.command)
+	err := handler(ctx, msg)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed handle bot command", logging.Err(err))
+	}
+}
+
+// parse command from payload, if payload is empty try to parse command from text
+// if user in the middle of multi-step command, number in text is the index of chosen timer
+func (r *Router) parseMessage(obj events.MessageNewObject) *botMessage {
+	msg := &botMessage{
+		userId: int64(obj.Message.FromID),
+		peerId: obj.Message.PeerID,
+		text:   strings.TrimSpace(obj.Message.Text),
+	}
+	if len(obj.Message.Payload) != 0 {
+		var payload commandPayload
+		err := json.Unmarshal([]byte(obj.Message.Payload), &payload)
+		if err == nil && payload.Command != "" {
+			msg.command = payload.Command
+			msg.timerId = payload.TimerId
+			return msg
+		}
+	}
+	text := strings.ToLower(strings.TrimPrefix(msg.text, "/"))
+	if command, ok := textCommands[text]; ok {
+		msg.command = command
+		return msg
+	}
+	// continue started conversation
+	if index, err := strconv.Atoi(text); err == nil {
+		if conv, ok := r.conversations.Get(msg.userId); ok && index > 0 && index <= len(conv.TimerIds) {
+			msg.command = conv.Command
+			msg.timerId = conv.TimerIds[index-1]
+		}
+	}
+	return msg
+}
+
+func (r *Router) cancelCommand(ctx context.Context, msg *botMessage) error {
+	r.conversations.Clear(msg.userId)
+	return r.send(ctx, msg.peerId, cancelMessage, mainKeyboard())
+}
+
+func (r *Router) unknownCommand(ctx context.Context, msg *botMessage) error {
+	return r.send(ctx, msg.peerId, unknownCommandMessage, mainKeyboard())
+}
+
+func (r *Router) send(ctx context.Context, peerId int, message string, keyboard any) error {
+	b := params.NewMessagesSendBuilder()
+	b.WithContext(ctx)
+	b.PeerID(peerId)
+	b.Message(message)
+	b.RandomID(rand.Int())
+	if keyboard != nil {
+		b.Keyboard(keyboard)
+	}
+	_, err := r.sender.MessagesSend(b.Params)
+	return err
+}
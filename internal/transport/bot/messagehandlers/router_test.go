@@ -0,0 +1,210 @@
+package messagehandlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SevereCloud/vksdk/v2/api"
+	"github.com/SevereCloud/vksdk/v2/events"
+	"github.com/SevereCloud/vksdk/v2/object"
+	"github.com/Tap-Team/timerapi/internal/model/remindermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/internal/testdatamodule"
+	"github.com/Tap-Team/timerapi/internal/transport/bot/botcallback"
+	"github.com/Tap-Team/timerapi/internal/transport/bot/messagehandlers"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender struct {
+	sent    []api.Params
+	answers []api.Params
+}
+
+func (s *fakeSender) MessagesSend(params api.Params) (int, error) {
+	s.sent = append(s.sent, params)
+	return 0, nil
+}
+
+func (s *fakeSender) MessagesSendMessageEventAnswer(params api.Params) (int, error) {
+	s.answers = append(s.answers, params)
+	return 0, nil
+}
+
+type fakeTimerUseCase struct {
+	timers       []*timermodel.Timer
+	unsubscribed []uuid.UUID
+}
+
+func (f *fakeTimerUseCase) Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error) {
+	for _, timer := range f.timers {
+		if timer.ID == timerId {
+			return timer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeTimerUseCase) UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	return f.timers, nil
+}
+
+func (f *fakeTimerUseCase) UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	return f.timers, nil
+}
+
+func (f *fakeTimerUseCase) UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error) {
+	return f.timers, nil
+}
+
+func (f *fakeTimerUseCase) Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error {
+	f.unsubscribed = append(f.unsubscribed, timerId)
+	return nil
+}
+
+type fakeCountdownUseCase struct {
+	stopped []uuid.UUID
+	started []uuid.UUID
+	reset   []uuid.UUID
+}
+
+func (f *fakeCountdownUseCase) Stop(ctx context.Context, timerId uuid.UUID, userId int64, version int64) error {
+	f.stopped = append(f.stopped, timerId)
+	return nil
+}
+
+func (f *fakeCountdownUseCase) Start(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error) {
+	f.started = append(f.started, timerId)
+	return &timermodel.Timer{ID: timerId}, nil
+}
+
+func (f *fakeCountdownUseCase) Reset(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error) {
+	f.reset = append(f.reset, timerId)
+	return &timermodel.Timer{ID: timerId, IsPaused: true}, nil
+}
+
+type fakeReminderStorage struct {
+	reminders []remindermodel.Reminder
+	due       []time.Time
+}
+
+func (f *fakeReminderStorage) Schedule(ctx context.Context, reminder remindermodel.Reminder, due time.Time) error {
+	f.reminders = append(f.reminders, reminder)
+	f.due = append(f.due, due)
+	return nil
+}
+
+func (f *fakeReminderStorage) TakeDue(ctx context.Context, now time.Time, limit int) ([]remindermodel.Reminder, error) {
+	reminders := make([]remindermodel.Reminder, 0)
+	for i := 0; i < len(f.reminders) && len(reminders) < limit; {
+		if f.due[i].After(now) {
+			i++
+			continue
+		}
+		reminders = append(reminders, f.reminders[i])
+		f.reminders = append(f.reminders[:i], f.reminders[i+1:]...)
+		f.due = append(f.due[:i], f.due[i+1:]...)
+	}
+	return reminders, nil
+}
+
+func message(userId int, text, payload string) events.MessageNewObject {
+	return events.MessageNewObject{
+		Message: object.MessagesMessage{FromID: userId, PeerID: userId, Text: text, Payload: payload},
+	}
+}
+
+func TestPauseConversation(t *testing.T) {
+	ctx := context.Background()
+	userId := 1
+	timers := testdatamodule.RandomTimerList(3, func(t *timermodel.Timer) {
+		t.Type = timerfields.COUNTDOWN
+		t.Creator = int64(userId)
+	})
+	sender := new(fakeSender)
+	timerUseCase := &fakeTimerUseCase{timers: timers}
+	countdownUseCase := new(fakeCountdownUseCase)
+	conversations := messagehandlers.NewConversationStorage(time.Minute)
+	router := messagehandlers.NewRouter(sender, timerUseCase, countdownUseCase, conversations, new(fakeReminderStorage))
+
+	// first step, bot ask which timer should be paused
+	router.MessageNew(ctx, message(userId, "Пауза", ""))
+	require.Len(t, sender.sent, 1, "choose timer message not sent")
+	conv, ok := conversations.Get(int64(userId))
+	require.True(t, ok, "conversation not saved")
+	require.Equal(t, messagehandlers.PauseCommand, conv.Command)
+	require.Len(t, conv.TimerIds, len(timers))
+
+	// second step, user send number of timer
+	router.MessageNew(ctx, message(userId, "2", ""))
+	require.Equal(t, []uuid.UUID{timers[1].ID}, countdownUseCase.stopped, "wrong timer stopped")
+	_, ok = conversations.Get(int64(userId))
+	require.False(t, ok, "conversation not cleared")
+
+	// number without conversation is unknown command
+	router.MessageNew(ctx, message(userId, "2", ""))
+	require.Len(t, countdownUseCase.stopped, 1)
+}
+
+func TestPayloadCommand(t *testing.T) {
+	ctx := context.Background()
+	timer := testdatamodule.RandomTimer()
+	sender := new(fakeSender)
+	timerUseCase := &fakeTimerUseCase{timers: []*timermodel.Timer{timer}}
+	router := messagehandlers.NewRouter(sender, timerUseCase, new(fakeCountdownUseCase), messagehandlers.NewConversationStorage(time.Minute), new(fakeReminderStorage))
+
+	router.MessageNew(ctx, message(1, "Отписаться", `{"command":"unsubscribe","timerId":"`+timer.ID.String()+`"}`))
+	require.Equal(t, []uuid.UUID{timer.ID}, timerUseCase.unsubscribed)
+	require.Len(t, sender.sent, 1)
+}
+
+func callback(userId int, payload botcallback.Payload) events.MessageEventObject {
+	data, _ := json.Marshal(payload)
+	return events.MessageEventObject{UserID: userId, PeerID: userId, EventID: "event", Payload: data}
+}
+
+func TestCallbackRestart(t *testing.T) {
+	ctx := context.Background()
+	userId := 1
+	timer := testdatamodule.RandomTimer()
+	sender := new(fakeSender)
+	countdownUseCase := new(fakeCountdownUseCase)
+	router := messagehandlers.NewRouter(sender, &fakeTimerUseCase{}, countdownUseCase, messagehandlers.NewConversationStorage(time.Minute), new(fakeReminderStorage))
+
+	// another user press the button of forwarded message
+	router.MessageEvent(ctx, callback(2, botcallback.Payload{Command: botcallback.Restart, TimerId: timer.ID, UserId: int64(userId)}))
+	require.Empty(t, countdownUseCase.reset, "foreign user restart timer")
+	require.Len(t, sender.answers, 1, "callback not answered")
+
+	router.MessageEvent(ctx, callback(userId, botcallback.Payload{Command: botcallback.Restart, TimerId: timer.ID, UserId: int64(userId)}))
+	require.Equal(t, []uuid.UUID{timer.ID}, countdownUseCase.reset, "timer not reset")
+	require.Equal(t, []uuid.UUID{timer.ID}, countdownUseCase.started, "paused timer not started after reset")
+	require.Len(t, sender.answers, 2, "callback not answered")
+}
+
+func TestCallbackSnooze(t *testing.T) {
+	ctx := context.Background()
+	userId := 1
+	timer := testdatamodule.RandomTimer()
+	sender := new(fakeSender)
+	reminders := new(fakeReminderStorage)
+	router := messagehandlers.NewRouter(sender, &fakeTimerUseCase{timers: []*timermodel.Timer{timer}}, new(fakeCountdownUseCase), messagehandlers.NewConversationStorage(time.Minute), reminders)
+
+	router.MessageEvent(ctx, callback(userId, botcallback.Payload{Command: botcallback.Snooze, TimerId: timer.ID, UserId: int64(userId)}))
+	require.Len(t, sender.answers, 1, "callback not answered")
+	require.Len(t, reminders.reminders, 1, "reminder not scheduled")
+	require.Equal(t, timer.ID, reminders.reminders[0].Timer.ID, "wrong timer in reminder")
+	require.WithinDuration(t, time.Now().Add(botcallback.SnoozeDuration), reminders.due[0], time.Second, "wrong due time")
+
+	// reminder isn't sent before due time
+	router.SendDueReminders(ctx)
+	require.Empty(t, sender.sent, "reminder sent before due time")
+
+	reminders.due[0] = time.Now()
+	router.SendDueReminders(ctx)
+	require.Len(t, sender.sent, 1, "reminder not sent")
+	require.Empty(t, reminders.reminders, "reminder not removed")
+}
@@ -0,0 +1,227 @@
+package messagehandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+)
+
+const (
+	// max count of timers in bot messages
+	timersLimit = 20
+
+	cancelMessage         = "Действие отменено"
+	unknownCommandMessage = "Не понимаю команду, воспользуйтесь кнопками ниже"
+	noTimersMessage       = "У вас нет таймеров"
+	noCountdownMessage    = "У вас нет таймеров обратного отсчёта"
+	noSubscriptionMessage = "Вы не подписаны ни на один таймер"
+	chooseTimerMessage    = "Выберите таймер, нажмите на кнопку или отправьте его номер"
+	failedMessage         = "Не удалось выполнить команду, попробуйте позже"
+)
+
+func timerName(timer *timermodel.Timer) string {
+	if len(timer.Name) == 0 {
+		return "Без названия"
+	}
+	return string(timer.Name)
+}
+
+// time left before timer end, paused timer keep remaining time fixed
+func remaining(timer *timermodel.Timer, now time.Time) time.Duration {
+	if timer.IsPaused {
+		return timer.EndTime.T().Sub(timer.PauseTime.T())
+	}
+	return timer.EndTime.T().Sub(now)
+}
+
+func formatRemaining(d time.Duration) string {
+	if d <= 0 {
+		return "истёк"
+	}
+	d = d.Round(time.Second)
+	days := d / (time.Hour * 24)
+	d -= days * time.Hour * 24
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+	if days > 0 {
+		return fmt.Sprintf("%d д. %02d:%02d:%02d", days, h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+}
+
+func timersMessage(timers []*timermodel.Timer, now time.Time) string {
+	b := new(strings.Builder)
+	for i, timer := range timers {
+		status := ""
+		if timer.IsPaused {
+			status = " (на паузе)"
+		}
+		fmt.Fprintf(b, "%d. %s — %s%s\n", i+1, timerName(timer), formatRemaining(remaining(timer, now)), status)
+	}
+	return b.String()
+}
+
+// user friendly message of use case error
+func errorMessage(err error) string {
+	switch {
+	case errors.Is(err, timererror.ExceptionUserForbidden()):
+		return "Только создатель таймера может это сделать"
+	case errors.Is(err, timererror.ExceptionTimerIsPaused()):
+		return "Таймер уже на паузе"
+	case errors.Is(err, timererror.ExceptionTimerIsPlaying()):
+		return "Таймер уже запущен"
+	case errors.Is(err, timererror.ExceptionTimerNotFound()), errors.Is(err, timererror.ExceptionCountDownTimerNotFound()):
+		return "Таймер не найден"
+	case errors.Is(err, timererror.ExceptionCreatorUnsubscribe()):
+		return "Создатель не может отписаться от своего таймера"
+	default:
+		return failedMessage
+	}
+}
+
+func countdownTimers(timers []*timermodel.Timer) []*timermodel.Timer {
+	countdown := make([]*timermodel.Timer, 0, len(timers))
+	for _, timer := range timers {
+		if timer.Type == timerfields.COUNTDOWN {
+			countdown = append(countdown, timer)
+		}
+	}
+	return countdown
+}
+
+func timerIds(timers []*timermodel.Timer) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(timers))
+	for _, timer := range timers {
+		ids = append(ids, timer.ID)
+	}
+	return ids
+}
+
+// ask user to choose timer and remember command in conversation
+func (r *Router) chooseTimer(ctx context.Context, msg *botMessage, timers []*timermodel.Timer, emptyMessage string) error {
+	if len(timers) == 0 {
+		r.conversations.Clear(msg.userId)
+		return r.send(ctx, msg.peerId, emptyMessage, mainKeyboard())
+	}
+	r.conversations.Set(msg.userId, msg.command, timerIds(timers))
+	text := chooseTimerMessage + "\n\n" + timersMessage(timers, time.Now())
+	return r.send(ctx, msg.peerId, text, timersKeyboard(msg.command, timers))
+}
+
+// send result of command and finish conversation
+func (r *Router) reply(ctx context.Context, msg *botMessage, text string, err error) error {
+	r.conversations.Clear(msg.userId)
+	if err != nil {
+		text = errorMessage(err)
+	}
+	sendErr := r.send(ctx, msg.peerId, text, mainKeyboard())
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("execute command "+msg.command, "reply", _PROVIDER))
+	}
+	return sendErr
+}
+
+func (r *Router) timersCommand(ctx context.Context, msg *botMessage) error {
+	r.conversations.Clear(msg.userId)
+	timers, err := r.timerUseCase.UserTimers(ctx, msg.userId, 0, timersLimit)
+	if err != nil {
+		return r.reply(ctx, msg, "", err)
+	}
+	if len(timers) == 0 {
+		return r.send(ctx, msg.peerId, noTimersMessage, mainKeyboard())
+	}
+	return r.send(ctx, msg.peerId, "Ваши таймеры:\n\n"+timersMessage(timers, time.Now()), mainKeyboard())
+}
+
+func (r *Router) remainingCommand(ctx context.Context, msg *botMessage) error {
+	if msg.timerId == uuid.Nil {
+		timers, err := r.timerUseCase.UserTimers(ctx, msg.userId, 0, timersLimit)
+		if err != nil {
+			return r.reply(ctx, msg, "", err)
+		}
+		return r.chooseTimer(ctx, msg, timers, noTimersMessage)
+	}
+	timer, err := r.timerUseCase.Timer(ctx, msg.timerId)
+	if err != nil {
+		return r.reply(ctx, msg, "", err)
+	}
+	text := fmt.Sprintf("До конца таймера %s осталось %s", timerName(timer), formatRemaining(remaining(timer, time.Now())))
+	if timer.IsPaused {
+		text += ", таймер на паузе"
+	}
+	return r.reply(ctx, msg, text, nil)
+}
+
+// list of countdown timers created by user, only creator can control countdown
+func (r *Router) userCountdownTimers(ctx context.Context, userId int64) ([]*timermodel.Timer, error) {
+	timers, err := r.timerUseCase.UserCreatedTimers(ctx, userId, 0, timersLimit)
+	if err != nil {
+		return nil, err
+	}
+	return countdownTimers(timers), nil
+}
+
+func (r *Router) pauseCommand(ctx context.Context, msg *botMessage) error {
+	if msg.timerId == uuid.Nil {
+		timers, err := r.userCountdownTimers(ctx, msg.userId)
+		if err != nil {
+			return r.reply(ctx, msg, "", err)
+		}
+		return r.chooseTimer(ctx, msg, timers, noCountdownMessage)
+	}
+	err := r.countdownTimerUseCase.Stop(ctx, msg.timerId, msg.userId, 0)
+	return r.reply(ctx, msg, "Таймер поставлен на паузу", err)
+}
+
+func (r *Router) resumeCommand(ctx context.Context, msg *botMessage) error {
+	if msg.timerId == uuid.Nil {
+		timers, err := r.userCountdownTimers(ctx, msg.userId)
+		if err != nil {
+			return r.reply(ctx, msg, "", err)
+		}
+		return r.chooseTimer(ctx, msg, timers, noCountdownMessage)
+	}
+	timer, err := r.countdownTimerUseCase.Start(ctx, msg.timerId, msg.userId, 0)
+	if err != nil {
+		return r.reply(ctx, msg, "", err)
+	}
+	return r.reply(ctx, msg, fmt.Sprintf("Таймер %s запущен, осталось %s", timerName(timer), formatRemaining(remaining(timer, time.Now()))), nil)
+}
+
+func (r *Router) resetCommand(ctx context.Context, msg *botMessage) error {
+	if msg.timerId == uuid.Nil {
+		timers, err := r.userCountdownTimers(ctx, msg.userId)
+		if err != nil {
+			return r.reply(ctx, msg, "", err)
+		}
+		return r.chooseTimer(ctx, msg, timers, noCountdownMessage)
+	}
+	timer, err := r.countdownTimerUseCase.Reset(ctx, msg.timerId, msg.userId, 0)
+	if err != nil {
+		return r.reply(ctx, msg, "", err)
+	}
+	return r.reply(ctx, msg, fmt.Sprintf("Таймер %s сброшен", timerName(timer)), nil)
+}
+
+func (r *Router) unsubscribeCommand(ctx context.Context, msg *botMessage) error {
+	if msg.timerId == uuid.Nil {
+		timers, err := r.timerUseCase.UserSubscriptions(ctx, msg.userId, 0, timersLimit)
+		if err != nil {
+			return r.reply(ctx, msg, "", err)
+		}
+		return r.chooseTimer(ctx, msg, timers, noSubscriptionMessage)
+	}
+	err := r.timerUseCase.Unsubscribe(ctx, msg.timerId, msg.userId)
+	return r.reply(ctx, msg, "Вы отписались от таймера", err)
+}
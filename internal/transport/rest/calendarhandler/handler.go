@@ -0,0 +1,204 @@
+package calendarhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/calendarmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/ical"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/calendarhandler"
+
+type CalendarUseCase interface {
+	Feed(ctx context.Context, userId int64) (*calendarmodel.Feed, error)
+	ResetFeed(ctx context.Context, userId int64) (*calendarmodel.Feed, error)
+	FeedCalendar(ctx context.Context, token string) ([]byte, error)
+	Import(ctx context.Context, userId int64, r io.Reader, loc *time.Location) ([]*calendarmodel.ImportResult, error)
+}
+
+// max size of imported file
+const maxImportSize = 1 << 20
+
+type Handler struct {
+	useCase CalendarUseCase
+}
+
+func New(useCase CalendarUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// feed routes are registered in e, feed itself is registered in public group because calendar apps can't sign vk launch params
+func Init(e *echo.Group, public *echo.Group, useCase CalendarUseCase) {
+	handler := &Handler{useCase: useCase}
+
+	group := e.Group("/calendar")
+	group.GET("/feed", handler.Feed())
+	group.POST("/feed/reset", handler.ResetFeed())
+	group.POST("/import", handler.Import())
+
+	public.GET("/calendar/:token", handler.FeedCalendar())
+}
+
+// Feed godoc
+//
+//	@Summary		Feed
+//	@Description	get url of user calendar feed, feed contains all user timers and subscriptions, url is created on first request and should be kept in secret
+//	@Tags			calendar
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{object}	calendarmodel.Feed
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/calendar/feed [get]
+func (h *Handler) Feed() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Feed", _PROVIDER))
+		}
+		feed, err := h.useCase.Feed(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get user feed", "Feed", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, feed)
+	}
+}
+
+// ResetFeed godoc
+//
+//	@Summary		ResetFeed
+//	@Description	create new url of user calendar feed, previous url stops working
+//	@Tags			calendar
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{object}	calendarmodel.Feed
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/calendar/feed/reset [post]
+func (h *Handler) ResetFeed() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "ResetFeed", _PROVIDER))
+		}
+		feed, err := h.useCase.ResetFeed(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("reset user feed", "ResetFeed", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, feed)
+	}
+}
+
+// FeedCalendar godoc
+//
+//	@Summary		FeedCalendar
+//	@Description	live iCalendar feed of user timers, doesn't require vk launch params, token is secret part of feed url
+//	@Tags			calendar
+//	@Param			token	path	string	true	"feed token, .ics extension is allowed"
+//	@Produce		text/calendar
+//	@Success		200	{string}	string
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/calendar/{token} [get]
+func (h *Handler) FeedCalendar() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		token := strings.TrimSuffix(c.Param("token"), ".ics")
+		b, err := h.useCase.FeedCalendar(ctx, token)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get feed calendar", "FeedCalendar", _PROVIDER))
+		}
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+		return c.Blob(http.StatusOK, ical.ContentType, b)
+	}
+}
+
+// location of floating times in imported file, timeZone is IANA zone, utc is offset in hours
+func importLocation(c echo.Context) (*time.Location, error) {
+	if zone := timerfields.TimeZone(c.QueryParam("timeZone")); zone != "" {
+		err := zone.Validate()
+		if err != nil {
+			return nil, err
+		}
+		return zone.Location(), nil
+	}
+	param := c.QueryParam("utc")
+	if param == "" {
+		return time.UTC, nil
+	}
+	utc, err := strconv.ParseInt(param, 10, 16)
+	if err != nil {
+		return nil, errors.Join(err, timererror.ExceptionWrongTimeZone())
+	}
+	err = timerfields.ValidateUTC(int16(utc))
+	if err != nil {
+		return nil, err
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+d", utc), int(utc)*3600), nil
+}
+
+// Import godoc
+//
+//	@Summary		Import
+//	@Description	create date timers from events of iCalendar file, file is sent as multipart form field "file" or as raw body
+//	@Description	event start becomes timer end, recurring event becomes timer of next occurrence, past events are skipped, name and description are truncated
+//	@Description	returns report of every event with status created, skipped or rejected, reason and notes about changes of event
+//	@Tags			calendar
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			timeZone	query	string	false	"IANA time zone of events without time zone, e.g. Europe/Berlin, utc is ignored if set"
+//	@Param			utc			query	int		false	"utc offset in hours of events without time zone, from -12 to 14, default 0"
+//	@Param			file		formData	file	false	"ics file"
+//	@Accept			multipart/form-data,text/calendar
+//	@Produce		json
+//	@Success		200	{array}		calendarmodel.ImportResult
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/calendar/import [post]
+func (h *Handler) Import() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Import", _PROVIDER))
+		}
+		loc, err := importLocation(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse time zone", "Import", _PROVIDER))
+		}
+		var body io.Reader = c.Request().Body
+		if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+			fileHeader, err := c.FormFile("file")
+			if err != nil {
+				return exception.Wrap(err, exception.NewCause("get form file", "Import", _PROVIDER))
+			}
+			file, err := fileHeader.Open()
+			if err != nil {
+				return exception.Wrap(err, exception.NewCause("open form file", "Import", _PROVIDER))
+			}
+			defer file.Close()
+			body = file
+		}
+		report, err := h.useCase.Import(ctx, userId, io.LimitReader(body, maxImportSize), loc)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("import calendar", "Import", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+}
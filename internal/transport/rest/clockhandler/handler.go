@@ -0,0 +1,50 @@
+package clockhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/clockhandler"
+
+type Handler struct{}
+
+func New() *Handler {
+	return &Handler{}
+}
+
+// clock is registered in public group, clients sync clock before launch params are signed
+func Init(public *echo.Group) {
+	handler := &Handler{}
+	public.GET("/time", handler.Time)
+}
+
+// Time godoc
+//
+//	@Summary		Time
+//	@Description	NTP-style clock sync, server is source of truth for timer times, all times are unix milliseconds
+//	@Description	with clientReceiveTime as time when response is got
+//	@Description	offset = ((serverReceiveTime - clientSendTime) + (serverSendTime - clientReceiveTime)) / 2
+//	@Description	delay = (clientReceiveTime - clientSendTime) - (serverSendTime - serverReceiveTime)
+//	@Description	drift is change of offset between syncs, same exchange is available in websocket with event_clock_sync
+//	@Tags			clock
+//	@Param			clientSendTime	query	int64	true	"client time of request, unix milliseconds"
+//	@Produce		json
+//	@Success		200	{object}	timerevent.ClockSyncEvent
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/time [get]
+func (h *Handler) Time(c echo.Context) error {
+	receiveTime := time.Now()
+	clientSendTime, err := strconv.ParseInt(c.QueryParam("clientSendTime"), 10, 64)
+	if err != nil {
+		return exception.Wrap(errors.Join(err, errors.New("client send time parse error")), exception.NewCause("parse client send time", "Time", _PROVIDER))
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, timerevent.NewClockSync(clientSendTime, receiveTime).Sent())
+}
@@ -0,0 +1,43 @@
+package clockhandler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/clockhandler"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime(t *testing.T) {
+	e := echo.New()
+	handler := clockhandler.New()
+
+	clientSendTime := time.Now().Add(-time.Hour).UnixMilli()
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/time?clientSendTime=%d", clientSendTime), nil)
+	rec := httptest.NewRecorder()
+	before := time.Now().UnixMilli()
+	err := handler.Time(e.NewContext(req, rec))
+	after := time.Now().UnixMilli()
+	require.NoError(t, err, "time failed")
+	require.Equal(t, http.StatusOK, rec.Code, "wrong status code")
+	require.Equal(t, "no-store", rec.Header().Get(echo.HeaderCacheControl), "response can be cached")
+
+	sync := new(timerevent.ClockSyncEvent)
+	err = json.Unmarshal(rec.Body.Bytes(), sync)
+	require.NoError(t, err, "unmarshal body failed")
+	require.Equal(t, timerevent.ClockSync, sync.Type, "wrong type")
+	require.Equal(t, clientSendTime, sync.ClientSendTime, "client send time not returned")
+	require.LessOrEqual(t, before, sync.ServerReceiveTime, "wrong receive time")
+	require.LessOrEqual(t, sync.ServerReceiveTime, sync.ServerSendTime, "send time before receive time")
+	require.LessOrEqual(t, sync.ServerSendTime, after, "wrong send time")
+
+	req = httptest.NewRequest(http.MethodGet, "/time", nil)
+	err = handler.Time(e.NewContext(req, httptest.NewRecorder()))
+	require.Error(t, err, "time without client send time")
+}
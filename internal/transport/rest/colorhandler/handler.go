@@ -0,0 +1,52 @@
+package colorhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/internal/model/colormodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/colorhandler"
+
+type ColorUseCase interface {
+	Colors(ctx context.Context) ([]*colormodel.Color, error)
+}
+
+type Handler struct {
+	colorUseCase ColorUseCase
+}
+
+func New(colorUseCase ColorUseCase) *Handler {
+	return &Handler{colorUseCase: colorUseCase}
+}
+
+// palette is registered in public group, it is same for every user
+func Init(public *echo.Group, colorUseCase ColorUseCase) {
+	handler := &Handler{colorUseCase: colorUseCase}
+	public.GET("/colors", handler.Colors())
+}
+
+// Colors godoc
+//
+//	@Summary		Colors
+//	@Description	palette of timer colors in palette order, color name is used as timer color
+//	@Description	timer can also have custom hex color with optional gradient, clients which don't know custom colors show palette color
+//	@Tags			colors
+//	@Produce		json
+//	@Success		200	{array}		colormodel.Color
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/colors [get]
+func (h *Handler) Colors() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		colors, err := h.colorUseCase.Colors(ctx)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get colors", "Colors", _PROVIDER))
+		}
+		c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(http.StatusOK, colors)
+	}
+}
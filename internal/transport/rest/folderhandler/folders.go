@@ -0,0 +1,177 @@
+package folderhandler
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+// Folders godoc
+//
+//	@Summary		Folders
+//	@Description	get user folders in manual order with count of timers in every folder
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{array}		foldermodel.Folder
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/folders [get]
+func (h *Handler) Folders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := userId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Folders", _PROVIDER))
+		}
+		folders, err := h.useCase.Folders(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get user folders", "Folders", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, folders)
+	}
+}
+
+// CreateFolder godoc
+//
+//	@Summary		CreateFolder
+//	@Description	create user folder, new folder is placed after other folders
+//	@Tags			folders
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			folder		body	foldermodel.FolderSettings	true	"folder"
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	foldermodel.Folder
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/folders [post]
+func (h *Handler) CreateFolder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := userId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "CreateFolder", _PROVIDER))
+		}
+		settings := new(foldermodel.FolderSettings)
+		err = c.Bind(settings)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "CreateFolder", _PROVIDER))
+		}
+		err = settings.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "CreateFolder", _PROVIDER))
+		}
+		folder, err := h.useCase.Create(ctx, userId, settings)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("create folder", "CreateFolder", _PROVIDER))
+		}
+		return c.JSON(http.StatusCreated, folder)
+	}
+}
+
+// RenameFolder godoc
+//
+//	@Summary		RenameFolder
+//	@Description	rename user folder
+//	@Tags			folders
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			id			path	string						true	"folder id"
+//	@Param			folder		body	foldermodel.FolderSettings	true	"folder"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	foldermodel.Folder
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/folders/{id} [put]
+func (h *Handler) RenameFolder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, folderId, err := userIdWithId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,folder id", "RenameFolder", _PROVIDER))
+		}
+		settings := new(foldermodel.FolderSettings)
+		err = c.Bind(settings)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "RenameFolder", _PROVIDER))
+		}
+		err = settings.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "RenameFolder", _PROVIDER))
+		}
+		folder, err := h.useCase.Rename(ctx, folderId, userId, settings)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("rename folder", "RenameFolder", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, folder)
+	}
+}
+
+// DeleteFolder godoc
+//
+//	@Summary		DeleteFolder
+//	@Description	delete user folder, timers of folder are not deleted and moved out of folders
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"folder id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/folders/{id} [delete]
+func (h *Handler) DeleteFolder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, folderId, err := userIdWithId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,folder id", "DeleteFolder", _PROVIDER))
+		}
+		err = h.useCase.Delete(ctx, folderId, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("delete folder", "DeleteFolder", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// OrderFolders godoc
+//
+//	@Summary		OrderFolders
+//	@Description	save manual order of user folders, first id goes first, ids of not owned folders are skipped
+//	@Tags			folders
+//	@Param			debug		query	string				false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64				true	"user id"
+//	@Param			order		body	foldermodel.Order	true	"folder ids"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/folders/order [put]
+func (h *Handler) OrderFolders() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := userId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "OrderFolders", _PROVIDER))
+		}
+		order := new(foldermodel.Order)
+		err = c.Bind(order)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "OrderFolders", _PROVIDER))
+		}
+		err = h.useCase.Order(ctx, userId, order)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("order folders", "OrderFolders", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,97 @@
+package folderhandler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/folderhandler"
+
+type FolderUseCase interface {
+	Create(ctx context.Context, userId int64, settings *foldermodel.FolderSettings) (*foldermodel.Folder, error)
+	Folders(ctx context.Context, userId int64) ([]*foldermodel.Folder, error)
+	Rename(ctx context.Context, folderId uuid.UUID, userId int64, settings *foldermodel.FolderSettings) (*foldermodel.Folder, error)
+	Delete(ctx context.Context, folderId uuid.UUID, userId int64) error
+	Order(ctx context.Context, userId int64, order *foldermodel.Order) error
+
+	MoveTimer(ctx context.Context, timerId uuid.UUID, userId int64, folderId *uuid.UUID) error
+	PinTimer(ctx context.Context, timerId uuid.UUID, userId int64, isPinned bool) error
+	OrderTimers(ctx context.Context, userId int64, order *foldermodel.Order) error
+
+	SetTimerTags(ctx context.Context, timerId uuid.UUID, userId int64, tags *foldermodel.Tags) error
+	Tags(ctx context.Context, userId int64) ([]*foldermodel.TagInfo, error)
+	RenameTag(ctx context.Context, userId int64, tag foldermodel.Tag, rename *foldermodel.RenameTag) error
+	DeleteTag(ctx context.Context, userId int64, tag foldermodel.Tag) error
+}
+
+type Handler struct {
+	useCase FolderUseCase
+}
+
+func New(useCase FolderUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func Init(e *echo.Group, useCase FolderUseCase) {
+	handler := &Handler{useCase: useCase}
+
+	folders := e.Group("/folders")
+	folders.GET("", handler.Folders())
+	folders.POST("", handler.CreateFolder())
+	folders.PUT("/order", handler.OrderFolders())
+	folders.PUT("/:id", handler.RenameFolder())
+	folders.DELETE("/:id", handler.DeleteFolder())
+
+	timers := e.Group("/timers")
+	timers.PUT("/order", handler.OrderTimers())
+	timers.PUT("/:id/folder", handler.MoveTimer())
+	timers.PUT("/:id/pin", handler.PinTimer())
+	timers.DELETE("/:id/pin", handler.UnpinTimer())
+	timers.PUT("/:id/tags", handler.SetTimerTags())
+
+	tags := e.Group("/tags")
+	tags.GET("", handler.Tags())
+	tags.PUT("/:tag", handler.RenameTag())
+	tags.DELETE("/:tag", handler.DeleteTag())
+}
+
+func userId(c echo.Context) (int64, error) {
+	userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+	if err != nil {
+		return 0, errors.Join(err, errors.New("user id parse error"))
+	}
+	return userId, nil
+}
+
+// user id and uuid from :id param
+func userIdWithId(c echo.Context) (int64, uuid.UUID, error) {
+	userId, err := userId(c)
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return 0, uuid.Nil, errors.Join(err, errors.New("id parse error"))
+	}
+	return userId, id, nil
+}
+
+// user id and tag from :tag param, tag is path unescaped
+func userIdTag(c echo.Context) (int64, foldermodel.Tag, error) {
+	userId, err := userId(c)
+	if err != nil {
+		return 0, "", err
+	}
+	tag, err := url.PathUnescape(c.Param("tag"))
+	if err != nil {
+		return 0, "", errors.Join(err, errors.New("tag parse error"))
+	}
+	return userId, foldermodel.Tag(tag), nil
+}
@@ -0,0 +1,103 @@
+package folderhandler
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+// Tags godoc
+//
+//	@Summary		Tags
+//	@Description	get user tags ordered by name with count of tagged timers
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{array}		foldermodel.TagInfo
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/tags [get]
+func (h *Handler) Tags() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := userId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Tags", _PROVIDER))
+		}
+		tags, err := h.useCase.Tags(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get user tags", "Tags", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, tags)
+	}
+}
+
+// RenameTag godoc
+//
+//	@Summary		RenameTag
+//	@Description	rename user tag on every timer, tag is merged with existing tag of same name
+//	@Tags			folders
+//	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64					true	"user id"
+//	@Param			tag			path	string					true	"tag, path escaped"
+//	@Param			rename		body	foldermodel.RenameTag	true	"new name"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/tags/{tag} [put]
+func (h *Handler) RenameTag() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, tag, err := userIdTag(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user id,tag", "RenameTag", _PROVIDER))
+		}
+		rename := new(foldermodel.RenameTag)
+		err = c.Bind(rename)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "RenameTag", _PROVIDER))
+		}
+		err = rename.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "RenameTag", _PROVIDER))
+		}
+		err = h.useCase.RenameTag(ctx, userId, tag, rename)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("rename tag", "RenameTag", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// DeleteTag godoc
+//
+//	@Summary		DeleteTag
+//	@Description	remove user tag from every timer
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			tag			path	string	true	"tag, path escaped"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/tags/{tag} [delete]
+func (h *Handler) DeleteTag() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, tag, err := userIdTag(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user id,tag", "DeleteTag", _PROVIDER))
+		}
+		err = h.useCase.DeleteTag(ctx, userId, tag)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("delete tag", "DeleteTag", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
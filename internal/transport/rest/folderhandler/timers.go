@@ -0,0 +1,173 @@
+package folderhandler
+
+import (
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/internal/model/foldermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+// MoveTimer godoc
+//
+//	@Summary		MoveTimer
+//	@Description	move timer into user folder, null folderId moves timer out of folders, only subscribers (creator inclusive) can move timer
+//	@Description	folder is private for user, moved timer goes after manually ordered timers of folder
+//	@Tags			folders
+//	@Param			debug		query	string				false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64				true	"user id"
+//	@Param			id			path	string				true	"timer id"
+//	@Param			move		body	foldermodel.Move	true	"folder"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/folder [put]
+func (h *Handler) MoveTimer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdWithId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "MoveTimer", _PROVIDER))
+		}
+		move := new(foldermodel.Move)
+		err = c.Bind(move)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "MoveTimer", _PROVIDER))
+		}
+		err = h.useCase.MoveTimer(ctx, timerId, userId, move.FolderId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("move timer", "MoveTimer", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// PinTimer godoc
+//
+//	@Summary		PinTimer
+//	@Description	pin timer, pinned timers go first in user timer lists, pin is private for user
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/pin [put]
+func (h *Handler) PinTimer() echo.HandlerFunc {
+	return h.pin("PinTimer", true)
+}
+
+// UnpinTimer godoc
+//
+//	@Summary		UnpinTimer
+//	@Description	unpin timer
+//	@Tags			folders
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/pin [delete]
+func (h *Handler) UnpinTimer() echo.HandlerFunc {
+	return h.pin("UnpinTimer", false)
+}
+
+func (h *Handler) pin(method string, isPinned bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdWithId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", method, _PROVIDER))
+		}
+		err = h.useCase.PinTimer(ctx, timerId, userId, isPinned)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set timer pin", method, _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// OrderTimers godoc
+//
+//	@Summary		OrderTimers
+//	@Description	save manual order of user timers, usually timers of one folder, first id goes first
+//	@Description	order is private for user, ids of timers which user isn't subscribed on are skipped
+//	@Tags			folders
+//	@Param			debug		query	string				false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64				true	"user id"
+//	@Param			order		body	foldermodel.Order	true	"timer ids"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/order [put]
+func (h *Handler) OrderTimers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := userId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "OrderTimers", _PROVIDER))
+		}
+		order := new(foldermodel.Order)
+		err = c.Bind(order)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "OrderTimers", _PROVIDER))
+		}
+		err = h.useCase.OrderTimers(ctx, userId, order)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("order timers", "OrderTimers", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// SetTimerTags godoc
+//
+//	@Summary		SetTimerTags
+//	@Description	replace user tags of timer, tags are private, subscriber can tag timer of other user
+//	@Description	tags are trimmed, duplicates are removed, empty list removes all tags of timer
+//	@Tags			folders
+//	@Param			debug		query	string				false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64				true	"user id"
+//	@Param			id			path	string				true	"timer id"
+//	@Param			tags		body	foldermodel.Tags	true	"tags"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	foldermodel.Tags
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/tags [put]
+func (h *Handler) SetTimerTags() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdWithId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "SetTimerTags", _PROVIDER))
+		}
+		tags := new(foldermodel.Tags)
+		err = c.Bind(tags)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "SetTimerTags", _PROVIDER))
+		}
+		err = tags.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "SetTimerTags", _PROVIDER))
+		}
+		err = h.useCase.SetTimerTags(ctx, timerId, userId, tags)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set timer tags", "SetTimerTags", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, tags)
+	}
+}
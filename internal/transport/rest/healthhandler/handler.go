@@ -0,0 +1,61 @@
+package healthhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Tap-Team/timerapi/internal/model/healthmodel"
+	"github.com/labstack/echo/v4"
+)
+
+type HealthUseCase interface {
+	Ready(ctx context.Context) *healthmodel.Readiness
+}
+
+type Handler struct {
+	useCase HealthUseCase
+}
+
+func New(useCase HealthUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// probes are registered in public group, orchestrator can't sign launch params
+func Init(public *echo.Group, useCase HealthUseCase) {
+	handler := &Handler{useCase: useCase}
+	public.GET("/healthz", handler.Healthz)
+	public.GET("/readyz", handler.Readyz)
+}
+
+// Healthz godoc
+//
+//	@Summary		Healthz
+//	@Description	liveness probe, process is alive if it responds, dependencies aren't checked
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	healthmodel.Liveness
+//	@Router			/healthz [get]
+func (h *Handler) Healthz(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, healthmodel.Liveness{Status: healthmodel.UP})
+}
+
+// Readyz godoc
+//
+//	@Summary		Readyz
+//	@Description	readiness probe, checks postgres pool, redis ping, ticker grpc connection and tick stream
+//	@Description	every dependency is returned with status, latency of check in milliseconds, info and error
+//	@Description	tick stream is down if stream is closed, or if last receive is older than ticker max_tick_age when it is set
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	healthmodel.Readiness
+//	@Failure		503	{object}	healthmodel.Readiness
+//	@Router			/readyz [get]
+func (h *Handler) Readyz(c echo.Context) error {
+	readiness := h.useCase.Ready(c.Request().Context())
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	if readiness.Status != healthmodel.UP {
+		return c.JSON(http.StatusServiceUnavailable, readiness)
+	}
+	return c.JSON(http.StatusOK, readiness)
+}
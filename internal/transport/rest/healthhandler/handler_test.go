@@ -0,0 +1,51 @@
+package healthhandler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/healthusecase"
+	"github.com/Tap-Team/timerapi/internal/model/healthmodel"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/healthhandler"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthz(t *testing.T) {
+	e := echo.New()
+	handler := healthhandler.New(healthusecase.New(time.Second))
+	rec := httptest.NewRecorder()
+	err := handler.Healthz(e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec))
+	require.NoError(t, err, "healthz failed")
+	require.Equal(t, http.StatusOK, rec.Code, "wrong status code")
+}
+
+func TestReadyz(t *testing.T) {
+	e := echo.New()
+	uc := healthusecase.New(time.Second)
+	handler := healthhandler.New(uc)
+	readyz := func() (*httptest.ResponseRecorder, *healthmodel.Readiness) {
+		rec := httptest.NewRecorder()
+		err := handler.Readyz(e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec))
+		require.NoError(t, err, "readyz failed")
+		readiness := new(healthmodel.Readiness)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), readiness), "unmarshal body failed")
+		return rec, readiness
+	}
+
+	uc.Register("postgres", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) { return "", nil }))
+	rec, readiness := readyz()
+	require.Equal(t, http.StatusOK, rec.Code, "wrong status code")
+	require.Equal(t, healthmodel.UP, readiness.Dependencies["postgres"].Status, "wrong dependency status")
+
+	uc.Register("redis", healthusecase.CheckerFunc(func(ctx context.Context) (string, error) { return "", errors.New("connection refused") }))
+	rec, readiness = readyz()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code, "wrong status code of down dependency")
+	require.Equal(t, healthmodel.DOWN, readiness.Status, "wrong status")
+	require.Equal(t, "connection refused", readiness.Dependencies["redis"].Error, "error not returned")
+}
@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/idempotencyerror"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/model/idempotencymodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/idempotency"
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// set on replayed responses
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+type Storage interface {
+	Start(ctx context.Context, key string, hash string, ttl time.Duration) (*idempotencymodel.Record, bool, error)
+	Complete(ctx context.Context, key string, record *idempotencymodel.Record, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type Config struct {
+	// ttl of completed response
+	TTL time.Duration
+	// ttl of request in progress, key is released if server stops before response
+	LockTTL time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{TTL: 24 * time.Hour, LockTTL: time.Minute}
+}
+
+// copy of response body written by handler
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// hash of method, url and body, body is restored for handler
+func requestHash(c echo.Context) (string, error) {
+	req := c.Request()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "?" + req.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// mutating requests with Idempotency-Key header are executed once per key and user,
+// duplicates get saved response, duplicate of request in progress and key reused with other request are rejected
+// failed requests aren't saved and can be repeated with same key
+func Middleware(storage Storage, config Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			idempotencyKey := c.Request().Header.Get(HeaderIdempotencyKey)
+			if idempotencyKey == "" || !mutating(c.Request().Method) {
+				return next(c)
+			}
+			if len(idempotencyKey) > idempotencymodel.MaxKeySize {
+				return exception.Wrap(idempotencyerror.ExceptionWrongKey(), exception.NewCause("check key size", "Middleware", _PROVIDER))
+			}
+			key := "user_" + c.QueryParam(vk.USER_ID)
+			if _, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64); err != nil {
+				key = "ip_" + c.RealIP()
+			}
+			key += "_" + idempotencyKey
+
+			hash, err := requestHash(c)
+			if err != nil {
+				return exception.Wrap(err, exception.NewCause("hash request", "Middleware", _PROVIDER))
+			}
+			ctx := c.Request().Context()
+			record, started, err := storage.Start(ctx, key, hash, config.LockTTL)
+			if err != nil {
+				return exception.Wrap(err, exception.NewCause("start request", "Middleware", _PROVIDER))
+			}
+			if !started {
+				return replay(c, record, hash)
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			err = next(c)
+			if err != nil || c.Response().Status >= http.StatusInternalServerError {
+				if deleteErr := storage.Delete(ctx, key); deleteErr != nil {
+					logging.FromContext(ctx).Error("failed delete idempotency key",
+						logging.Err(exception.Wrap(deleteErr, exception.NewCause("delete failed request", "Middleware", _PROVIDER))),
+					)
+				}
+				return err
+			}
+			record.Status = c.Response().Status
+			record.ContentType = c.Response().Header().Get(echo.HeaderContentType)
+			record.Body = recorder.body.Bytes()
+			if err := storage.Complete(ctx, key, record, config.TTL); err != nil {
+				logging.FromContext(ctx).Error("failed complete idempotency key",
+					logging.Err(exception.Wrap(err, exception.NewCause("complete request", "Middleware", _PROVIDER))),
+				)
+			}
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, record *idempotencymodel.Record, hash string) error {
+	if record.Hash != hash {
+		return exception.Wrap(idempotencyerror.ExceptionKeyReused(), exception.NewCause("compare request hash", "replay", _PROVIDER))
+	}
+	if !record.Completed {
+		return exception.Wrap(idempotencyerror.ExceptionRequestInProgress(), exception.NewCause("check request completed", "replay", _PROVIDER))
+	}
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	if len(record.Body) == 0 {
+		return c.NoContent(record.Status)
+	}
+	return c.Blob(record.Status, record.ContentType, record.Body)
+}
@@ -0,0 +1,97 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/idempotencyerror"
+	"github.com/Tap-Team/timerapi/internal/model/idempotencymodel"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/idempotency"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type storage map[string]*idempotencymodel.Record
+
+func (s storage) Start(ctx context.Context, key string, hash string, ttl time.Duration) (*idempotencymodel.Record, bool, error) {
+	if record, ok := s[key]; ok {
+		return record, false, nil
+	}
+	s[key] = &idempotencymodel.Record{Hash: hash}
+	return s[key], true, nil
+}
+
+func (s storage) Complete(ctx context.Context, key string, record *idempotencymodel.Record, ttl time.Duration) error {
+	record.Completed = true
+	s[key] = record
+	return nil
+}
+
+func (s storage) Delete(ctx context.Context, key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	s := make(storage)
+	calls := 0
+	handler := idempotency.Middleware(s, idempotency.DefaultConfig())(func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"call": calls})
+	})
+	request := func(key, body string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/timers/create?vk_user_id=1", strings.NewReader(body))
+		req.Header.Set(idempotency.HeaderIdempotencyKey, key)
+		rec := httptest.NewRecorder()
+		return rec, handler(e.NewContext(req, rec))
+	}
+
+	first, err := request("key", "{}")
+	require.NoError(t, err, "first request failed")
+	require.Equal(t, http.StatusCreated, first.Code, "wrong first status")
+
+	// duplicate gets saved response without handler call
+	second, err := request("key", "{}")
+	require.NoError(t, err, "duplicate request failed")
+	require.Equal(t, 1, calls, "handler called for duplicate")
+	require.Equal(t, http.StatusCreated, second.Code, "wrong replayed status")
+	require.Equal(t, first.Body.String(), second.Body.String(), "wrong replayed body")
+	require.Equal(t, "true", second.Header().Get(idempotency.HeaderIdempotentReplayed), "replay header not set")
+
+	// same key with other body
+	_, err = request("key", `{"name":"other"}`)
+	require.ErrorIs(t, err, idempotencyerror.ExceptionKeyReused(), "wrong reused key error")
+
+}
+
+func TestMiddlewareInProgress(t *testing.T) {
+	e := echo.New()
+	s := make(storage)
+	var duplicateErr error
+	var handler echo.HandlerFunc
+	request := func() (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/timers/id/stop?vk_user_id=1", nil)
+		req.Header.Set(idempotency.HeaderIdempotencyKey, "key")
+		rec := httptest.NewRecorder()
+		return rec, handler(e.NewContext(req, rec))
+	}
+	nested := false
+	handler = idempotency.Middleware(s, idempotency.DefaultConfig())(func(c echo.Context) error {
+		// duplicate is sent while first request is in progress
+		if !nested {
+			nested = true
+			_, duplicateErr = request()
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec, err := request()
+	require.NoError(t, err, "first request failed")
+	require.Equal(t, http.StatusNoContent, rec.Code, "wrong first status")
+	require.ErrorIs(t, duplicateErr, idempotencyerror.ExceptionRequestInProgress(), "wrong in progress error")
+}
@@ -0,0 +1,48 @@
+package metricshandler
+
+import (
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/metrics"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics are registered in public group, scraper can't sign launch params
+func Init(public *echo.Group) {
+	public.GET("/metrics", Metrics)
+}
+
+// Metrics godoc
+//
+//	@Summary		Metrics
+//	@Description	prometheus metrics in text exposition format
+//	@Tags			metrics
+//	@Produce		plain
+//	@Success		200	{string}	string
+//	@Router			/metrics [get]
+func Metrics(c echo.Context) error {
+	promhttp.Handler().ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// observe latency and status of requests by route template
+// websockets are skipped, their duration is lifetime of connection
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.IsWebSocket() {
+				return next(c)
+			}
+			start := time.Now()
+			err := next(c)
+			// handle error here to get status which is written by error handler
+			// inner logger handles error itself, then response is already committed
+			if err != nil && !c.Response().Committed {
+				c.Error(err)
+			}
+			metrics.ObserveHTTP(c.Request().Method, c.Path(), c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
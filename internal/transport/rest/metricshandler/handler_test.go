@@ -0,0 +1,31 @@
+package metricshandler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/transport/rest/metricshandler"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(metricshandler.Middleware())
+	metricshandler.Init(e.Group(""))
+	e.GET("/timers/:id", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/timers/"+t.Name(), nil))
+	require.Equal(t, http.StatusNotFound, rec.Code, "wrong status code")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code, "wrong status code")
+	body := rec.Body.String()
+	require.Contains(t, body, `timerapi_http_request_duration_seconds_count{method="GET",route="/timers/:id",status="404"} 1`, "request not observed by route")
+	require.NotContains(t, body, t.Name(), "path params in labels")
+}
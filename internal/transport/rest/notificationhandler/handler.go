@@ -26,13 +26,12 @@ func New(uc NotificationUseCase) *Handler {
 }
 
 func Init(e *echo.Group, ntionUseCase NotificationUseCase) {
-	ctx := context.Background()
 	handler := &Handler{useCase: ntionUseCase}
 	group := e.Group("/notifications")
 
-	group.GET("", handler.NotificationsByUser(ctx))
+	group.GET("", handler.NotificationsByUser())
 
-	group.DELETE("", handler.Delete(ctx))
+	group.DELETE("", handler.Delete())
 }
 
 // NotificationsByUser godoc
@@ -48,8 +47,9 @@ func Init(e *echo.Group, ntionUseCase NotificationUseCase) {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/notifications [get]
-func (h *Handler) NotificationsByUser(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) NotificationsByUser() echo.HandlerFunc {
 	f := func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, err := strconv.ParseInt(c.QueryParam("vk_user_id"), 10, 64)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse userId param", "NotificationsByUser", _PROVIDER))
@@ -76,8 +76,9 @@ func (h *Handler) NotificationsByUser(ctx context.Context) echo.HandlerFunc {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/notifications [delete]
-func (h *Handler) Delete(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) Delete() echo.HandlerFunc {
 	f := func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, err := strconv.ParseInt(c.QueryParam("vk_user_id"), 10, 64)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse userId param", "Delete", _PROVIDER))
@@ -15,11 +15,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/database/postgres/colorstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/notificationstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/timerstorage"
 	"github.com/Tap-Team/timerapi/internal/database/redis/subscriberstorage"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timereventstream"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timernotificationstream"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/countdowntimerusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/notificationusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
@@ -53,39 +55,39 @@ func createTimer(ctx context.Context, userId int64, timer *timermodel.CreateTime
 	req := httptest.NewRequest(http.MethodPost, timerPath("/create?vk_user_id="+fmt.Sprint(userId)), bytes.NewReader(b))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, timerHandler.CreateTimer(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, timerHandler.CreateTimer()(c)
 }
 func deleteTimer(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodDelete, timerPath("/:id"+"?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(timerId.String())
-	return rec, timerHandler.DeleteTimer(ctx)(c)
+	return rec, timerHandler.DeleteTimer()(c)
 }
 
 func subscribe(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodPost, timerPath("/:id/subscribe?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(timerId.String())
-	return rec, timerHandler.Subscribe(ctx)(c)
+	return rec, timerHandler.Subscribe()(c)
 }
 
 func userNotifications(ctx context.Context, userId int64) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodGet, notificationPath("?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, notificationHandler.NotificationsByUser(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, notificationHandler.NotificationsByUser()(c)
 }
 
 func deleteNotifications(ctx context.Context, userId int64) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodDelete, notificationPath("?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, notificationHandler.Delete(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, notificationHandler.Delete()(c)
 }
 
 var (
@@ -132,11 +134,11 @@ func TestMain(m *testing.M) {
 	)
 	go ns.Start(ctx)
 
-	timerUseCase = timerusecase.New(ts, subst, timerService, es, ns)
+	timerUseCase = timerusecase.New(ts, subst, timerService, colorusecase.New(colorstorage.New(p), time.Minute), es, ns)
 	countdownUseCase := countdowntimerusecase.New(timerService, ts, es)
 	notificationUseCase := notificationusecase.New(notificationStorage)
 
-	timerHandler = timerhandler.New(countdownUseCase, timerUseCase)
+	timerHandler = timerhandler.New(countdownUseCase, timerUseCase, nil)
 	notificationHandler = notificationhandler.New(notificationUseCase)
 
 	m.Run()
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/ratelimit"
+
+type Limiter interface {
+	Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error)
+}
+
+type Config struct {
+	// tokens per second, 0 disables rate limit
+	Rate float64
+	// max tokens in bucket
+	Burst int
+}
+
+// mutating requests are limited, reads aren't
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+// rate limit of mutating requests by token bucket of user, must be used after vk middleware which verifies vk_user_id
+// requests without user are limited by ip, if limiter fails request isn't limited
+func Middleware(limiter Limiter, config Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if config.Rate <= 0 {
+			return next
+		}
+		burst := config.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		return func(c echo.Context) error {
+			if !mutating(c.Request().Method) {
+				return next(c)
+			}
+			key := "user_" + c.QueryParam(vk.USER_ID)
+			if _, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64); err != nil {
+				key = "ip_" + c.RealIP()
+			}
+			ok, wait, err := limiter.Take(c.Request().Context(), key, config.Rate, burst)
+			if err != nil {
+				logging.FromContext(c.Request().Context()).Error("rate limit failed, request is allowed",
+					logging.Err(exception.Wrap(err, exception.NewCause("take token", "Middleware", _PROVIDER))),
+				)
+				return next(c)
+			}
+			if !ok {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				return exception.Wrap(timererror.ExceptionRateLimit(), exception.NewCause("rate limit exceeded", "Middleware", _PROVIDER))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/ratelimit"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// limiter with fixed amount of tokens per key
+type limiter map[string]int
+
+func (l limiter) Take(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	if l[key] == 0 {
+		return false, 1500 * time.Millisecond, nil
+	}
+	l[key]--
+	return true, 0, nil
+}
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	l := limiter{"user_1": 1}
+	handler := ratelimit.Middleware(l, ratelimit.Config{Rate: 1, Burst: 1})(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	request := func(method string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(method, "/timers/create?vk_user_id=1", nil)
+		rec := httptest.NewRecorder()
+		return rec, handler(e.NewContext(req, rec))
+	}
+
+	_, err := request(http.MethodPost)
+	require.NoError(t, err, "first request limited")
+
+	rec, err := request(http.MethodPost)
+	require.ErrorIs(t, err, timererror.ExceptionRateLimit(), "wrong limit error")
+	require.Equal(t, "2", rec.Header().Get("Retry-After"), "wrong retry after")
+
+	_, err = request(http.MethodGet)
+	require.NoError(t, err, "read request limited")
+}
@@ -0,0 +1,21 @@
+package requestid
+
+import (
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// header of request id, id of client is reused, otherwise new id is generated
+const Header = echo.HeaderXRequestID
+
+// set request id to response header and to logger of request context
+func Middleware() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		TargetHeader: Header,
+		RequestIDHandler: func(c echo.Context, id string) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(logging.WithRequestID(req.Context(), id)))
+		},
+	})
+}
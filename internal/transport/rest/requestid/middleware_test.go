@@ -0,0 +1,69 @@
+package requestid_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/requestid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/exp/slog"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(requestid.Middleware())
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, logging.RequestID(c.Request().Context()))
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	id := rec.Header().Get(requestid.Header)
+	require.NotEmpty(t, id, "request id not generated")
+	require.Equal(t, id, rec.Body.String(), "request id not in context")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestid.Header, "client-id")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, "client-id", rec.Header().Get(requestid.Header), "client request id not reused")
+	require.Equal(t, "client-id", rec.Body.String(), "client request id not in context")
+}
+
+func TestRequestLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	prevLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prevLogger) })
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(prevProvider) })
+
+	// middlewares are in the same order as in app
+	e := echo.New()
+	e.Use(otelecho.Middleware(tracing.ServiceName))
+	e.Use(requestid.Middleware())
+	e.GET("/", func(c echo.Context) error {
+		// usecases and storages log with request context of handler
+		logging.FromContext(c.Request().Context()).Info("message")
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(requestid.Header, "client-id")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	record := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), "unmarshal record failed")
+	require.Equal(t, "client-id", record["request_id"], "request id not logged")
+	require.NotEmpty(t, record["trace_id"], "trace id not logged")
+}
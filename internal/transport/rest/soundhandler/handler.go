@@ -0,0 +1,142 @@
+package soundhandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/soundmodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/soundhandler"
+
+type SoundUseCase interface {
+	Sounds(ctx context.Context) ([]*soundmodel.Sound, error)
+	SetSubscriberSound(ctx context.Context, timerId uuid.UUID, userId int64, sound *timermodel.SubscriberSound) error
+}
+
+type Handler struct {
+	useCase SoundUseCase
+}
+
+func New(useCase SoundUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// sounds catalog is registered in public group, it is same for every user
+func Init(e *echo.Group, public *echo.Group, useCase SoundUseCase) {
+	handler := &Handler{useCase: useCase}
+
+	public.GET("/sounds", handler.Sounds())
+
+	timers := e.Group("/timers")
+	timers.PUT("/:id/sound", handler.SetSubscriberSound())
+	timers.DELETE("/:id/sound", handler.ResetSubscriberSound())
+}
+
+// Sounds godoc
+//
+//	@Summary		Sounds
+//	@Description	catalog of alarm sounds in catalog order, sound id is used as timer soundId
+//	@Tags			sounds
+//	@Produce		json
+//	@Success		200	{array}		soundmodel.Sound
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/sounds [get]
+func (h *Handler) Sounds() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		sounds, err := h.useCase.Sounds(ctx)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get sounds", "Sounds", _PROVIDER))
+		}
+		c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+		return c.JSON(http.StatusOK, sounds)
+	}
+}
+
+// SetSubscriberSound godoc
+//
+//	@Summary		SetSubscriberSound
+//	@Description	pick alarm sound of timer for user, it is played instead of timer sound and returned in subscriberSound of timer
+//	@Description	sound is private for user, only subscribers (creator inclusive) can pick sound, empty volume is max volume
+//	@Tags			sounds
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			id			path	string						true	"timer id"
+//	@Param			sound		body	timermodel.SubscriberSound	true	"sound"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/sound [put]
+func (h *Handler) SetSubscriberSound() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "SetSubscriberSound", _PROVIDER))
+		}
+		sound := new(timermodel.SubscriberSound)
+		err = c.Bind(sound)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "SetSubscriberSound", _PROVIDER))
+		}
+		err = sound.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate sound", "SetSubscriberSound", _PROVIDER))
+		}
+		err = h.useCase.SetSubscriberSound(ctx, timerId, userId, sound)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set subscriber sound", "SetSubscriberSound", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// ResetSubscriberSound godoc
+//
+//	@Summary		ResetSubscriberSound
+//	@Description	return user to timer sound
+//	@Tags			sounds
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/sound [delete]
+func (h *Handler) ResetSubscriberSound() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "ResetSubscriberSound", _PROVIDER))
+		}
+		err = h.useCase.SetSubscriberSound(ctx, timerId, userId, nil)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("reset subscriber sound", "ResetSubscriberSound", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func userIdTimerId(c echo.Context) (int64, uuid.UUID, error) {
+	userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+	if err != nil {
+		return 0, uuid.Nil, errors.Join(err, errors.New("user id parse error"))
+	}
+	timerId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return 0, uuid.Nil, errors.Join(err, errors.New("timer id parse error"))
+	}
+	return userId, timerId, nil
+}
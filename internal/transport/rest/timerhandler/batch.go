@@ -0,0 +1,183 @@
+package timerhandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/labstack/echo/v4"
+)
+
+// 200 if every item succeeded, otherwise 207 with result of every item
+func batchResponse(c echo.Context, result *timermodel.BatchResult) error {
+	if result.Failed > 0 {
+		return c.JSON(http.StatusMultiStatus, result)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// BatchCreate godoc
+//
+//	@Summary		BatchCreate
+//	@Description	create up to 100 user timers in one transaction
+//	@Description	atomic batch creates all timers or nothing, otherwise every timer is created independently
+//	@Tags			timers
+//	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64					true	"user id"
+//	@Param			batch		body	timermodel.BatchCreate	true	"timers"
+//	@Produce		json
+//	@Accept			json
+//	@Success		200	{object}	timermodel.BatchResult
+//	@Success		207	{object}	timermodel.BatchResult
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/batch/create [post]
+func (h *Handler) BatchCreate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "BatchCreate", _PROVIDER))
+		}
+		batch := new(timermodel.BatchCreate)
+		err = c.Bind(batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "BatchCreate", _PROVIDER))
+		}
+		err = batch.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "BatchCreate", _PROVIDER))
+		}
+		result, err := h.timerUseCase.BatchCreate(ctx, userId, batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("create timers", "BatchCreate", _PROVIDER))
+		}
+		return batchResponse(c, result)
+	}
+}
+
+// BatchDelete godoc
+//
+//	@Summary		BatchDelete
+//	@Description	delete up to 100 user timers in one transaction
+//	@Description	atomic batch deletes all timers or nothing, otherwise every timer is deleted independently
+//	@Tags			timers
+//	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64					true	"user id"
+//	@Param			batch		body	timermodel.BatchDelete	true	"timer ids"
+//	@Produce		json
+//	@Accept			json
+//	@Success		200	{object}	timermodel.BatchResult
+//	@Success		207	{object}	timermodel.BatchResult
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/batch/delete [post]
+func (h *Handler) BatchDelete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "BatchDelete", _PROVIDER))
+		}
+		batch := new(timermodel.BatchDelete)
+		err = c.Bind(batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "BatchDelete", _PROVIDER))
+		}
+		err = batch.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "BatchDelete", _PROVIDER))
+		}
+		result, err := h.timerUseCase.BatchDelete(ctx, userId, batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("delete timers", "BatchDelete", _PROVIDER))
+		}
+		return batchResponse(c, result)
+	}
+}
+
+// BatchSubscribe godoc
+//
+//	@Summary		BatchSubscribe
+//	@Description	subscribe up to 100 users to timer in one transaction, only timer creator can subscribe users
+//	@Description	atomic batch subscribes all users or nobody, otherwise every user is subscribed independently
+//	@Tags			timers
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			batch		body	timermodel.BatchSubscribers	true	"timer id and user ids"
+//	@Produce		json
+//	@Accept			json
+//	@Success		200	{object}	timermodel.BatchResult
+//	@Success		207	{object}	timermodel.BatchResult
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/batch/subscribe [post]
+func (h *Handler) BatchSubscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "BatchSubscribe", _PROVIDER))
+		}
+		batch := new(timermodel.BatchSubscribers)
+		err = c.Bind(batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "BatchSubscribe", _PROVIDER))
+		}
+		err = batch.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "BatchSubscribe", _PROVIDER))
+		}
+		result, err := h.timerUseCase.BatchSubscribe(ctx, userId, batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("subscribe users", "BatchSubscribe", _PROVIDER))
+		}
+		return batchResponse(c, result)
+	}
+}
+
+// BatchUnsubscribe godoc
+//
+//	@Summary		BatchUnsubscribe
+//	@Description	unsubscribe up to 100 users from timer in one transaction, only timer creator can unsubscribe users
+//	@Description	atomic batch unsubscribes all users or nobody, otherwise every user is unsubscribed independently
+//	@Tags			timers
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			batch		body	timermodel.BatchSubscribers	true	"timer id and user ids"
+//	@Produce		json
+//	@Accept			json
+//	@Success		200	{object}	timermodel.BatchResult
+//	@Success		207	{object}	timermodel.BatchResult
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/batch/unsubscribe [post]
+func (h *Handler) BatchUnsubscribe() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "BatchUnsubscribe", _PROVIDER))
+		}
+		batch := new(timermodel.BatchSubscribers)
+		err = c.Bind(batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "BatchUnsubscribe", _PROVIDER))
+		}
+		err = batch.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "BatchUnsubscribe", _PROVIDER))
+		}
+		result, err := h.timerUseCase.BatchUnsubscribe(ctx, userId, batch)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("unsubscribe users", "BatchUnsubscribe", _PROVIDER))
+		}
+		return batchResponse(c, result)
+	}
+}
@@ -0,0 +1,53 @@
+package timerhandler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/ical"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const icsExtension = ".ics"
+
+func (h *Handler) timerOrCalendar() echo.HandlerFunc {
+	timer := h.Timer()
+	calendar := h.TimerCalendar()
+	return func(c echo.Context) error {
+		if strings.HasSuffix(c.Param("id"), icsExtension) {
+			return calendar(c)
+		}
+		return timer(c)
+	}
+}
+
+// TimerCalendar godoc
+//
+//	@Summary		TimerCalendar
+//	@Description	export timer as iCalendar file, date timer is event at end time, countdown timer is event of current run, paused countdown timer can't be exported
+//	@Tags			timers
+//	@Param			debug	query	string	false	"you can add secret key to query for debug requests"
+//	@Param			id		path	string	true	"timer id"
+//	@Produce		text/calendar
+//	@Success		200	{string}	string
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}.ics [get]
+func (h *Handler) TimerCalendar() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := uuid.Parse(strings.TrimSuffix(c.Param("id"), icsExtension))
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse timer id", "TimerCalendar", _PROVIDER))
+		}
+		b, err := h.calendarUseCase.TimerCalendar(ctx, id)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get timer calendar", "TimerCalendar", _PROVIDER))
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+id.String()+icsExtension+`"`)
+		return c.Blob(http.StatusOK, ical.ContentType, b)
+	}
+}
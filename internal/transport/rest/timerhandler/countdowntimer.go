@@ -1,48 +1,55 @@
 package timerhandler
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/pkg/exception"
 	"github.com/labstack/echo/v4"
 )
 
 /*
-	group.PATCH("/:id/stop", handler.StopTimer(ctx))
-	group.PATCH("/:id/start", handler.StartTimer(ctx))
-	group.PATCH("/:id/reset", handler.ResetTimer(ctx))
+	group.PATCH("/:id/stop", handler.StopTimer())
+	group.PATCH("/:id/start", handler.StartTimer())
+	group.PATCH("/:id/reset", handler.ResetTimer())
+	group.PATCH("/:id/adjust", handler.AdjustTimer())
 */
 
 // StopTimer godoc
 //
 //	@Summary		StopTimer
 //	@Description	stop timer by timer id, only owner can stop timer, every subscriber (creator inclusive) will be send stop event
+//	@Description	server time is used as pause time, new timer version is returned in ETag
 //	@Tags			timers
 //	@Param			vk_user_id	query	int64	true	"user id"
-//	@Param			pauseTime	query	int64	true	"pause time, 1690465114"
+//	@Param			pauseTime	query	int64	false	"deprecated, ignored, server time is used"
 //	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
 //	@Param			id			path	string	true	"timer id"
+//	@Param			If-Match	header	string	true	"timer version from ETag, * matches any version"
 //	@Success		204
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		428	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/stop [patch]
-func (h *Handler) StopTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) StopTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "StopTimer", _PROVIDER))
 		}
-		pauseTime, err := strconv.ParseInt(c.QueryParam("pauseTime"), 10, 64)
+		// stop timer by id uuid
+		err = h.countdownTimerUseCase.Stop(ctx, timerId, userId, expectedVersion(c))
 		if err != nil {
-			return exception.Wrap(err, exception.NewCause("parse pause time", "StopTimer", _PROVIDER))
+			return exception.Wrap(err, exception.NewCause("stop timer", "StopTimer", _PROVIDER))
 		}
-		// delete timer by id uuid
-		err = h.countdownTimerUseCase.Stop(ctx, timerId, userId, pauseTime)
+		_, err = h.setETag(ctx, c, timerId)
 		if err != nil {
-			return exception.Wrap(err, exception.NewCause("stop timer", "StopTimer", _PROVIDER))
+			return exception.Wrap(err, exception.NewCause("set etag", "StopTimer", _PROVIDER))
 		}
 		return c.NoContent(http.StatusNoContent)
 	}
@@ -56,23 +63,31 @@ func (h *Handler) StopTimer(ctx context.Context) echo.HandlerFunc {
 //	@Param			vk_user_id	query	int64	true	"user id"
 //	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
 //	@Param			id			path	string	true	"timer id"
+//	@Param			If-Match	header	string	true	"timer version from ETag, * matches any version"
 //	@Produce		json
 //	@Success		200	{object}	timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		428	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/start [patch]
-func (h *Handler) StartTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) StartTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "StartTimer", _PROVIDER))
 		}
 		// start timer
-		timer, err := h.countdownTimerUseCase.Start(ctx, timerId, userId)
+		timer, err := h.countdownTimerUseCase.Start(ctx, timerId, userId, expectedVersion(c))
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("start timer", "StartTimer", _PROVIDER))
 		}
+		timer.Version, err = h.setETag(ctx, c, timerId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set etag", "StartTimer", _PROVIDER))
+		}
 		return c.JSON(http.StatusOK, timer)
 	}
 }
@@ -85,23 +100,74 @@ func (h *Handler) StartTimer(ctx context.Context) echo.HandlerFunc {
 //	@Param			vk_user_id	query	int64	true	"user id"
 //	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
 //	@Param			id			path	string	true	"timer id"
+//	@Param			If-Match	header	string	true	"timer version from ETag, * matches any version"
 //	@Produce		json
 //	@Success		200	{object}	timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		428	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/reset [patch]
-func (h *Handler) ResetTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) ResetTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "ResetTimer", _PROVIDER))
 		}
 		// start timer
-		timer, err := h.countdownTimerUseCase.Reset(ctx, timerId, userId)
+		timer, err := h.countdownTimerUseCase.Reset(ctx, timerId, userId, expectedVersion(c))
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("reset timer", "ResetTimer", _PROVIDER))
 		}
+		timer.Version, err = h.setETag(ctx, c, timerId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set etag", "ResetTimer", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, timer)
+	}
+}
+
+// AdjustTimer godoc
+//
+//	@Summary		AdjustTimer
+//	@Description	add or subtract time of running or paused countdown timer, end time and duration are shifted by delta
+//	@Description	time left and duration after shift should be not less than min timer duration, every subscriber (creator inclusive) will be send adjust event
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			delta		query	int64	true	"seconds to add, negative to subtract, e.g. 300 or -60, absolute value not greater than 10 years"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			If-Match	header	string	true	"timer version from ETag, * matches any version"
+//	@Produce		json
+//	@Success		200	{object}	timermodel.Timer
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		428	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/adjust [patch]
+func (h *Handler) AdjustTimer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "AdjustTimer", _PROVIDER))
+		}
+		delta, err := strconv.ParseInt(c.QueryParam("delta"), 10, 64)
+		if err != nil {
+			return exception.Wrap(errors.Join(err, timererror.ExceptionWrongDelta()), exception.NewCause("parse delta", "AdjustTimer", _PROVIDER))
+		}
+		timer, err := h.countdownTimerUseCase.Adjust(ctx, timerId, userId, delta, expectedVersion(c))
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("adjust timer", "AdjustTimer", _PROVIDER))
+		}
+		timer.Version, err = h.setETag(ctx, c, timerId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set etag", "AdjustTimer", _PROVIDER))
+		}
 		return c.JSON(http.StatusOK, timer)
 	}
 }
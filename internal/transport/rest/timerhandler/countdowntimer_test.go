@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/countdowntimerusecase"
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
@@ -25,10 +27,10 @@ func stopTimer(ctx context.Context, timerId uuid.UUID, userId int64, pauseTime i
 	v.Set("pauseTime", fmt.Sprint(pauseTime))
 	req := httptest.NewRequest(http.MethodPatch, basePath("/:id/stop?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(timerId.String())
-	return rec, handler.StopTimer(ctx)(c)
+	return rec, handler.StopTimer()(c)
 }
 
 func startTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*httptest.ResponseRecorder, error) {
@@ -36,10 +38,10 @@ func startTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*httptest
 	v.Set("vk_user_id", fmt.Sprint(userId))
 	req := httptest.NewRequest(http.MethodPatch, basePath("/:id/start?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(timerId.String())
-	return rec, handler.StartTimer(ctx)(c)
+	return rec, handler.StartTimer()(c)
 }
 
 func resetTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*httptest.ResponseRecorder, error) {
@@ -47,10 +49,22 @@ func resetTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*httptest
 	v.Set("vk_user_id", fmt.Sprint(userId))
 	req := httptest.NewRequest(http.MethodPatch, basePath("/:id/reset?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(timerId.String())
-	return rec, handler.ResetTimer(ctx)(c)
+	return rec, handler.ResetTimer()(c)
+}
+
+func adjustTimer(ctx context.Context, timerId uuid.UUID, userId int64, delta int64) (*httptest.ResponseRecorder, error) {
+	v := make(url.Values)
+	v.Set("vk_user_id", fmt.Sprint(userId))
+	v.Set("delta", fmt.Sprint(delta))
+	req := httptest.NewRequest(http.MethodPatch, basePath("/:id/adjust?"+v.Encode()), new(bytes.Buffer))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req.WithContext(ctx), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(timerId.String())
+	return rec, handler.AdjustTimer()(c)
 }
 
 func randomPause(until time.Duration) {
@@ -107,9 +121,10 @@ func stop(t *testing.T, ctx context.Context, timer *timermodel.Timer, pauseTime
 	_, err = stopTimer(ctx, timer.ID, timer.Creator, pauseTime.Unix())
 	require.ErrorIs(t, err, timererror.ExceptionTimerIsPaused(), "stop timer which already stopped")
 
+	// server time is pause time
 	tm, err := timerStorage.Timer(ctx, timer.ID)
 	require.NoError(t, err, "get timer from storage failed")
-	require.Equal(t, pauseTime.Unix(), tm.PauseTime.Unix(), "wrong pause time")
+	require.InDelta(t, time.Now().Unix(), tm.PauseTime.Unix(), 2, "wrong pause time")
 	require.True(t, tm.IsPaused, "timer not paused")
 	return tm
 }
@@ -170,3 +185,51 @@ func reset(t *testing.T, ctx context.Context, timer *timermodel.Timer) *timermod
 
 	return timer
 }
+
+func TestAdjustTimer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+	duration := int64(600)
+	timer := randomTimer(func(t *timermodel.Timer) {
+		t.Creator = userId
+		t.EndTime = amidtime.DateTime(time.Now().Add(time.Second * time.Duration(duration)))
+		t.Duration = duration
+		t.Type = timerfields.COUNTDOWN
+	})
+	_, err := createTimer(ctx, userId, timer.CreateTimer())
+	require.NoError(t, err, "create timer failed")
+
+	// add time to running timer
+	rec, err := adjustTimer(ctx, timer.ID, userId, 300)
+	require.NoError(t, err, "adjust running timer failed")
+	require.Equal(t, http.StatusOK, rec.Result().StatusCode, "wrong status code of adjust")
+	tm, err := timerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer from storage failed")
+	require.Equal(t, timer.EndTime.Unix()+300, tm.EndTime.Unix(), "end time not shifted")
+	require.Equal(t, duration+300, tm.Duration, "duration not shifted")
+
+	// subtract time from paused timer
+	_, err = stopTimer(ctx, timer.ID, userId, 0)
+	require.NoError(t, err, "stop timer failed")
+	_, err = adjustTimer(ctx, timer.ID, userId, -60)
+	require.NoError(t, err, "adjust paused timer failed")
+	paused, err := timerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer from storage failed")
+	require.Equal(t, tm.EndTime.Unix()-60, paused.EndTime.Unix(), "end time of paused timer not shifted")
+	require.True(t, paused.IsPaused, "timer started by adjust")
+
+	// time left can't be negative
+	_, err = adjustTimer(ctx, timer.ID, userId, -paused.Duration)
+	require.ErrorIs(t, err, timererror.ExceptionWrongTimerTime(), "adjust over time left wrong error")
+	_, err = adjustTimer(ctx, timer.ID, userId, 0)
+	require.ErrorIs(t, err, timererror.ExceptionWrongDelta(), "zero delta wrong error")
+	_, err = adjustTimer(ctx, timer.ID, userId, math.MaxInt64)
+	require.ErrorIs(t, err, timererror.ExceptionWrongDelta(), "overflowing delta wrong error")
+	_, err = adjustTimer(ctx, timer.ID, userId, -countdowntimerusecase.MAX_ADJUST_DELTA-1)
+	require.ErrorIs(t, err, timererror.ExceptionWrongDelta(), "too small delta wrong error")
+	_, err = adjustTimer(ctx, timer.ID, rand.Int63(), 60)
+	require.ErrorIs(t, err, timererror.ExceptionUserForbidden(), "adjust by not creator wrong error")
+
+	clearTimers(t, ctx, timer)
+}
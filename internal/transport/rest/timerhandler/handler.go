@@ -16,55 +16,91 @@ const _PROVIDER = "internal/transport/rest/timerhandler"
 type TimerUseCase interface {
 	Create(ctx context.Context, creator int64, timer *timermodel.CreateTimer) error
 	Delete(ctx context.Context, timerId uuid.UUID, userId int64) error
-	Update(ctx context.Context, timerId uuid.UUID, userId int64, timer *timermodel.TimerSettings) error
+	Clone(ctx context.Context, timerId uuid.UUID, userId int64, clone *timermodel.CloneTimer) (*timermodel.Timer, error)
+	Update(ctx context.Context, timerId uuid.UUID, userId int64, timer *timermodel.TimerSettings, version int64) error
+	Patch(ctx context.Context, timerId uuid.UUID, userId int64, patch *timermodel.TimerPatch, version int64) error
 	Subscribe(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
 	Unsubscribe(ctx context.Context, timerId uuid.UUID, userId int64) error
 
+	BatchCreate(ctx context.Context, creator int64, batch *timermodel.BatchCreate) (*timermodel.BatchResult, error)
+	BatchDelete(ctx context.Context, userId int64, batch *timermodel.BatchDelete) (*timermodel.BatchResult, error)
+	BatchSubscribe(ctx context.Context, userId int64, batch *timermodel.BatchSubscribers) (*timermodel.BatchResult, error)
+	BatchUnsubscribe(ctx context.Context, userId int64, batch *timermodel.BatchSubscribers) (*timermodel.BatchResult, error)
+
 	TimerSubscribers(ctx context.Context, timerId uuid.UUID) ([]int64, error)
+	TimerSubscribersPage(ctx context.Context, timerId uuid.UUID, userId int64, offset, limit int) ([]*timermodel.Subscriber, error)
+	RemoveSubscriber(ctx context.Context, timerId uuid.UUID, userId int64, subscriberId int64, ban bool) error
+	Unban(ctx context.Context, timerId uuid.UUID, userId int64, bannedId int64) error
+	SubscribersLimit(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.SubscribersLimit, error)
+	SetSubscribersLimit(ctx context.Context, timerId uuid.UUID, userId int64, maxSubscribers int) (*timermodel.SubscribersLimit, error)
 
 	UserSubscriptions(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
 	UserCreatedTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
 	UserTimers(ctx context.Context, userId int64, offset, limit int) ([]*timermodel.Timer, error)
+	FilteredTimers(ctx context.Context, userId int64, filter *timermodel.TimerFilter, offset, limit int) ([]*timermodel.Timer, error)
 	Timer(ctx context.Context, timerId uuid.UUID) (*timermodel.Timer, error)
+	UserTimer(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
+
+	TimerVersion(ctx context.Context, timerId uuid.UUID) (int64, error)
 }
 
 type CountdownTimerUseCase interface {
-	Stop(ctx context.Context, timerId uuid.UUID, userId int64, pauseTime int64) error
-	Start(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
-	Reset(ctx context.Context, timerId uuid.UUID, userId int64) (*timermodel.Timer, error)
+	Stop(ctx context.Context, timerId uuid.UUID, userId int64, version int64) error
+	Start(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error)
+	Reset(ctx context.Context, timerId uuid.UUID, userId int64, version int64) (*timermodel.Timer, error)
+	Adjust(ctx context.Context, timerId uuid.UUID, userId int64, delta int64, version int64) (*timermodel.Timer, error)
+}
+
+type CalendarUseCase interface {
+	TimerCalendar(ctx context.Context, timerId uuid.UUID) ([]byte, error)
 }
 
 type Handler struct {
 	countdownTimerUseCase CountdownTimerUseCase
 	timerUseCase          TimerUseCase
+	calendarUseCase       CalendarUseCase
 }
 
-func New(countdownTimerUseCase CountdownTimerUseCase, timerUseCase TimerUseCase) *Handler {
-	return &Handler{countdownTimerUseCase: countdownTimerUseCase, timerUseCase: timerUseCase}
+func New(countdownTimerUseCase CountdownTimerUseCase, timerUseCase TimerUseCase, calendarUseCase CalendarUseCase) *Handler {
+	return &Handler{countdownTimerUseCase: countdownTimerUseCase, timerUseCase: timerUseCase, calendarUseCase: calendarUseCase}
 }
 
-func Init(e *echo.Group, timerUseCase TimerUseCase, countdownTimerUseCase CountdownTimerUseCase) {
+func Init(e *echo.Group, timerUseCase TimerUseCase, countdownTimerUseCase CountdownTimerUseCase, calendarUseCase CalendarUseCase) {
 
-	handler := &Handler{timerUseCase: timerUseCase, countdownTimerUseCase: countdownTimerUseCase}
+	handler := &Handler{timerUseCase: timerUseCase, countdownTimerUseCase: countdownTimerUseCase, calendarUseCase: calendarUseCase}
 	group := e.Group("/timers")
-	ctx := context.Background()
-
-	group.GET("/user-subscriptions", handler.UserSubscriptions(ctx))
-	group.GET("/user-created", handler.UserCreated(ctx))
-	group.GET("/:id/subscribers", handler.TimerSubscribers(ctx))
-	group.GET("/user", handler.TimersByUser(ctx))
 
-	group.POST("/create", handler.CreateTimer(ctx))
-	group.DELETE("/:id", handler.DeleteTimer(ctx))
-	group.PUT("/:id", handler.UpdateTimer(ctx))
-	group.GET("/:id", handler.Timer(ctx))
-
-	group.POST("/:id/subscribe", handler.Subscribe(ctx))
-	group.DELETE("/:id/unsubscribe", handler.Unsubscribe(ctx))
-
-	group.PATCH("/:id/stop", handler.StopTimer(ctx))
-	group.PATCH("/:id/start", handler.StartTimer(ctx))
-	group.PATCH("/:id/reset", handler.ResetTimer(ctx))
+	group.GET("/user-subscriptions", handler.UserSubscriptions())
+	group.GET("/user-created", handler.UserCreated())
+	group.GET("/:id/subscribers", handler.TimerSubscribers())
+	group.GET("/user", handler.TimersByUser())
+
+	group.POST("/create", handler.CreateTimer())
+	group.DELETE("/:id", handler.DeleteTimer())
+	group.PUT("/:id", handler.versioned(handler.UpdateTimer()))
+	group.PATCH("/:id", handler.optionalVersioned(handler.PatchTimer()))
+	// /timers/:id.ics is handled by the same route, echo param can't have static suffix
+	group.GET("/:id", handler.timerOrCalendar())
+
+	group.POST("/:id/subscribe", handler.Subscribe())
+	group.DELETE("/:id/unsubscribe", handler.Unsubscribe())
+	group.POST("/:id/clone", handler.CloneTimer())
+
+	group.GET("/:id/subscribers/list", handler.TimerSubscribersPage())
+	group.DELETE("/:id/subscribers/:userId", handler.RemoveSubscriber())
+	group.DELETE("/:id/bans/:userId", handler.Unban())
+	group.GET("/:id/subscribers/limit", handler.SubscribersLimit())
+	group.PUT("/:id/subscribers/limit", handler.SetSubscribersLimit())
+
+	group.PATCH("/:id/stop", handler.versioned(handler.StopTimer()))
+	group.PATCH("/:id/start", handler.versioned(handler.StartTimer()))
+	group.PATCH("/:id/reset", handler.versioned(handler.ResetTimer()))
+	group.PATCH("/:id/adjust", handler.versioned(handler.AdjustTimer()))
+
+	group.POST("/batch/create", handler.BatchCreate())
+	group.POST("/batch/delete", handler.BatchDelete())
+	group.POST("/batch/subscribe", handler.BatchSubscribe())
+	group.POST("/batch/unsubscribe", handler.BatchUnsubscribe())
 }
 
 func offsetLimit(c echo.Context) (offset, limit int, err error) {
@@ -79,6 +115,19 @@ func offsetLimit(c echo.Context) (offset, limit int, err error) {
 	return
 }
 
+// filter of timer list by optional folderId and tag query params
+func timerFilter(c echo.Context, list timermodel.TimerList) (*timermodel.TimerFilter, error) {
+	filter := &timermodel.TimerFilter{List: list, Tag: c.QueryParam("tag")}
+	if folder := c.QueryParam("folderId"); folder != "" {
+		folderId, err := uuid.Parse(folder)
+		if err != nil {
+			return nil, errors.Join(err, errors.New("folder id parse error"))
+		}
+		filter.FolderId = &folderId
+	}
+	return filter, nil
+}
+
 func userIdTimerId(c echo.Context) (int64, uuid.UUID, error) {
 	// parse vk_user_id
 	userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
@@ -9,8 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Tap-Team/timerapi/internal/database/postgres/colorstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/timerstorage"
 	"github.com/Tap-Team/timerapi/internal/database/redis/subscriberstorage"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/countdowntimerusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
@@ -161,6 +163,7 @@ func TestMain(m *testing.M) {
 		timerStorage,
 		subStorage,
 		timerService,
+		colorusecase.New(colorstorage.New(p), time.Minute),
 		&ESender{},
 		&NSender{},
 	)
@@ -171,6 +174,6 @@ func TestMain(m *testing.M) {
 		&ESender{},
 	)
 
-	handler = timerhandler.New(countdownTimerUseCase, timerUseCase)
+	handler = timerhandler.New(countdownTimerUseCase, timerUseCase, nil)
 	m.Run()
 }
@@ -1,7 +1,6 @@
 package timerhandler
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/Tap-Team/timerapi/pkg/exception"
@@ -22,8 +21,9 @@ import (
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/subscribe [post]
-func (h *Handler) Subscribe(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) Subscribe() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "Subscribe", _PROVIDER))
@@ -50,8 +50,9 @@ func (h *Handler) Subscribe(ctx context.Context) echo.HandlerFunc {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/unsubscribe [delete]
-func (h *Handler) Unsubscribe(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) Unsubscribe() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "Unsubscribe", _PROVIDER))
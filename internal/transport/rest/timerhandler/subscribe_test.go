@@ -33,8 +33,8 @@ func userSubscriptions(ctx context.Context, userId int64, offset, limit int) (*h
 	v.Set("offset", fmt.Sprint(offset))
 	req := httptest.NewRequest(http.MethodGet, basePath("/user-subscriptions?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, handler.UserSubscriptions(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, handler.UserSubscriptions()(c)
 }
 
 func userCreated(ctx context.Context, userId int64, offset, limit int) (*httptest.ResponseRecorder, error) {
@@ -44,8 +44,8 @@ func userCreated(ctx context.Context, userId int64, offset, limit int) (*httptes
 	v.Set("offset", fmt.Sprint(offset))
 	req := httptest.NewRequest(http.MethodGet, basePath("/user-created?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, handler.UserCreated(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, handler.UserCreated()(c)
 }
 
 func timersByUser(ctx context.Context, userId int64, offset, limit int) (*httptest.ResponseRecorder, error) {
@@ -55,26 +55,26 @@ func timersByUser(ctx context.Context, userId int64, offset, limit int) (*httpte
 	v.Set("offset", fmt.Sprint(offset))
 	req := httptest.NewRequest(http.MethodGet, basePath("/user-created?"+v.Encode()), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, handler.TimersByUser(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, handler.TimersByUser()(c)
 }
 
 func subscribe(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodPost, basePath("/:id/subscribe?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.Subscribe(ctx)(c)
+	return rec, handler.Subscribe()(c)
 }
 
 func unsubscribe(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodPost, basePath("/:id/subscribe?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.Unsubscribe(ctx)(c)
+	return rec, handler.Unsubscribe()(c)
 }
 
 func TestUserTimers(t *testing.T) {
@@ -0,0 +1,200 @@
+package timerhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/labstack/echo/v4"
+)
+
+func subscriberId(c echo.Context) (int64, error) {
+	subscriberId, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return 0, errors.Join(err, errors.New("subscriber id parse error"))
+	}
+	return subscriberId, nil
+}
+
+// TimerSubscribersPage godoc
+//
+//	@Summary		TimerSubscribersPage
+//	@Description	page of timer subscribers with subscription time ordered by subscription time, creator isn't listed
+//	@Description	only timer creator can get list
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			offset		query	int		true	"offset"
+//	@Param			limit		query	int		true	"limit"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Produce		json
+//	@Success		200	{array}		timermodel.Subscriber
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/subscribers/list [get]
+func (h *Handler) TimerSubscribersPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "TimerSubscribersPage", _PROVIDER))
+		}
+		offset, limit, err := offsetLimit(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse offset limit", "TimerSubscribersPage", _PROVIDER))
+		}
+		subscribers, err := h.timerUseCase.TimerSubscribersPage(ctx, timerId, userId, offset, limit)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get subscribers page", "TimerSubscribersPage", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, subscribers)
+	}
+}
+
+// RemoveSubscriber godoc
+//
+//	@Summary		RemoveSubscriber
+//	@Description	creator removes subscriber from timer, removed user gets notification_removed notification
+//	@Description	with ban=true user also can't subscribe again, user which isn't subscribed can be banned too
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			userId		path	int64	true	"subscriber id"
+//	@Param			ban			query	bool	false	"ban user"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/subscribers/{userId} [delete]
+func (h *Handler) RemoveSubscriber() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "RemoveSubscriber", _PROVIDER))
+		}
+		subscriberId, err := subscriberId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse subscriber id", "RemoveSubscriber", _PROVIDER))
+		}
+		ban := c.QueryParam("ban") == "true"
+		err = h.timerUseCase.RemoveSubscriber(ctx, timerId, userId, subscriberId, ban)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("remove subscriber", "RemoveSubscriber", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Unban godoc
+//
+//	@Summary		Unban
+//	@Description	creator allows banned user to subscribe again
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			userId		path	int64	true	"banned user id"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/bans/{userId} [delete]
+func (h *Handler) Unban() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "Unban", _PROVIDER))
+		}
+		bannedId, err := subscriberId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse banned user id", "Unban", _PROVIDER))
+		}
+		err = h.timerUseCase.Unban(ctx, timerId, userId, bannedId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("unban user", "Unban", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// SubscribersLimit godoc
+//
+//	@Summary		SubscribersLimit
+//	@Description	max subscribers of timer and current subscribers count, 0 max subscribers is unlimited
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Produce		json
+//	@Success		200	{object}	timermodel.SubscribersLimit
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/subscribers/limit [get]
+func (h *Handler) SubscribersLimit() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "SubscribersLimit", _PROVIDER))
+		}
+		limit, err := h.timerUseCase.SubscribersLimit(ctx, timerId, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get subscribers limit", "SubscribersLimit", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, limit)
+	}
+}
+
+// SetSubscribersLimit godoc
+//
+//	@Summary		SetSubscribersLimit
+//	@Description	set max subscribers of timer, 0 is unlimited, current subscribers over limit aren't removed
+//	@Tags			timers
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			id			path	string						true	"timer id"
+//	@Param			limit		body	timermodel.SubscribersLimit	true	"limit, count is ignored"
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Produce		json
+//	@Accept			json
+//	@Success		200	{object}	timermodel.SubscribersLimit
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/subscribers/limit [put]
+func (h *Handler) SetSubscribersLimit() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "SetSubscribersLimit", _PROVIDER))
+		}
+		limit := new(timermodel.SubscribersLimit)
+		err = c.Bind(limit)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "SetSubscribersLimit", _PROVIDER))
+		}
+		err = limit.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate limit", "SetSubscribersLimit", _PROVIDER))
+		}
+		limit, err = h.timerUseCase.SetSubscribersLimit(ctx, timerId, userId, limit.MaxSubscribers)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set subscribers limit", "SetSubscribersLimit", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, limit)
+	}
+}
@@ -1,9 +1,10 @@
 package timerhandler
 
 import (
-	"context"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
 	"github.com/Tap-Team/timerapi/pkg/exception"
@@ -12,32 +13,38 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const mimeMergePatch = "application/merge-patch+json"
+
 /*
-	group.GET("/user", handler.TimersByUser(ctx))
-	group.GET("/:id/subscribers", handler.TimerSubscribers(ctx))
+	group.GET("/user", handler.TimersByUser())
+	group.GET("/:id/subscribers", handler.TimerSubscribers())
 
-	group.POST("/create", handler.CreateTimer(ctx))
-	group.DELETE("/:id", handler.DeleteTimer(ctx))
-	group.PUT("/:id", handler.UpdateTimer(ctx))
+	group.POST("/create", handler.CreateTimer())
+	group.DELETE("/:id", handler.DeleteTimer())
+	group.PUT("/:id", handler.UpdateTimer())
 */
 
 // TimersByUser godoc
 //
 //	@Summary		TimersByUser
 //	@Description	get all user timers with offset and limit, timers include created by user and user subscriptions
+//	@Description	pinned timers go first, then timers in manual order, then other timers by creation time
 //	@Tags			timers
 //	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
 //	@Param			vk_user_id	query	int64	true	"user id"
 //	@Param			offset		query	int64	true	"offset"
 //	@Param			limit		query	int64	true	"limit"
+//	@Param			folderId	query	string	false	"only timers in user folder"
+//	@Param			tag			query	string	false	"only timers with user tag"
 //	@Produce		json
 //	@Success		200	{array}		timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/user [get]
-func (h *Handler) TimersByUser(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) TimersByUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		// parse offset and limit query
 		offset, limit, err := offsetLimit(c)
 		if err != nil {
@@ -48,9 +55,18 @@ func (h *Handler) TimersByUser(ctx context.Context) echo.HandlerFunc {
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse userId", "TimersByUser", _PROVIDER))
 		}
+		filter, err := timerFilter(c, timermodel.AllTimers)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse filter", "TimersByUser", _PROVIDER))
+		}
 
 		// get timers from use case
-		timers, err := h.timerUseCase.UserTimers(ctx, userId, offset, limit)
+		var timers []*timermodel.Timer
+		if filter.Empty() {
+			timers, err = h.timerUseCase.UserTimers(ctx, userId, offset, limit)
+		} else {
+			timers, err = h.timerUseCase.FilteredTimers(ctx, userId, filter, offset, limit)
+		}
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("get user timers error", "TimersByUser", _PROVIDER))
 		}
@@ -67,14 +83,17 @@ func (h *Handler) TimersByUser(ctx context.Context) echo.HandlerFunc {
 //	@Param			vk_user_id	query	int64	true	"user id"
 //	@Param			offset		query	int64	true	"offset"
 //	@Param			limit		query	int64	true	"limit"
+//	@Param			folderId	query	string	false	"only timers in user folder"
+//	@Param			tag			query	string	false	"only timers with user tag"
 //	@Produce		json
 //	@Success		200	{array}		timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/user-subscriptions [get]
-func (h *Handler) UserSubscriptions(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) UserSubscriptions() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		// parse offset and limit query
 		offset, limit, err := offsetLimit(c)
 		if err != nil {
@@ -85,9 +104,18 @@ func (h *Handler) UserSubscriptions(ctx context.Context) echo.HandlerFunc {
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse userId", "UserSubscriptions", _PROVIDER))
 		}
+		filter, err := timerFilter(c, timermodel.Subscriptions)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse filter", "UserSubscriptions", _PROVIDER))
+		}
 
 		// get timers from use case
-		timers, err := h.timerUseCase.UserSubscriptions(ctx, userId, offset, limit)
+		var timers []*timermodel.Timer
+		if filter.Empty() {
+			timers, err = h.timerUseCase.UserSubscriptions(ctx, userId, offset, limit)
+		} else {
+			timers, err = h.timerUseCase.FilteredTimers(ctx, userId, filter, offset, limit)
+		}
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("get user timers error", "UserSubscriptions", _PROVIDER))
 		}
@@ -104,14 +132,17 @@ func (h *Handler) UserSubscriptions(ctx context.Context) echo.HandlerFunc {
 //	@Param			vk_user_id	query	int64	true	"user id"
 //	@Param			offset		query	int64	true	"offset"
 //	@Param			limit		query	int64	true	"limit"
+//	@Param			folderId	query	string	false	"only timers in user folder"
+//	@Param			tag			query	string	false	"only timers with user tag"
 //	@Produce		json
 //	@Success		200	{array}		timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/user-created [get]
-func (h *Handler) UserCreated(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) UserCreated() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		// parse offset and limit query
 		offset, limit, err := offsetLimit(c)
 		if err != nil {
@@ -122,9 +153,18 @@ func (h *Handler) UserCreated(ctx context.Context) echo.HandlerFunc {
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse userId", "UserCreated", _PROVIDER))
 		}
+		filter, err := timerFilter(c, timermodel.CreatedTimers)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse filter", "UserCreated", _PROVIDER))
+		}
 
 		// get timers from use case
-		timers, err := h.timerUseCase.UserCreatedTimers(ctx, userId, offset, limit)
+		var timers []*timermodel.Timer
+		if filter.Empty() {
+			timers, err = h.timerUseCase.UserCreatedTimers(ctx, userId, offset, limit)
+		} else {
+			timers, err = h.timerUseCase.FilteredTimers(ctx, userId, filter, offset, limit)
+		}
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("get user timers error", "UserCreated", _PROVIDER))
 		}
@@ -146,8 +186,9 @@ func (h *Handler) UserCreated(ctx context.Context) echo.HandlerFunc {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id}/subscribers [get]
-func (h *Handler) TimerSubscribers(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) TimerSubscribers() echo.HandlerFunc {
 	f := func(c echo.Context) error {
+		ctx := c.Request().Context()
 		timerId, err := uuid.Parse(c.Param("id"))
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse timerid", "TimerSubscribers", _PROVIDER))
@@ -165,6 +206,8 @@ func (h *Handler) TimerSubscribers(ctx context.Context) echo.HandlerFunc {
 //
 //	@Summary		CreateTimer
 //	@Description	create user timer
+//	@Description	color is name from /colors palette, customColor is hex color with optional gradient, color is DEFAULT if only customColor is set
+//	@Description	soundId is id from /sounds catalog, withMusic without soundId sets default sound, empty volume is max volume
 //	@Tags			timers
 //	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
 //	@Param			vk_user_id	query	int64					true	"user id"
@@ -176,8 +219,9 @@ func (h *Handler) TimerSubscribers(ctx context.Context) echo.HandlerFunc {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/create [post]
-func (h *Handler) CreateTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) CreateTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		// parse vk_user_id
 		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
 		if err != nil {
@@ -203,6 +247,42 @@ func (h *Handler) CreateTimer(ctx context.Context) echo.HandlerFunc {
 	}
 }
 
+// CloneTimer godoc
+//
+//	@Summary		CloneTimer
+//	@Description	create own copy of timer with new id, copy has same name, duration, color, music and sound, countdown of copy can be reset or end with original timer
+//	@Tags			timers
+//	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64					true	"user id"
+//	@Param			id			path	string					true	"timer id"
+//	@Param			clone		body	timermodel.CloneTimer	false	"copy options"
+//	@Produce		json
+//	@Accept			json
+//	@Success		201	{object}	timermodel.Timer
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id}/clone [post]
+func (h *Handler) CloneTimer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "CloneTimer", _PROVIDER))
+		}
+		clone := new(timermodel.CloneTimer)
+		err = c.Bind(clone)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "CloneTimer", _PROVIDER))
+		}
+		timer, err := h.timerUseCase.Clone(ctx, timerId, userId, clone)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("clone timer", "CloneTimer", _PROVIDER))
+		}
+		return c.JSON(http.StatusCreated, timer)
+	}
+}
+
 // DeleteTimer godoc
 //
 //	@Summary		DeleteTimer
@@ -217,8 +297,9 @@ func (h *Handler) CreateTimer(ctx context.Context) echo.HandlerFunc {
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id} [delete]
-func (h *Handler) DeleteTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) DeleteTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse user,timer id", "DeleteTimer", _PROVIDER))
@@ -235,21 +316,27 @@ func (h *Handler) DeleteTimer(ctx context.Context) echo.HandlerFunc {
 // UpdateTimer godoc
 //
 //	@Summary		UpdateTimer
-//	@Description	update user timer
+//	@Description	update user timer, new timer version is returned in ETag
+//	@Description	customColor isn't changed if it is absent, it is cleared by merge patch with null customColor
+//	@Description	soundId and volume aren't changed if they are absent, withMusic false makes timer silent
 //	@Tags			timers
 //	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
 //	@Param			vk_user_id	query	int64						true	"user id"
 //	@Param			id			path	string						true	"timer id"
 //	@Param			settings	body	timermodel.TimerSettings	true	"timer update settings"
+//	@Param			If-Match	header	string						true	"timer version from ETag, * matches any version"
 //	@Accept			json
 //	@Produce		json
 //	@Success		204
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		428	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id} [put]
-func (h *Handler) UpdateTimer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) UpdateTimer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		// parse user timer id
 		userId, timerId, err := userIdTimerId(c)
 		if err != nil {
@@ -262,10 +349,72 @@ func (h *Handler) UpdateTimer(ctx context.Context) echo.HandlerFunc {
 			return exception.Wrap(err, exception.NewCause("parse body", "UpdateTimer", _PROVIDER))
 		}
 		// update timer by id uuid
-		err = h.timerUseCase.Update(ctx, timerId, userId, settings)
+		err = h.timerUseCase.Update(ctx, timerId, userId, settings, expectedVersion(c))
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("update timer", "UpdateTimer", _PROVIDER))
 		}
+		_, err = h.setETag(ctx, c, timerId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set etag", "UpdateTimer", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// PatchTimer godoc
+//
+//	@Summary		PatchTimer
+//	@Description	partial update of user timer with RFC 7386 merge patch, only fields of patch are validated and updated
+//	@Description	null name, description or customColor clears it, null soundId makes timer silent, null of other fields is not allowed
+//	@Description	update event contains only changed fields, new timer version is returned in ETag
+//	@Tags			timers
+//	@Param			debug		query	string					false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64					true	"user id"
+//	@Param			id			path	string					true	"timer id"
+//	@Param			patch		body	timermodel.TimerPatch	true	"timer merge patch"
+//	@Param			If-Match	header	string					false	"timer version from ETag, version isn't checked without header"
+//	@Accept			application/merge-patch+json
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		412	{object}	VersionConflictResponse
+//	@Failure		415	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/timers/{id} [patch]
+func (h *Handler) PatchTimer() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "PatchTimer", _PROVIDER))
+		}
+		contentType := c.Request().Header.Get(echo.HeaderContentType)
+		if !strings.HasPrefix(contentType, mimeMergePatch) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+			return echo.ErrUnsupportedMediaType
+		}
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("read body", "PatchTimer", _PROVIDER))
+		}
+		patch, err := timermodel.ParseTimerPatch(body)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse patch", "PatchTimer", _PROVIDER))
+		}
+		err = patch.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate patch", "PatchTimer", _PROVIDER))
+		}
+		err = h.timerUseCase.Patch(ctx, timerId, userId, patch, expectedVersion(c))
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("patch timer", "PatchTimer", _PROVIDER))
+		}
+		_, err = h.setETag(ctx, c, timerId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("set etag", "PatchTimer", _PROVIDER))
+		}
 		return c.NoContent(http.StatusNoContent)
 	}
 }
@@ -274,25 +423,35 @@ func (h *Handler) UpdateTimer(ctx context.Context) echo.HandlerFunc {
 //
 //	@Summary		TimerById
 //	@Description	"returns timer by param id"
+//	@Description	with vk_user_id relation and subscribedAt of user are returned, timer version is returned in ETag
 //	@Tags			timers
-//	@Param			debug	query	string	false	"you can add secret key to query for debug requests"
-//	@Param			id		path	string	true	"timer id"
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			id			path	string	true	"timer id"
+//	@Param			vk_user_id	query	int64	false	"user id"
 //	@Produce		json
 //	@Success		200	{object}	timermodel.Timer
 //	@Failure		400	{object}	echoconfig.ErrorResponse
 //	@Failure		404	{object}	echoconfig.ErrorResponse
 //	@Failure		500	{object}	echoconfig.ErrorResponse
 //	@Router			/timers/{id} [get]
-func (h *Handler) Timer(ctx context.Context) echo.HandlerFunc {
+func (h *Handler) Timer() echo.HandlerFunc {
 	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 		id, err := uuid.Parse(c.Param("id"))
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("parse timer id", "Timer", _PROVIDER))
 		}
-		timer, err := h.timerUseCase.Timer(ctx, id)
+		var timer *timermodel.Timer
+		userId, parseErr := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if parseErr == nil {
+			timer, err = h.timerUseCase.UserTimer(ctx, id, userId)
+		} else {
+			timer, err = h.timerUseCase.Timer(ctx, id)
+		}
 		if err != nil {
 			return exception.Wrap(err, exception.NewCause("get timer by id", "Timer", _PROVIDER))
 		}
+		c.Response().Header().Set(headerETag, etag(timer.Version))
 		return c.JSON(http.StatusOK, timer)
 	}
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
 	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel/timerfields"
 	"github.com/Tap-Team/timerapi/pkg/amidtime"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -26,8 +27,8 @@ func createTimer(ctx context.Context, userId int64, timer *timermodel.CreateTime
 	req := httptest.NewRequest(http.MethodPost, basePath("/create?vk_user_id="+fmt.Sprint(userId)), bytes.NewReader(b))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, handler.CreateTimer(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, handler.CreateTimer()(c)
 }
 
 func updateTimer(ctx context.Context, userId int64, timerId uuid.UUID, settings *timermodel.TimerSettings) (*httptest.ResponseRecorder, error) {
@@ -35,28 +36,28 @@ func updateTimer(ctx context.Context, userId int64, timerId uuid.UUID, settings
 	req := httptest.NewRequest(http.MethodPut, basePath("/:id"+"?vk_user_id="+fmt.Sprint(userId)), bytes.NewReader(b))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.UpdateTimer(ctx)(c)
+	return rec, handler.UpdateTimer()(c)
 }
 
 func deleteTimer(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodDelete, basePath("/:id"+"?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.DeleteTimer(ctx)(c)
+	return rec, handler.DeleteTimer()(c)
 }
 
 func getTimer(ctx context.Context, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodGet, basePath("/:id"), &bytes.Buffer{})
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.Timer(ctx)(c)
+	return rec, handler.Timer()(c)
 }
 
 func TestCRUD(t *testing.T) {
@@ -151,6 +152,62 @@ func deleteTimerTest(t *testing.T, ctx context.Context, timerId uuid.UUID, userI
 	require.ErrorIs(t, timererror.ExceptionTimerNotFound(), err, "delete no exists timer wrong error")
 }
 
+func patchTimer(ctx context.Context, userId int64, timerId uuid.UUID, patch string) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(http.MethodPatch, basePath("/:id"+"?vk_user_id="+fmt.Sprint(userId)), bytes.NewBufferString(patch))
+	req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req.WithContext(ctx), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(fmt.Sprint(timerId))
+	return rec, handler.PatchTimer()(c)
+}
+
+func TestPatchTimer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	userId := rand.Int63()
+	timer := randomTimer(func(t *timermodel.Timer) {
+		t.Creator = userId
+		t.Name = "name"
+		t.Description = "description"
+	})
+	_, err := createTimer(ctx, userId, timer.CreateTimer())
+	require.NoError(t, err, "create timer failed")
+
+	// only color is changed
+	rec, err := patchTimer(ctx, userId, timer.ID, `{"color":"RED"}`)
+	require.NoError(t, err, "patch color failed")
+	require.Equal(t, http.StatusNoContent, rec.Result().StatusCode, "wrong status code of patch")
+	tm, err := timerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer from storage failed")
+	require.Equal(t, timerfields.RED, tm.Color, "color not patched")
+	require.Equal(t, timer.Name, tm.Name, "name changed")
+	require.Equal(t, timer.Description, tm.Description, "description changed")
+	require.Equal(t, timer.EndTime.Unix(), tm.EndTime.Unix(), "end time changed")
+
+	// null clears description, end time change updates duration
+	endTime := tm.EndTime.Unix() - tm.Duration/2
+	rec, err = patchTimer(ctx, userId, timer.ID, fmt.Sprintf(`{"description":null,"endTime":%d}`, endTime))
+	require.NoError(t, err, "patch description and end time failed")
+	require.Equal(t, http.StatusNoContent, rec.Result().StatusCode, "wrong status code of patch")
+	patched, err := timerStorage.Timer(ctx, timer.ID)
+	require.NoError(t, err, "get timer from storage failed")
+	require.Empty(t, patched.Description, "description not cleared")
+	require.Equal(t, timer.Name, patched.Name, "name changed")
+	require.Equal(t, endTime, patched.EndTime.Unix(), "end time not patched")
+	require.Equal(t, tm.Duration+endTime-tm.EndTime.Unix(), patched.Duration, "duration not updated")
+
+	// wrong patches
+	for _, patch := range []string{`{"color":"WRONG"}`, `{"withMusic":null}`, `{"unknown":1}`, `[]`} {
+		_, err = patchTimer(ctx, userId, timer.ID, patch)
+		require.Error(t, err, "wrong patch %s accepted", patch)
+	}
+
+	// only creator can patch timer
+	_, err = patchTimer(ctx, rand.Int63(), timer.ID, `{"color":"BLUE"}`)
+	require.ErrorIs(t, err, timererror.ExceptionUserForbidden(), "patch by not creator wrong error")
+}
+
 func TestGetTimer(t *testing.T) {
 	const listSize = 100
 	ctx, cancel := context.WithCancel(context.Background())
@@ -172,3 +229,47 @@ func TestGetTimer(t *testing.T) {
 	}
 	clearTimers(t, ctx, timerList...)
 }
+
+func cloneTimer(ctx context.Context, userId int64, timerId uuid.UUID, clone *timermodel.CloneTimer) (*httptest.ResponseRecorder, error) {
+	b, _ := json.Marshal(clone)
+	req := httptest.NewRequest(http.MethodPost, basePath("/:id/clone"+"?vk_user_id="+fmt.Sprint(userId)), bytes.NewReader(b))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req.WithContext(ctx), rec)
+	c.SetParamNames("id")
+	c.SetParamValues(fmt.Sprint(timerId))
+	return rec, handler.CloneTimer()(c)
+}
+
+func TestCloneTimer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	original := randomTimer()
+	createTimersTest(t, ctx, []*timermodel.Timer{original})
+	defer clearTimers(t, ctx, original)
+
+	userId := rand.Int63()
+	for _, clone := range []*timermodel.CloneTimer{{}, {Reset: true, KeepSettings: true}} {
+		rec, err := cloneTimer(ctx, userId, original.ID, clone)
+		require.NoError(t, err, "clone timer failed")
+		require.Equal(t, http.StatusCreated, rec.Result().StatusCode, "wrong status code of clone timer")
+		timer := timerFromBody(t, rec)
+		defer clearTimers(t, ctx, timer)
+
+		require.NotEqual(t, original.ID, timer.ID, "clone has same id")
+		require.Equal(t, userId, timer.Creator, "wrong clone creator")
+		require.Equal(t, original.Name, timer.Name, "wrong clone name")
+		require.Equal(t, original.Duration, timer.Duration, "wrong clone duration")
+		require.Equal(t, original.Color, timer.Color, "wrong clone color")
+		require.Equal(t, original.WithMusic, timer.WithMusic, "wrong clone music")
+		if clone.KeepSettings {
+			require.Equal(t, original.Description, timer.Description, "settings not copied")
+		} else {
+			require.Empty(t, timer.Description, "settings copied without keepSettings")
+		}
+
+		subs, err := subscriberStorage.TimerSubscribers(ctx, timer.ID)
+		require.NoError(t, err, "error while get clone subscribers")
+		require.True(t, len(subs) == 1 && subs[userId] == struct{}{}, "wrong clone subscribers")
+	}
+}
@@ -0,0 +1,65 @@
+package timerhandler_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/echoconfig"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/tracing"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/timerhandler"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStorageSpanParent(t *testing.T) {
+	ctx := context.Background()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	router := echo.New()
+	router.HTTPErrorHandler = echoconfig.ErrorHandler
+	router.Use(otelecho.Middleware(tracing.ServiceName))
+	timerhandler.Init(router.Group(""), timerUseCase, countdownTimerUseCase, nil)
+
+	userId := rand.Int63()
+	timer := randomTimer(func(t *timermodel.Timer) { t.Creator = userId })
+	_, err := createTimer(ctx, userId, timer.CreateTimer())
+	require.NoError(t, err, "create timer failed")
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/timers/%s?vk_user_id=%d", timer.ID, userId), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, "get timer failed")
+
+	var server, usecase, storage sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch {
+		case span.SpanKind() == trace.SpanKindServer:
+			server = span
+		case span.Name() == "timerusecase.UserTimer":
+			usecase = span
+		case span.Name() == "timerstorage.UserTimer":
+			storage = span
+		}
+	}
+	require.NotNil(t, server, "http span not recorded")
+	require.NotNil(t, usecase, "usecase span not recorded")
+	require.NotNil(t, storage, "storage span not recorded")
+
+	require.Equal(t, server.SpanContext().TraceID(), storage.SpanContext().TraceID(), "storage span not in request trace")
+	require.Equal(t, usecase.SpanContext().SpanID(), storage.Parent().SpanID(), "wrong storage span parent")
+	require.Equal(t, server.SpanContext().SpanID(), usecase.Parent().SpanID(), "usecase span parent isn't http span")
+}
@@ -0,0 +1,119 @@
+package timerhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Tap-Team/timerapi/internal/errorutils/timererror"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerIfMatch = "If-Match"
+	headerETag    = "ETag"
+
+	// echo context key of version from If-Match
+	versionKey = "timer_version"
+)
+
+// response of request with stale If-Match, timer is current state for merge
+type VersionConflictResponse struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Timer   *timermodel.Timer `json:"timer"`
+}
+
+func etag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// parse version from If-Match header, "*" matches any version and is parsed as 0 version
+func ifMatch(c echo.Context) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if header == "" {
+		return 0, timererror.ExceptionVersionRequired()
+	}
+	if header == "*" {
+		return 0, nil
+	}
+	header = strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	version, err := strconv.ParseInt(header, 10, 64)
+	if err != nil {
+		return 0, errors.Join(err, timererror.ExceptionWrongVersion())
+	}
+	// versions start from 1
+	if version < 1 {
+		return 0, timererror.ExceptionWrongVersion()
+	}
+	return version, nil
+}
+
+// expected version of mutation, 0 if request isn't versioned
+func expectedVersion(c echo.Context) int64 {
+	version, _ := c.Get(versionKey).(int64)
+	return version
+}
+
+// set ETag header with current timer version
+func (h *Handler) setETag(ctx context.Context, c echo.Context, timerId uuid.UUID) (int64, error) {
+	version, err := h.timerUseCase.TimerVersion(ctx, timerId)
+	if err != nil {
+		return 0, exception.Wrap(err, exception.NewCause("get timer version", "setETag", _PROVIDER))
+	}
+	c.Response().Header().Set(headerETag, etag(version))
+	return version, nil
+}
+
+// require If-Match with timer version, version is passed to mutation and checked by the same storage update
+// stale version gets 412 with current timer
+func (h *Handler) versioned(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		_, timerId, err := userIdTimerId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,timer id", "versioned", _PROVIDER))
+		}
+		version, err := ifMatch(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse If-Match", "versioned", _PROVIDER))
+		}
+		c.Set(versionKey, version)
+		err = next(c)
+		if errors.Is(err, timererror.ExceptionVersionMismatch()) {
+			return h.versionConflict(ctx, c, timerId)
+		}
+		return err
+	}
+}
+
+// check If-Match only if it is set, merge patch changes only its fields, so it is allowed without version
+func (h *Handler) optionalVersioned(next echo.HandlerFunc) echo.HandlerFunc {
+	versioned := h.versioned(next)
+	return func(c echo.Context) error {
+		if c.Request().Header.Get(headerIfMatch) == "" {
+			return next(c)
+		}
+		return versioned(c)
+	}
+}
+
+func (h *Handler) versionConflict(ctx context.Context, c echo.Context, timerId uuid.UUID) error {
+	timer, err := h.timerUseCase.Timer(ctx, timerId)
+	if err != nil {
+		return exception.Wrap(err, exception.NewCause("get current timer", "versionConflict", _PROVIDER))
+	}
+	mismatch := timererror.ExceptionVersionMismatch()
+	c.Response().Header().Set(headerETag, etag(timer.Version))
+	return c.JSON(http.StatusPreconditionFailed, VersionConflictResponse{
+		Code:    exception.MakeCode(mismatch),
+		Message: mismatch.Error(),
+		Timer:   timer,
+	})
+}
@@ -0,0 +1,65 @@
+package timerhandler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/internal/echoconfig"
+	"github.com/Tap-Team/timerapi/internal/model/timermodel"
+	"github.com/Tap-Team/timerapi/internal/transport/rest/timerhandler"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerVersion(t *testing.T) {
+	ctx := context.Background()
+	router := echo.New()
+	router.HTTPErrorHandler = echoconfig.ErrorHandler
+	timerhandler.Init(router.Group(""), timerUseCase, countdownTimerUseCase, nil)
+
+	userId := rand.Int63()
+	timer := randomTimer(func(t *timermodel.Timer) { t.Creator = userId })
+	_, err := createTimer(ctx, userId, timer.CreateTimer())
+	require.NoError(t, err, "create timer failed")
+
+	update := func(timerId uuid.UUID, ifMatch string) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(randomTimerSettings())
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/timers/%s?vk_user_id=%d", timerId, userId), bytes.NewReader(b))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec, err := getTimer(ctx, timer.ID)
+	require.NoError(t, err, "get timer failed")
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag, "etag not set")
+
+	rec = update(timer.ID, "")
+	require.Equal(t, http.StatusPreconditionRequired, rec.Code, "update without If-Match")
+
+	rec = update(timer.ID, etag)
+	require.Equal(t, http.StatusNoContent, rec.Code, "update with current version failed")
+	newEtag := rec.Header().Get("ETag")
+	require.NotEqual(t, etag, newEtag, "version not changed")
+
+	// stale version gets current timer
+	rec = update(timer.ID, etag)
+	require.Equal(t, http.StatusPreconditionFailed, rec.Code, "update with stale version")
+	conflict := new(timerhandler.VersionConflictResponse)
+	err = json.NewDecoder(rec.Body).Decode(conflict)
+	require.NoError(t, err, "decode conflict failed")
+	require.Equal(t, timer.ID, conflict.Timer.ID, "wrong conflict timer")
+	require.Equal(t, newEtag, fmt.Sprintf(`"%d"`, conflict.Timer.Version), "wrong conflict timer version")
+}
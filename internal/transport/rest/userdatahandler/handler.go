@@ -0,0 +1,122 @@
+package userdatahandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/userdatamodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/userdatahandler"
+
+type UserDataUseCase interface {
+	Export(ctx context.Context, userId int64) (*userdatamodel.Archive, error)
+	Erase(ctx context.Context, userId int64) (*userdatamodel.Erasure, error)
+	Erasure(ctx context.Context, userId int64) (*userdatamodel.Erasure, error)
+}
+
+type Handler struct {
+	useCase UserDataUseCase
+}
+
+func New(useCase UserDataUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func Init(e *echo.Group, useCase UserDataUseCase) {
+	handler := &Handler{useCase: useCase}
+
+	group := e.Group("/user-data")
+	group.GET("", handler.Export())
+	group.DELETE("", handler.Erase())
+	group.GET("/erasure", handler.Erasure())
+}
+
+// Export godoc
+//
+//	@Summary		Export
+//	@Description	json archive of all user data: created timers, subscriptions, unread notifications, webhooks and calendar feed
+//	@Tags			user-data
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{object}	userdatamodel.Archive
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/user-data [get]
+func (h *Handler) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Export", _PROVIDER))
+		}
+		archive, err := h.useCase.Export(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("export user data", "Export", _PROVIDER))
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="timerapi-%d.json"`, userId))
+		return c.JSON(http.StatusOK, archive)
+	}
+}
+
+// Erase godoc
+//
+//	@Summary		Erase
+//	@Description	erase all user data, created timers are deleted and subscribers get delete notification, user is unsubscribed from all timers
+//	@Description	request can be repeated, failed erasure is continued from unfinished step
+//	@Tags			user-data
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{object}	userdatamodel.Erasure
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/user-data [delete]
+func (h *Handler) Erase() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Erase", _PROVIDER))
+		}
+		erasure, err := h.useCase.Erase(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("erase user data", "Erase", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, erasure)
+	}
+}
+
+// Erasure godoc
+//
+//	@Summary		Erasure
+//	@Description	state of last user data erasure
+//	@Tags			user-data
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{object}	userdatamodel.Erasure
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/user-data/erasure [get]
+func (h *Handler) Erasure() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Erasure", _PROVIDER))
+		}
+		erasure, err := h.useCase.Erasure(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get erasure", "Erasure", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, erasure)
+	}
+}
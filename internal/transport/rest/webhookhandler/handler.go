@@ -0,0 +1,218 @@
+package webhookhandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Tap-Team/timerapi/internal/model/webhookmodel"
+	"github.com/Tap-Team/timerapi/pkg/exception"
+	"github.com/Tap-Team/timerapi/pkg/vk"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const _PROVIDER = "internal/transport/rest/webhookhandler"
+
+type WebhookUseCase interface {
+	Create(ctx context.Context, userId int64, create *webhookmodel.CreateWebhook) (*webhookmodel.Webhook, error)
+	Webhooks(ctx context.Context, userId int64) ([]*webhookmodel.Webhook, error)
+	Delete(ctx context.Context, webhookId uuid.UUID, userId int64) error
+	Enable(ctx context.Context, webhookId uuid.UUID, userId int64) error
+	Deliveries(ctx context.Context, webhookId uuid.UUID, userId int64, offset, limit int) ([]*webhookmodel.Delivery, error)
+}
+
+type Handler struct {
+	useCase WebhookUseCase
+}
+
+func New(useCase WebhookUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+func Init(e *echo.Group, useCase WebhookUseCase) {
+	handler := &Handler{useCase: useCase}
+	group := e.Group("/webhooks")
+
+	group.POST("", handler.CreateWebhook())
+	group.GET("", handler.Webhooks())
+	group.DELETE("/:id", handler.DeleteWebhook())
+	group.PATCH("/:id/enable", handler.EnableWebhook())
+	group.GET("/:id/deliveries", handler.Deliveries())
+}
+
+func userIdWebhookId(c echo.Context) (int64, uuid.UUID, error) {
+	userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+	if err != nil {
+		return 0, uuid.Nil, errors.Join(err, errors.New("user id parse error"))
+	}
+	webhookId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return 0, uuid.Nil, errors.Join(err, errors.New("webhook id parse error"))
+	}
+	return userId, webhookId, nil
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		CreateWebhook
+//	@Description	register https endpoint on public address which receive timer events of user timers and subscriptions, secret is returned only in this response, every request is signed with X-Timer-Signature header, sha256=hex(hmac_sha256(secret, X-Timer-Timestamp + "." + body))
+//	@Tags			webhooks
+//	@Param			debug		query	string						false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64						true	"user id"
+//	@Param			webhook		body	webhookmodel.CreateWebhook	true	"webhook, events: expire, delete, update, stop, start, reset, adjust"
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	webhookmodel.Webhook
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/webhooks [post]
+func (h *Handler) CreateWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "CreateWebhook", _PROVIDER))
+		}
+		create := new(webhookmodel.CreateWebhook)
+		err = c.Bind(create)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("bind body", "CreateWebhook", _PROVIDER))
+		}
+		err = create.Validate()
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("validate body", "CreateWebhook", _PROVIDER))
+		}
+		webhook, err := h.useCase.Create(ctx, userId, create)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("create webhook", "CreateWebhook", _PROVIDER))
+		}
+		return c.JSON(http.StatusCreated, webhook)
+	}
+}
+
+// Webhooks godoc
+//
+//	@Summary		Webhooks
+//	@Description	get user webhooks, secrets are hidden
+//	@Tags			webhooks
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Produce		json
+//	@Success		200	{array}		webhookmodel.Webhook
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/webhooks [get]
+func (h *Handler) Webhooks() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, err := strconv.ParseInt(c.QueryParam(vk.USER_ID), 10, 64)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse userId", "Webhooks", _PROVIDER))
+		}
+		webhooks, err := h.useCase.Webhooks(ctx, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get user webhooks", "Webhooks", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, webhooks)
+	}
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		DeleteWebhook
+//	@Description	delete user webhook with delivery log
+//	@Tags			webhooks
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"webhook id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, webhookId, err := userIdWebhookId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,webhook id", "DeleteWebhook", _PROVIDER))
+		}
+		err = h.useCase.Delete(ctx, webhookId, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("delete webhook", "DeleteWebhook", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// EnableWebhook godoc
+//
+//	@Summary		EnableWebhook
+//	@Description	enable webhook which was disabled after repeated failed deliveries
+//	@Tags			webhooks
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"webhook id"
+//	@Success		204
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/webhooks/{id}/enable [patch]
+func (h *Handler) EnableWebhook() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, webhookId, err := userIdWebhookId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,webhook id", "EnableWebhook", _PROVIDER))
+		}
+		err = h.useCase.Enable(ctx, webhookId, userId)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("enable webhook", "EnableWebhook", _PROVIDER))
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// Deliveries godoc
+//
+//	@Summary		Deliveries
+//	@Description	delivery log of webhook, every attempt is separate record, newest first
+//	@Tags			webhooks
+//	@Param			debug		query	string	false	"you can add secret key to query for debug requests"
+//	@Param			vk_user_id	query	int64	true	"user id"
+//	@Param			id			path	string	true	"webhook id"
+//	@Param			offset		query	int64	true	"offset"
+//	@Param			limit		query	int64	true	"limit"
+//	@Produce		json
+//	@Success		200	{array}		webhookmodel.Delivery
+//	@Failure		400	{object}	echoconfig.ErrorResponse
+//	@Failure		403	{object}	echoconfig.ErrorResponse
+//	@Failure		404	{object}	echoconfig.ErrorResponse
+//	@Failure		500	{object}	echoconfig.ErrorResponse
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *Handler) Deliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		userId, webhookId, err := userIdWebhookId(c)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse user,webhook id", "Deliveries", _PROVIDER))
+		}
+		offset, err := strconv.Atoi(c.QueryParam("offset"))
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse offset", "Deliveries", _PROVIDER))
+		}
+		limit, err := strconv.Atoi(c.QueryParam("limit"))
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("parse limit", "Deliveries", _PROVIDER))
+		}
+		deliveries, err := h.useCase.Deliveries(ctx, webhookId, userId, offset, limit)
+		if err != nil {
+			return exception.Wrap(err, exception.NewCause("get webhook deliveries", "Deliveries", _PROVIDER))
+		}
+		return c.JSON(http.StatusOK, deliveries)
+	}
+}
@@ -24,10 +24,10 @@ import (
 func subscribe(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodPost, basePath("/:id/subscribe?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.Subscribe(ctx)(c)
+	return rec, handler.Subscribe()(c)
 }
 
 func TestNotificationStream(t *testing.T) {
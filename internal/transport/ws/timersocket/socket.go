@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/Tap-Team/timerapi/internal/logging"
+	"github.com/Tap-Team/timerapi/internal/metrics"
 	"github.com/Tap-Team/timerapi/internal/model/notification"
 	"github.com/Tap-Team/timerapi/internal/model/timerevent"
 	"github.com/Tap-Team/timerapi/pkg/exception"
@@ -24,6 +27,9 @@ var (
 
 const _PROVIDER = "internal/transport/ws/timersocket"
 
+// text of close frame sent on shutdown, client should reconnect to another instance
+const reconnectHint = "reconnect"
+
 type Streamer interface {
 	NewStream() interface {
 		Subscribe(...uuid.UUID)
@@ -43,6 +49,12 @@ type NotificationStreamer interface {
 type TimerSocket struct {
 	streamer             Streamer
 	notificationStreamer NotificationStreamer
+
+	mu       sync.Mutex
+	closed   bool
+	shutdown chan struct{}
+	// open connections
+	conns sync.WaitGroup
 }
 
 func New(
@@ -52,6 +64,7 @@ func New(
 	return &TimerSocket{
 		streamer:             streamer,
 		notificationStreamer: expTimerStream,
+		shutdown:             make(chan struct{}),
 	}
 }
 
@@ -59,30 +72,65 @@ func Init(
 	e *echo.Group,
 	streamer Streamer,
 	notificationStreamer NotificationStreamer,
-) {
-	socket := &TimerSocket{
-		streamer:             streamer,
-		notificationStreamer: notificationStreamer,
-	}
+) *TimerSocket {
+	socket := New(streamer, notificationStreamer)
 
 	e.GET("/ws/timer", socket.TimerWS)
 
+	return socket
+}
+
+// send close frame with reconnect hint to every connection and wait until connections are closed
+// new connections are rejected after shutdown
+func (s *TimerSocket) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.shutdown)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.conns.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// register connection, returns false if socket is shut down
+func (s *TimerSocket) open() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns.Add(1)
+	return true
 }
 
 // WSReadStream godoc
 //
 //	@Summary	Websocket
-//	@Description
+//	@Description	on server shutdown connection is closed with code 1012 (service restart) and text "reconnect", client should reconnect
 //	@Tags		ws
 //	@Param		vk_user_id	query	int64	true	"user id"
 //	@Param		debug		query	string	false	"you can add secret key to query for debug requests"
 //	@Produce	json
-//	@Param		event	body		timerevent.SubscribeEvent		true	"event to add\remove timers from event stream"
+//	@Param		event	body		timerevent.ClientEvent			true	"event to add\remove timers from event stream or event_clock_sync with clientSendTime"
 //	@Success	200		{object}	notification.NotificationDTO	"notification"
 //	@Success	201		{object}	timerevent.ResetEvent			"reset event"
 //	@Success	202		{object}	timerevent.StopEvent			"stop event"
 //	@Success	203		{object}	timerevent.StartEvent			"start event"
 //	@Success	204		{object}	timerevent.UpdateEvent			"update event"
+//	@Success	205		{object}	timerevent.PatchEvent			"update event of merge patch, only changed fields"
+//	@Success	206		{object}	timerevent.ClockSyncEvent		"response to event_clock_sync"
+//	@Success	207		{object}	timerevent.AdjustEvent			"adjust event"
 //	@Router		/ws/timer [get]
 func (s *TimerSocket) TimerWS(c echo.Context) error {
 	// parse user id from query
@@ -90,6 +138,12 @@ func (s *TimerSocket) TimerWS(c echo.Context) error {
 	if err != nil {
 		return exception.Wrap(err, exception.NewCause("parse user id from request", "TimerWS", _PROVIDER))
 	}
+	if !s.open() {
+		return echo.ErrServiceUnavailable
+	}
+	defer s.conns.Done()
+	metrics.WebsocketOpened()
+	defer metrics.WebsocketClosed()
 	// create websocket connection
 	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
@@ -131,6 +185,16 @@ Loop:
 		// ctx done listener
 		case <-ctx.Done():
 			break Loop
+		// server shutdown, ask client to reconnect
+		case <-s.shutdown:
+			mu.Lock()
+			ws.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseServiceRestart, reconnectHint),
+				time.Now().Add(time.Second),
+			)
+			mu.Unlock()
+			break Loop
 		// handle notification stream
 		case n, ok := <-notificationStream.Stream():
 			// log.Printf("WEBSOCKET %s, client notification %s", ws.LocalAddr(), n.Type())
@@ -153,6 +217,11 @@ Loop:
 				timerEventStream.Subscribe(event.TimerIds...)
 			case timerevent.Unsubscribe:
 				timerEventStream.Unsubscribe(event.TimerIds...)
+			case timerevent.ClockSync:
+				clockSync := timerevent.NewClockSync(event.ClientSendTime, event.ReceiveTime)
+				mu.Lock()
+				ws.WriteJSON(clockSync.Sent())
+				mu.Unlock()
 			}
 		// listen timers events from timers
 		case event, ok := <-timerEventStream.Stream():
@@ -167,13 +236,13 @@ Loop:
 			mu.Unlock()
 		}
 	}
-	c.Logger().Infof("WEBSOCKET CLOSED, %s", ws.RemoteAddr())
+	logging.FromContext(c.Request().Context()).Info("websocket closed", "user_id", userId, "remote_addr", ws.RemoteAddr().String())
 	return nil
 }
 
 // stream which read all events from websocket connection and write it to created chan
-func WSReadStream(ctx context.Context, ws *websocket.Conn) <-chan *timerevent.SubscribeEvent {
-	ech := make(chan *timerevent.SubscribeEvent)
+func WSReadStream(ctx context.Context, ws *websocket.Conn) <-chan *timerevent.ClientEvent {
+	ech := make(chan *timerevent.ClientEvent)
 	go func() {
 	Loop:
 		for {
@@ -181,8 +250,9 @@ func WSReadStream(ctx context.Context, ws *websocket.Conn) <-chan *timerevent.Su
 			case <-ctx.Done():
 				break Loop
 			default:
-				var event timerevent.SubscribeEvent
+				var event timerevent.ClientEvent
 				err := ws.ReadJSON(&event)
+				event.ReceiveTime = time.Now()
 				if _, ok := err.(*websocket.CloseError); ok {
 					break Loop
 				}
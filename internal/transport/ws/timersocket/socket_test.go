@@ -13,12 +13,15 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Tap-Team/timerapi/internal/database/postgres/colorstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/notificationstorage"
 	"github.com/Tap-Team/timerapi/internal/database/postgres/timerstorage"
 	"github.com/Tap-Team/timerapi/internal/database/redis/subscriberstorage"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timereventstream"
 	"github.com/Tap-Team/timerapi/internal/domain/datastream/timernotificationstream"
+	"github.com/Tap-Team/timerapi/internal/domain/usecase/colorusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/countdowntimerusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/notificationusecase"
 	"github.com/Tap-Team/timerapi/internal/domain/usecase/timerusecase"
@@ -50,16 +53,16 @@ func createTimer(ctx context.Context, userId int64, timer *timermodel.CreateTime
 	req := httptest.NewRequest(http.MethodPost, basePath("/create?vk_user_id="+fmt.Sprint(userId)), bytes.NewReader(b))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-	return rec, handler.CreateTimer(ctx)(c)
+	c := e.NewContext(req.WithContext(ctx), rec)
+	return rec, handler.CreateTimer()(c)
 }
 func deleteTimer(ctx context.Context, userId int64, timerId uuid.UUID) (*httptest.ResponseRecorder, error) {
 	req := httptest.NewRequest(http.MethodDelete, basePath("/:id"+"?vk_user_id="+fmt.Sprint(userId)), new(bytes.Buffer))
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := e.NewContext(req.WithContext(ctx), rec)
 	c.SetParamNames("id")
 	c.SetParamValues(fmt.Sprint(timerId))
-	return rec, handler.DeleteTimer(ctx)(c)
+	return rec, handler.DeleteTimer()(c)
 }
 
 func clearTimers(t *testing.T, ctx context.Context, timers ...*timermodel.Timer) {
@@ -123,10 +126,10 @@ func TestMain(m *testing.M) {
 	)
 	go ns.Start(ctx)
 
-	timerUseCase := timerusecase.New(ts, subst, timerService, es, ns)
+	timerUseCase := timerusecase.New(ts, subst, timerService, colorusecase.New(colorstorage.New(p), time.Minute), es, ns)
 	countdownUseCase := countdowntimerusecase.New(timerService, ts, es)
 
-	handler = timerhandler.New(countdownUseCase, timerUseCase)
+	handler = timerhandler.New(countdownUseCase, timerUseCase, nil)
 	timersocket.Init(e.Group(""), es, ns)
 
 	e.Use(middleware.Recover())
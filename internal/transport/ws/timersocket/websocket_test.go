@@ -3,7 +3,9 @@ package timersocket_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/Tap-Team/timerapi/internal/model/timerevent"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,3 +26,22 @@ func TestWebsocket(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestClockSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conn := NewConn(t, ctx, server, 1)
+	defer conn.Close()
+
+	clientSendTime := time.Now().UnixMilli()
+	err := conn.ws.WriteJSON(timerevent.ClientEvent{Type: timerevent.ClockSync, ClientSendTime: clientSendTime})
+	require.NoError(t, err, "send clock sync failed")
+
+	sync := new(timerevent.ClockSyncEvent)
+	err = conn.ws.ReadJSON(sync)
+	require.NoError(t, err, "read clock sync failed")
+	require.Equal(t, timerevent.ClockSync, sync.Type, "wrong event type")
+	require.Equal(t, clientSendTime, sync.ClientSendTime, "client send time not returned")
+	require.LessOrEqual(t, clientSendTime, sync.ServerReceiveTime, "wrong receive time")
+	require.LessOrEqual(t, sync.ServerReceiveTime, sync.ServerSendTime, "send time before receive time")
+}
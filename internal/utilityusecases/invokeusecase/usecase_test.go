@@ -142,10 +142,10 @@ func TestInvoke(t *testing.T) {
 	for _, timer := range timers {
 		timer := timer
 		if rand.Int63()%2 == 0 {
-			err := timerStorage.InsertDateTimer(ctx, creator, timer.CreateTimer())
+			err := timerStorage.InsertDateTimer(ctx, creator, timer.CreateTimer(), 0)
 			require.NoError(t, err, "failed insert date timer")
 		} else {
-			err := timerStorage.InsertCountdownTimer(ctx, creator, timer.CreateTimer())
+			err := timerStorage.InsertCountdownTimer(ctx, creator, timer.CreateTimer(), 0)
 			require.NoError(t, err, "failed insert date timer")
 		}
 		subs := make([]int64, 0, subamount)
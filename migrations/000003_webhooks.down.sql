@@ -0,0 +1,6 @@
+BEGIN;
+
+drop table if exists webhook_deliveries;
+drop table if exists webhooks;
+
+COMMIT;
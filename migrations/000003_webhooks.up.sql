@@ -0,0 +1,35 @@
+BEGIN;
+
+create table if not exists webhooks (
+    id uuid not null,
+    user_id bigint not null,
+    url text not null,
+    secret varchar(64) not null,
+    events varchar(30)[] not null,
+    is_enabled boolean not null default true,
+    failure_count int not null default 0,
+    created_at timestamp(0) not null default now(),
+
+    constraint webhooks_key primary key (id)
+);
+
+create index if not exists webhooks_user_id_idx on webhooks (user_id);
+
+create table if not exists webhook_deliveries (
+    id uuid not null,
+    webhook_id uuid not null,
+    event varchar(30) not null,
+    attempt int not null,
+    status_code int not null default 0,
+    error text,
+    is_success boolean not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint fk_webhook_deliveries__webhooks foreign key (webhook_id) references webhooks(id) on delete cascade,
+
+    constraint webhook_deliveries_key primary key (id, attempt)
+);
+
+create index if not exists webhook_deliveries_webhook_id_idx on webhook_deliveries (webhook_id, created_at);
+
+COMMIT;
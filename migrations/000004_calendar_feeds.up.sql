@@ -0,0 +1,12 @@
+BEGIN;
+
+create table if not exists calendar_feeds (
+    user_id bigint not null,
+    token varchar(64) not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint calendar_feeds_key primary key (user_id),
+    constraint calendar_feeds_token_unique unique (token)
+);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+create table if not exists user_erasures (
+    user_id bigint not null,
+    requested_at timestamp(0) not null default now(),
+    completed_at timestamp(0) default null,
+
+    constraint user_erasures_key primary key (user_id)
+);
+
+COMMIT;
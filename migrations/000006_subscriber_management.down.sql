@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM notifications WHERE notification_type_id = (SELECT id FROM notification_types WHERE type = 'notification_removed');
+
+DELETE FROM notification_types WHERE type = 'notification_removed';
+
+drop table if exists timer_bans;
+
+ALTER TABLE timers DROP COLUMN max_subscribers;
+
+ALTER TABLE timer_subcribers DROP COLUMN created_at;
+
+COMMIT;
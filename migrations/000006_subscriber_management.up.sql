@@ -0,0 +1,19 @@
+BEGIN;
+
+ALTER TABLE timer_subcribers ADD COLUMN created_at timestamp(0) not null default now();
+
+ALTER TABLE timers ADD COLUMN max_subscribers integer not null default 0;
+
+create table if not exists timer_bans (
+    timer_id uuid not null,
+    user_id bigint not null,
+    created_at timestamp(0) not null default now(),
+
+    constraint fk_timer_bans__timers foreign key (timer_id) references timers(id) on delete cascade,
+
+    constraint timer_bans_key primary key (timer_id, user_id)
+);
+
+INSERT INTO notification_types (type) VALUES ('notification_removed');
+
+COMMIT;
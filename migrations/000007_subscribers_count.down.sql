@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS timer_subcribers_count ON timer_subcribers;
+
+DROP FUNCTION IF EXISTS timer_subscribers_count();
+
+ALTER TABLE timers DROP COLUMN subscribers_count;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+ALTER TABLE timers ADD COLUMN subscribers_count integer not null default 0;
+
+UPDATE timers SET subscribers_count = (
+    SELECT count(*) FROM timer_subcribers
+    WHERE timer_subcribers.timer_id = timers.id AND timer_subcribers.user_id != timers.creator
+);
+
+-- subscribers count is kept denormalized for timer lists, creator isn't counted
+CREATE OR REPLACE FUNCTION timer_subscribers_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE timers SET subscribers_count = subscribers_count + 1 WHERE id = NEW.timer_id AND creator != NEW.user_id;
+        RETURN NEW;
+    END IF;
+    UPDATE timers SET subscribers_count = subscribers_count - 1 WHERE id = OLD.timer_id AND creator != OLD.user_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER timer_subcribers_count
+    AFTER INSERT OR DELETE ON timer_subcribers
+    FOR EACH ROW EXECUTE FUNCTION timer_subscribers_count();
+
+COMMIT;
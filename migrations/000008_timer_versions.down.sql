@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE timers DROP COLUMN version;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE timers ADD COLUMN version bigint not null default 1;
+
+COMMIT;
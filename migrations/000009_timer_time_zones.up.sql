@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE timers ADD COLUMN time_zone varchar(64) not null default 'UTC';
+
+-- legacy utc offsets in hours are moved to Etc/GMT zones, sign of Etc/GMT zones is inverted
+UPDATE timers SET time_zone = 'Etc/GMT' || CASE WHEN utc > 0 THEN '-' ELSE '+' END || abs(utc)
+WHERE utc != 0 AND utc BETWEEN -12 AND 14;
+
+COMMIT;
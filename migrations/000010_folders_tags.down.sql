@@ -0,0 +1,9 @@
+BEGIN;
+
+drop table if exists timer_tags;
+
+drop table if exists timer_placements;
+
+drop table if exists folders;
+
+COMMIT;
@@ -0,0 +1,44 @@
+BEGIN;
+
+create table if not exists folders (
+    id uuid not null,
+    user_id bigint not null,
+    name varchar(60) not null,
+    position integer not null default 0,
+    created_at timestamp(0) not null default now(),
+
+    constraint folders_key primary key (id)
+);
+
+create index if not exists folders_user_id_idx on folders (user_id);
+
+-- placement of timer in user timer lists, row exists only while user is subscriber (creator inclusive)
+create table if not exists timer_placements (
+    timer_id uuid not null,
+    user_id bigint not null,
+    folder_id uuid,
+    position integer,
+    is_pinned boolean not null default false,
+
+    constraint fk_timer_placements__timer_subcribers foreign key (timer_id, user_id) references timer_subcribers(timer_id, user_id) on delete cascade,
+    constraint fk_timer_placements__folders foreign key (folder_id) references folders(id) on delete set null,
+
+    constraint timer_placements_key primary key (timer_id, user_id)
+);
+
+create index if not exists timer_placements_folder_id_idx on timer_placements (folder_id);
+
+-- tags are private, every user tags timers only for himself
+create table if not exists timer_tags (
+    timer_id uuid not null,
+    user_id bigint not null,
+    tag varchar(32) not null,
+
+    constraint fk_timer_tags__timer_subcribers foreign key (timer_id, user_id) references timer_subcribers(timer_id, user_id) on delete cascade,
+
+    constraint timer_tags_key primary key (timer_id, user_id, tag)
+);
+
+create index if not exists timer_tags_user_id_tag_idx on timer_tags (user_id, tag);
+
+COMMIT;
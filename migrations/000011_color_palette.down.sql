@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE timers DROP COLUMN custom_gradient;
+ALTER TABLE timers DROP COLUMN custom_color;
+
+ALTER TABLE colors DROP COLUMN position;
+ALTER TABLE colors DROP COLUMN gradient;
+ALTER TABLE colors DROP COLUMN hex;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- palette is data driven, colors are added without deploy
+ALTER TABLE colors ADD COLUMN hex varchar(7) not null default '#818C99';
+ALTER TABLE colors ADD COLUMN gradient varchar(7);
+ALTER TABLE colors ADD COLUMN position smallint not null default 0;
+
+UPDATE colors SET hex = '#818C99', position = 0 WHERE color = 'DEFAULT';
+UPDATE colors SET hex = '#E64646', position = 1 WHERE color = 'RED';
+UPDATE colors SET hex = '#4BB34B', position = 2 WHERE color = 'GREEN';
+UPDATE colors SET hex = '#3F8AE0', position = 3 WHERE color = 'BLUE';
+UPDATE colors SET hex = '#792EC0', position = 4 WHERE color = 'PURPLE';
+UPDATE colors SET hex = '#FFA000', position = 5 WHERE color = 'YELLOW';
+
+-- custom color of timer, color_id is kept as fallback for clients which don't know custom colors
+ALTER TABLE timers ADD COLUMN custom_color varchar(7);
+ALTER TABLE timers ADD COLUMN custom_gradient varchar(7);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE timer_subcribers DROP COLUMN volume;
+ALTER TABLE timer_subcribers DROP COLUMN sound_id;
+
+UPDATE timers SET with_music = sound_id IS NOT NULL;
+ALTER TABLE timers DROP COLUMN volume;
+ALTER TABLE timers DROP COLUMN sound_id;
+
+drop table if exists sounds;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+-- catalog of alarm sounds, url is path of asset on static host of app
+create table if not exists sounds (
+    id smallserial primary key,
+    title varchar(60) not null,
+    duration integer not null,
+    url varchar(255) not null,
+    position smallint not null default 0
+);
+
+INSERT INTO sounds (id, title, duration, url, position) VALUES
+    (1, 'Classic', 12, '/sounds/classic.mp3', 0),
+    (2, 'Bell', 8, '/sounds/bell.mp3', 1),
+    (3, 'Chime', 6, '/sounds/chime.mp3', 2),
+    (4, 'Digital', 10, '/sounds/digital.mp3', 3),
+    (5, 'Birds', 15, '/sounds/birds.mp3', 4);
+SELECT setval(pg_get_serial_sequence('sounds', 'id'), (SELECT max(id) FROM sounds));
+
+-- sound of timer, NULL if timer is silent, with_music is kept for old clients
+ALTER TABLE timers ADD COLUMN sound_id smallint;
+ALTER TABLE timers ADD COLUMN volume smallint not null default 100;
+ALTER TABLE timers ADD CONSTRAINT fk_timers__sounds foreign key (sound_id) references sounds(id) on delete set null;
+
+-- timers with music play classic sound which was the only sound
+UPDATE timers SET sound_id = 1 WHERE with_music;
+
+-- sound picked by subscriber instead of timer sound
+ALTER TABLE timer_subcribers ADD COLUMN sound_id smallint;
+ALTER TABLE timer_subcribers ADD COLUMN volume smallint;
+ALTER TABLE timer_subcribers ADD CONSTRAINT fk_timer_subcribers__sounds foreign key (sound_id) references sounds(id) on delete set null;
+
+COMMIT;
@@ -121,6 +121,13 @@ func (e *AmidException) Unwrap() error {
 	return e.err
 }
 
+// causes in order of wrapping, from the deepest call
+func (e *AmidException) Causes() []Cause {
+	causes := make([]Cause, len(e.causes))
+	copy(causes, e.causes)
+	return causes
+}
+
 func Wrap(err error, cause Cause) Exception {
 	switch err := err.(type) {
 	case *AmidException:
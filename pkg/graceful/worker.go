@@ -0,0 +1,44 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+)
+
+// goroutines which are drained on shutdown
+// context of goroutines isn't canceled when intake stops, it is canceled only if drain deadline is exceeded
+type Worker struct {
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewWorker() *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{ctx: ctx, cancel: cancel}
+}
+
+// run f in new goroutine with context of worker
+func (w *Worker) Go(f func(ctx context.Context)) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		f(w.ctx)
+	}()
+}
+
+// wait for goroutines, if ctx is done before context of goroutines is canceled and ctx error is returned
+func (w *Worker) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		return ctx.Err()
+	}
+}
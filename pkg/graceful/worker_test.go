@@ -0,0 +1,45 @@
+package graceful_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Tap-Team/timerapi/pkg/graceful"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrain(t *testing.T) {
+	worker := graceful.NewWorker()
+	var done atomic.Int32
+	for i := 0; i < 10; i++ {
+		worker.Go(func(ctx context.Context) {
+			time.Sleep(time.Millisecond * 20)
+			// nested goroutines are drained too
+			worker.Go(func(ctx context.Context) { done.Add(1) })
+			done.Add(1)
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, worker.Drain(ctx), "drain failed")
+	require.Equal(t, int32(20), done.Load(), "goroutines not finished")
+}
+
+func TestDrainDeadline(t *testing.T) {
+	worker := graceful.NewWorker()
+	canceled := make(chan struct{})
+	worker.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(canceled)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	require.ErrorIs(t, worker.Drain(ctx), context.DeadlineExceeded, "wrong drain error")
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("context of goroutine not canceled after deadline")
+	}
+}
@@ -0,0 +1,240 @@
+// minimal RFC 5545 encoder, only properties used by timer export are supported
+package ical
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateTimeLayout = "20060102T150405"
+	// max line length in octets without CRLF
+	lineLength = 75
+)
+
+type Alarm struct {
+	// trigger relative to event end, negative value is before end
+	Trigger     time.Duration
+	Description string
+}
+
+type Event struct {
+	UID   string
+	Stamp time.Time
+	Start time.Time
+	// zero End means event without duration
+	End         time.Time
+	Summary     string
+	Description string
+	// css3 color name
+	Color  string
+	Alarms []Alarm
+}
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	// zero value means calendar apps use their own refresh interval
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+func (w *writer) line(name, value string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, fold(name+":"+value)+"\r\n")
+}
+
+// fold content line by 75 octets, utf-8 sequences are never split
+func fold(line string) string {
+	if len(line) <= lineLength {
+		return line
+	}
+	var b strings.Builder
+	size := 0
+	limit := lineLength
+	for _, r := range line {
+		rl := len(string(r))
+		if size+rl > limit {
+			b.WriteString("\r\n ")
+			size = 0
+			// leading space is part of the line
+			limit = lineLength - 1
+		}
+		b.WriteRune(r)
+		size += rl
+	}
+	return b.String()
+}
+
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout) + "Z"
+}
+
+// time zone id of location, fixed zones are named by offset, e.g. UTC+03:00
+func tzid(loc *time.Location) string {
+	name := loc.String()
+	if name != "" && name != "Local" {
+		return name
+	}
+	_, offset := time.Now().In(loc).Zone()
+	return "UTC" + formatOffset(offset, true)
+}
+
+func formatOffset(offset int, colon bool) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	if colon {
+		return fmt.Sprintf("%s%02d:%02d", sign, offset/3600, offset%3600/60)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+func (w *writer) dateTime(name string, t time.Time) {
+	if t.Location() == time.UTC {
+		w.line(name, utc(t))
+		return
+	}
+	w.line(name+";TZID="+tzid(t.Location()), t.Format(dateTimeLayout))
+}
+
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	d = d.Truncate(time.Second)
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d == 0 {
+		return b.String()
+	}
+	b.WriteString("T")
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d -= m * time.Minute
+	}
+	if s := d / time.Second; s > 0 {
+		fmt.Fprintf(&b, "%dS", s)
+	}
+	return b.String()
+}
+
+// write every time zone used by events, zones are supposed to have fixed offset
+func (w *writer) timezones(events []Event) {
+	zones := make(map[string]*time.Location)
+	for _, e := range events {
+		for _, t := range []time.Time{e.Start, e.End} {
+			if t.IsZero() || t.Location() == time.UTC {
+				continue
+			}
+			zones[tzid(t.Location())] = t.Location()
+		}
+	}
+	ids := make([]string, 0, len(zones))
+	for id := range zones {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		_, offset := time.Now().In(zones[id]).Zone()
+		w.line("BEGIN", "VTIMEZONE")
+		w.line("TZID", id)
+		w.line("BEGIN", "STANDARD")
+		w.line("DTSTART", "19700101T000000")
+		w.line("TZOFFSETFROM", formatOffset(offset, false))
+		w.line("TZOFFSETTO", formatOffset(offset, false))
+		w.line("TZNAME", id)
+		w.line("END", "STANDARD")
+		w.line("END", "VTIMEZONE")
+	}
+}
+
+func (w *writer) event(e *Event) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	w.line("DTSTAMP", utc(e.Stamp))
+	w.dateTime("DTSTART", e.Start)
+	if !e.End.IsZero() {
+		w.dateTime("DTEND", e.End)
+	}
+	w.line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escape(e.Description))
+	}
+	if e.Color != "" {
+		w.line("COLOR", e.Color)
+	}
+	for _, a := range e.Alarms {
+		w.line("BEGIN", "VALARM")
+		w.line("ACTION", "DISPLAY")
+		w.line("TRIGGER;RELATED=END", formatDuration(a.Trigger))
+		w.line("DESCRIPTION", escape(a.Description))
+		w.line("END", "VALARM")
+	}
+	w.line("END", "VEVENT")
+}
+
+func (c *Calendar) Encode(out io.Writer) error {
+	w := &writer{w: out}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProdID)
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		w.line("NAME", escape(c.Name))
+		w.line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		w.line("REFRESH-INTERVAL;VALUE=DURATION", formatDuration(c.RefreshInterval))
+		w.line("X-PUBLISHED-TTL", formatDuration(c.RefreshInterval))
+	}
+	w.timezones(c.Events)
+	for i := range c.Events {
+		w.event(&c.Events[i])
+	}
+	w.line("END", "VCALENDAR")
+	return w.err
+}
+
+func (c *Calendar) Bytes() ([]byte, error) {
+	var b strings.Builder
+	err := c.Encode(&b)
+	return []byte(b.String()), err
+}
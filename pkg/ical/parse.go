@@ -0,0 +1,194 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const dateLayout = "20060102"
+
+var (
+	ErrNotCalendar = errors.New("ical: input is not VCALENDAR")
+	ErrNoStart     = errors.New("ical: event without DTSTART")
+	ErrWrongTime   = errors.New("ical: wrong date-time value")
+)
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// unfold content lines, CRLF followed by space or tab continues previous line
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func parseProperty(line string) (property, bool) {
+	// value starts after first colon which is not inside quoted param value
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, false
+	}
+	parts := strings.Split(line[:colon], ";")
+	p := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return p, true
+}
+
+func unescape(s string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(s)
+}
+
+// parse DATE or DATE-TIME value, floating time and unknown TZID are read in def location
+func parseTime(p property, def *time.Location) (t time.Time, allDay bool, err error) {
+	value := p.value
+	if p.params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err = time.ParseInLocation(dateLayout, value, def)
+		if err != nil {
+			return t, true, fmt.Errorf("%w %q", ErrWrongTime, value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.ParseInLocation(dateTimeLayout, strings.TrimSuffix(value, "Z"), time.UTC)
+		if err != nil {
+			return t, false, fmt.Errorf("%w %q", ErrWrongTime, value)
+		}
+		return t, false, nil
+	}
+	loc := def
+	if tzid, ok := p.params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err = time.ParseInLocation(dateTimeLayout, value, loc)
+	if err != nil {
+		return t, false, fmt.Errorf("%w %q", ErrWrongTime, value)
+	}
+	return t, false, nil
+}
+
+// parsed VEVENT, Err is set if event can't be read
+type ParsedEvent struct {
+	Event
+	AllDay bool
+	// raw recurrence rule, empty for single events
+	RRule string
+	Err   error
+}
+
+// parse every VEVENT of calendar, floating times are read in def location
+// nested components of events (alarms) are ignored
+func Parse(r io.Reader, def *time.Location) ([]ParsedEvent, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, ErrNotCalendar
+	}
+	events := make([]ParsedEvent, 0)
+	var (
+		current  *ParsedEvent
+		hasStart bool
+		// depth of components nested into event
+		depth int
+	)
+	for _, line := range lines {
+		p, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT") && current == nil:
+			current = new(ParsedEvent)
+			hasStart = false
+			continue
+		case current == nil:
+			continue
+		case p.name == "BEGIN":
+			depth++
+			continue
+		case p.name == "END" && depth > 0:
+			depth--
+			continue
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT"):
+			if !hasStart && current.Err == nil {
+				current.Err = ErrNoStart
+			}
+			events = append(events, *current)
+			current = nil
+			continue
+		case depth > 0:
+			continue
+		}
+		switch p.name {
+		case "UID":
+			current.UID = p.value
+		case "SUMMARY":
+			current.Summary = unescape(p.value)
+		case "DESCRIPTION":
+			current.Description = unescape(p.value)
+		case "RRULE":
+			current.RRule = p.value
+		case "DTSTART":
+			current.Start, current.AllDay, err = parseTime(p, def)
+			if err != nil {
+				current.Err = err
+			}
+			hasStart = true
+		case "DTEND":
+			current.End, _, err = parseTime(p, def)
+			if err != nil {
+				current.Err = err
+			}
+		}
+	}
+	return events, nil
+}
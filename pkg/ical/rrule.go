@@ -0,0 +1,123 @@
+package ical
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedRule = errors.New("ical: unsupported recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// recurrence rule, BY* parts are not supported and ignored, only FREQ, INTERVAL, COUNT and UNTIL are used
+type RRule struct {
+	Freq     Frequency
+	Interval int
+	// zero means unlimited
+	Count int
+	// zero means unlimited
+	Until time.Time
+	// true if rule has BY* parts which were ignored
+	Approximate bool
+}
+
+func ParseRRule(s string) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(v))
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, ErrUnsupportedRule
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, ErrUnsupportedRule
+			}
+			rule.Count = n
+		case "UNTIL":
+			t, _, err := parseTime(property{value: v, params: map[string]string{}}, time.UTC)
+			if err != nil {
+				return nil, ErrUnsupportedRule
+			}
+			rule.Until = t
+		case "WKST":
+		default:
+			if strings.HasPrefix(strings.ToUpper(k), "BY") {
+				rule.Approximate = true
+			}
+		}
+	}
+	switch rule.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+		return rule, nil
+	default:
+		return nil, ErrUnsupportedRule
+	}
+}
+
+func (r *RRule) occurrence(start time.Time, n int) time.Time {
+	step := n * r.Interval
+	switch r.Freq {
+	case Daily:
+		return start.AddDate(0, 0, step)
+	case Weekly:
+		return start.AddDate(0, 0, step*7)
+	case Monthly:
+		return start.AddDate(0, step, 0)
+	default:
+		return start.AddDate(step, 0, 0)
+	}
+}
+
+// first occurrence of rule after t, false if rule has ended
+func (r *RRule) Next(start, after time.Time) (time.Time, bool) {
+	n := 0
+	// skip whole periods without iterating from start, period is upper bound so no occurrence is skipped
+	if after.After(start) {
+		var period time.Duration
+		switch r.Freq {
+		case Daily:
+			period = 24 * time.Hour
+		case Weekly:
+			period = 7 * 24 * time.Hour
+		case Monthly:
+			period = 31 * 24 * time.Hour
+		default:
+			period = 366 * 24 * time.Hour
+		}
+		n = int(after.Sub(start)/(period*time.Duration(r.Interval))) - 1
+		if n < 0 {
+			n = 0
+		}
+	}
+	for ; ; n++ {
+		if r.Count > 0 && n >= r.Count {
+			return time.Time{}, false
+		}
+		t := r.occurrence(start, n)
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return time.Time{}, false
+		}
+		if t.After(after) {
+			return t, true
+		}
+	}
+}
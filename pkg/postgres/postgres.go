@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,6 +19,7 @@ type Postgres struct {
 	maxPoolSize int
 	connAttemps int
 	connTimeout time.Duration
+	tracer      pgx.QueryTracer
 	Pool        *pgxpool.Pool
 }
 
@@ -38,6 +40,9 @@ func New(url string, options ...Option) (*Postgres, error) {
 	}
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize)
+	if pg.tracer != nil {
+		poolConfig.ConnConfig.Tracer = pg.tracer
+	}
 
 	for pg.connAttemps > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
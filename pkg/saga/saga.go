@@ -1,35 +1,97 @@
 package saga
 
 import (
+	"context"
 	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Saga struct {
 	mu    sync.Mutex
-	queue []func()
+	queue []func() error
 	ok    bool
+	name  string
+	// ctx of saga, span of ctx gets rollback and compensation events
+	ctx context.Context
+}
+
+var (
+	observerMu sync.RWMutex
+	observer   func(name string)
+	// called when compensation of rollback fails
+	errObserver func(ctx context.Context, name string, step int, err error)
+)
+
+// set function which is called with saga name every time registered rollbacks are executed
+func OnRollback(f func(name string)) {
+	observerMu.Lock()
+	observer = f
+	observerMu.Unlock()
+}
+
+// set function which is called with ctx of saga when compensation returns error
+// step is index of compensation in order of registration
+func OnCompensationError(f func(ctx context.Context, name string, step int, err error)) {
+	observerMu.Lock()
+	errObserver = f
+	observerMu.Unlock()
+}
+
+// named saga, name is passed to rollback observer
+func New(name string) *Saga {
+	return &Saga{name: name, ctx: context.Background()}
+}
+
+// named saga which records rollback and every compensation as events of span from ctx
+func NewContext(ctx context.Context, name string) *Saga {
+	return &Saga{name: name, ctx: ctx}
 }
 
 func (s *Saga) Rollback() {
 	s.mu.Lock()
-	if s.ok {
+	defer s.mu.Unlock()
+	if s.ok || len(s.queue) == 0 {
 		return
 	}
+	observerMu.RLock()
+	if observer != nil {
+		observer(s.name)
+	}
+	observerMu.RUnlock()
+	span := trace.SpanFromContext(s.ctx)
+	span.AddEvent("saga.rollback", trace.WithAttributes(
+		attribute.String("saga.name", s.name),
+		attribute.Int("saga.compensations", len(s.queue)),
+	))
 	for len(s.queue) != 0 {
-		s.queue[len(s.queue)-1]()
-		s.queue = s.queue[:len(s.queue)-1]
+		step := len(s.queue) - 1
+		span.AddEvent("saga.compensation", trace.WithAttributes(
+			attribute.String("saga.name", s.name),
+			attribute.Int("saga.step", step),
+		))
+		if err := s.queue[step](); err != nil {
+			span.RecordError(err, trace.WithAttributes(attribute.Int("saga.step", step)))
+			observerMu.RLock()
+			if errObserver != nil {
+				errObserver(s.ctx, s.name, step, err)
+			}
+			observerMu.RUnlock()
+		}
+		s.queue = s.queue[:step]
 	}
-	s.mu.Unlock()
 }
 
 func (s *Saga) OK() {
 	s.mu.Lock()
 	s.ok = true
-	s.queue = []func(){}
+	s.queue = []func() error{}
 	s.mu.Unlock()
 }
 
-func (s *Saga) Register(f func()) {
+// register compensation, error of compensation is passed to compensation error observer
+func (s *Saga) Register(f func() error) {
 	s.mu.Lock()
 	s.queue = append(s.queue, f)
 	s.mu.Unlock()
@@ -0,0 +1,60 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tap-Team/timerapi/pkg/saga"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRollback(t *testing.T) {
+	rollbacks := make([]string, 0)
+	saga.OnRollback(func(name string) { rollbacks = append(rollbacks, name) })
+	defer saga.OnRollback(nil)
+
+	order := make([]int, 0)
+	failed := saga.New("failed")
+	failed.Register(func() error { order = append(order, 1); return nil })
+	failed.Register(func() error { order = append(order, 2); return nil })
+	failed.Rollback()
+	require.Equal(t, []int{2, 1}, order, "wrong rollback order")
+
+	ok := saga.New("ok")
+	ok.Register(func() error { order = append(order, 3); return nil })
+	ok.OK()
+	ok.Rollback()
+
+	empty := saga.New("empty")
+	empty.Rollback()
+
+	require.Equal(t, []int{2, 1}, order, "rollback of ok saga executed")
+	require.Equal(t, []string{"failed"}, rollbacks, "wrong observed rollbacks")
+}
+
+func TestRollbackEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("saga_test").Start(context.Background(), "usecase")
+
+	s := saga.NewContext(ctx, "usecase")
+	s.Register(func() error { return nil })
+	s.Register(func() error { return errors.New("compensation failed") })
+	failedSteps := make([]int, 0)
+	saga.OnCompensationError(func(ctx context.Context, name string, step int, err error) { failedSteps = append(failedSteps, step) })
+	defer saga.OnCompensationError(nil)
+	s.Rollback()
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1, "wrong number of spans")
+	names := make([]string, 0)
+	for _, event := range spans[0].Events() {
+		names = append(names, event.Name)
+	}
+	require.Equal(t, []string{"saga.rollback", "saga.compensation", "exception", "saga.compensation"}, names, "wrong span events")
+	require.Equal(t, []int{1}, failedSteps, "wrong failed compensations")
+}